@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"takeaway/takeaway-server/internal/config"
+
+	"gopkg.in/yaml.v2"
+)
+
+// runConfig runs the config subcommand, writing the given configuration to out as YAML with its secrets redacted, e.g. 'takeaway-server -config prod.yaml config print'. An
+// error is returned after the configuration is written should it not be valid, allowing a configuration to be checked without starting the server.
+func runConfig(args []string, c *config.Config, out io.Writer) error {
+	if len(args) != 1 || args[0] != "print" {
+		return fmt.Errorf("usage: config print")
+	}
+
+	b, err := yaml.Marshal(c.Redact())
+	if err != nil {
+		return err
+	}
+	if _, err = out.Write(b); err != nil {
+		return err
+	}
+	return c.Validate()
+}
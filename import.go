@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"takeaway/takeaway-server/internal/catalogue"
+)
+
+// runImport runs the import subcommand, importing the restaurants within the file named by the given arguments into the catalogue and writing the import's report to out. The
+// catalogue must have been initialised, using the same data sources the server would.
+func runImport(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "format of the file, one of csv, json, geojson or vcard. Detected from the file's extension should it be omitted.")
+	ws := fs.String("workspace", "", "workspace whose catalogue the restaurants are imported into, defaulting to the default workspace.")
+	dryRun := fs.Bool("dryRun", false, "report what would be imported without changing the catalogue.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: import [-format csv|json|geojson|vcard] [-workspace id] [-dryRun] file")
+	}
+
+	name := fs.Arg(0)
+	f := catalogue.Format(*format)
+	if f == "" {
+		var ok bool
+		if f, ok = catalogue.DetectFormat(name, ""); !ok {
+			return fmt.Errorf("could not detect the format of %s, specify one using -format", name)
+		}
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := catalogue.ParseRestaurants(f, file)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %s", name, err.Error())
+	}
+
+	report, _, err := catalogue.Import(*ws, rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range report.Rows {
+		fmt.Fprintf(out, "row %d\t%s\t%s\t%s\t%s\n", r.Row, r.Action, r.Name, r.ID, r.Reason)
+	}
+	if report.DryRun {
+		fmt.Fprint(out, "dry run, the catalogue has not been changed: ")
+	}
+	fmt.Fprintf(out, "%d created, %d updated, %d skipped\n", report.Created, report.Updated, report.Skipped)
+	return nil
+}
@@ -0,0 +1,28 @@
+package caller
+
+import "context"
+
+type key int
+
+const (
+	workspaceKey key = iota
+	userKey
+)
+
+// NewContext returns a copy of the given context recording the workspace and user a request was made by.
+func NewContext(ctx context.Context, workspace string, user string) context.Context {
+	ctx = context.WithValue(ctx, workspaceKey, workspace)
+	return context.WithValue(ctx, userKey, user)
+}
+
+// Workspace returns the workspace recorded within the given context, returning the default workspace, represented by an empty string, should none be recorded.
+func Workspace(ctx context.Context) string {
+	w, _ := ctx.Value(workspaceKey).(string)
+	return w
+}
+
+// User returns the user recorded within the given context, returning an empty string should no user be recorded.
+func User(ctx context.Context) string {
+	u, _ := ctx.Value(userKey).(string)
+	return u
+}
@@ -0,0 +1,251 @@
+package catalogue
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// The similarity restaurants must reach to be treated as the same restaurant when importing, names and addresses being compared once normalised.
+const (
+	NameSimilarity    = 0.85
+	AddressSimilarity = 0.8
+)
+
+// ImportAction states what an import did with a single row.
+type ImportAction string
+
+const (
+	// Created rows were added to the catalogue as new restaurants.
+	Created ImportAction = "created"
+	// Updated rows matched an existing restaurant, which was updated with the row's details.
+	Updated ImportAction = "updated"
+	// Skipped rows were either invalid or matched an existing restaurant without changing it.
+	Skipped ImportAction = "skipped"
+)
+
+// RowResult reports what an import did with a single row, ID being the ID of the created or matched restaurant and Reason explaining why the row was skipped.
+type RowResult struct {
+	Row    int          `json:"row"`
+	Name   string       `json:"name"`
+	Action ImportAction `json:"action"`
+	ID     string       `json:"id,omitempty"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// ImportReport reports the outcome of an import, counting the rows created, updated and skipped along with the result of every row. Dry runs report what would have happened
+// without changing the catalogue.
+type ImportReport struct {
+	DryRun  bool         `json:"dryRun"`
+	Created int          `json:"created"`
+	Updated int          `json:"updated"`
+	Skipped int          `json:"skipped"`
+	Rows    []*RowResult `json:"rows"`
+}
+
+func (r *ImportReport) add(row *RowResult) {
+	switch row.Action {
+	case Created:
+		r.Created++
+	case Updated:
+		r.Updated++
+	case Skipped:
+		r.Skipped++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// Import adds the given rows to the catalogue of the given workspace. Rows matching a restaurant already within the catalogue, or an earlier row, by name and address similarity
+// update said restaurant with the details they give rather than creating a duplicate, while invalid rows are skipped. Restaurants without a location are located using the
+// injected Geocoder. Should dryRun be set the report is produced without changing the catalogue.
+func Import(workspace string, rows []*ImportRow, dryRun bool) (report *ImportReport, status vote.Status, err error) {
+	existing, status, err := instance.Model.GetRestaurants(workspace)
+	if err != nil {
+		return
+	}
+
+	report = &ImportReport{DryRun: dryRun, Rows: make([]*RowResult, 0, len(rows))}
+	for _, row := range rows {
+		b := row.Restaurant
+		result := &RowResult{Row: row.Row, Name: b.Name, Action: Skipped}
+		if result.Reason = row.Err; result.Reason == "" {
+			result.Reason = checkImported(b)
+		}
+		if result.Reason != "" {
+			report.add(result)
+			continue
+		}
+
+		b.Workspace = workspace
+		if i := findSimilar(existing, b); i >= 0 {
+			match := existing[i]
+			result.ID = match.ID
+			merged := merge(match, b)
+			if merged == nil {
+				result.Reason = fmt.Sprintf("matches %s without changing it", match.Name)
+				report.add(result)
+				continue
+			}
+
+			if !dryRun {
+				if status, err = instance.Model.UpdateRestaurant(merged); err != nil {
+					return
+				}
+				vote.RefreshRestaurant(workspace, merged)
+			}
+			// later rows are matched against the updated restaurant, without changing the restaurant returned by the model.
+			existing[i] = merged
+			result.Action = Updated
+			report.add(result)
+			continue
+		}
+
+		if b.Location == nil && b.Address != "" && instance.Geocoder != nil {
+			if b.Location, err = instance.Geocoder.Geocode(b.Address); err != nil {
+				log.Printf("Could not locate imported restaurant %s due to: %s\n", b.Name, err.Error())
+				err = nil
+			}
+		}
+
+		b.ID = ""
+		if !dryRun {
+			if status, err = instance.Model.NewRestaurant(b); err != nil {
+				return
+			}
+		}
+		existing = append(existing, b)
+		result.ID = b.ID
+		result.Action = Created
+		report.add(result)
+	}
+	return
+}
+
+// checkImported returns why the given restaurant, whether imported or added through the catalogue's handlers, cannot be added to the catalogue, being empty should it be valid.
+func checkImported(b *restaurant.Building) string {
+	switch {
+	case strings.TrimSpace(b.Name) == "":
+		return "name must not be empty"
+	case len(b.Name) > vote.MaxNameLength:
+		return fmt.Sprintf("name must not be longer than %d characters", vote.MaxNameLength)
+	case len(b.Address) > vote.MaxAddressLength:
+		return fmt.Sprintf("address must not be longer than %d characters", vote.MaxAddressLength)
+	case b.Location != nil && (b.Location.Lat < -90 || b.Location.Lat > 90 || b.Location.Lng < -180 || b.Location.Lng > 180):
+		return "location must be a valid latitude and longitude"
+	case b.DeliveryRadius < 0 || b.DeliveryRadius > vote.MaxDeliveryRadius:
+		return fmt.Sprintf("deliveryRadius must be between 0 and %d metres", vote.MaxDeliveryRadius)
+	}
+
+	switch b.Service {
+	case "", restaurant.Delivery, restaurant.Collection, restaurant.DeliveryAndCollection:
+	default:
+		return fmt.Sprintf("service must be one of %s, %s or %s", restaurant.Delivery, restaurant.Collection, restaurant.DeliveryAndCollection)
+	}
+	return ""
+}
+
+// findSimilar returns the index of the first of the given restaurants similar enough to b to be the same restaurant, or -1 should there be none. Restaurants are compared by name
+// and, should both give one, address.
+func findSimilar(restaurants []*restaurant.Building, b *restaurant.Building) int {
+	for i, r := range restaurants {
+		if Similarity(r.Name, b.Name) < NameSimilarity {
+			continue
+		}
+		if r.Address == "" || b.Address == "" || Similarity(r.Address, b.Address) >= AddressSimilarity {
+			return i
+		}
+	}
+	return -1
+}
+
+// merge returns a copy of the existing restaurant updated with the details given by the imported restaurant, returning nil should the import not change the restaurant.
+// Details the import leaves blank keep their existing value, as do details only differing by case or punctuation.
+func merge(existing *restaurant.Building, imported *restaurant.Building) *restaurant.Building {
+	m := existing.Copy()
+	changed := false
+	set := func(dst *string, src string) {
+		if src != "" && normalise(*dst) != normalise(src) {
+			*dst, changed = src, true
+		}
+	}
+
+	set(&m.Address, imported.Address)
+	set(&m.TimeZone, imported.TimeZone)
+	if imported.Service != "" && m.Service != imported.Service {
+		m.Service, changed = imported.Service, true
+	}
+	if imported.Location != nil && (m.Location == nil || *m.Location != *imported.Location) {
+		l := *imported.Location
+		m.Location, changed = &l, true
+	}
+	if imported.DeliveryRadius != 0 && m.DeliveryRadius != imported.DeliveryRadius {
+		m.DeliveryRadius, changed = imported.DeliveryRadius, true
+	}
+	if len(imported.Hours) > 0 {
+		m.Hours, changed = imported.Copy().Hours, true
+	}
+	if len(imported.Exceptions) > 0 {
+		m.Exceptions, changed = imported.Copy().Exceptions, true
+	}
+
+	if !changed {
+		return nil
+	}
+	return m
+}
+
+// Similarity returns how alike two names or addresses are, between 0 for nothing in common and 1 for identical, once both are normalised by ignoring case, punctuation and
+// repeated whitespace, along with the word 'the'. The similarity is based on the edit distance between the normalised strings.
+func Similarity(a string, b string) float64 {
+	x, y := []rune(normalise(a)), []rune(normalise(b))
+	longest := len(x)
+	if len(y) > longest {
+		longest = len(y)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(x, y))/float64(longest)
+}
+
+func normalise(s string) string {
+	words := make([]string, 0)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if w != "the" {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// editDistance returns the Levenshtein distance between x and y, being the number of single character insertions, deletions and substitutions needed to turn x into y.
+func editDistance(x []rune, y []rune) int {
+	prev := make([]int, len(y)+1)
+	cur := make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		cur[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if d := prev[j] + 1; d < cur[j] {
+				cur[j] = d
+			}
+			if d := cur[j-1] + 1; d < cur[j] {
+				cur[j] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(y)]
+}
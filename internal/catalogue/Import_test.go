@@ -0,0 +1,91 @@
+package catalogue
+
+import (
+	"strings"
+	"testing"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+func TestParseRestaurants(t *testing.T) {
+	for _, c := range []struct {
+		format Format
+		file   string
+	}{
+		{CSV, "Address,Name,Lat,Lng,Service\n\"1 High St, London\",Pizza Place,51.5,-0.12,Delivery\n"},
+		{JSON, `[{"name": "Pizza Place", "address": "1 High St, London", "location": {"lat": 51.5, "lng": -0.12}, "service": "delivery"}]`},
+		{GeoJSON, `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-0.12, 51.5]},
+			"properties": {"name": "Pizza Place", "address": "1 High St, London", "service": "delivery"}}]}`},
+		{VCard, "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Pizza Place\r\nADR;TYPE=work:;;1 High St;London\r\nGEO:geo:51.5,-0.12\r\nEND:VCARD\r\n"},
+	} {
+		rows, err := ParseRestaurants(c.format, strings.NewReader(c.file))
+		if err != nil || len(rows) != 1 {
+			t.Logf("Expected a single %s row, got %v %v", c.format, rows, err)
+			t.Fail()
+			continue
+		}
+
+		b := rows[0].Restaurant
+		if rows[0].Err != "" || b.Name != "Pizza Place" || b.Address != "1 High St, London" || b.Location == nil || b.Location.Lat != 51.5 || b.Location.Lng != -0.12 {
+			t.Logf("Expected the %s row to be read, got %+v %q", c.format, b, rows[0].Err)
+			t.Fail()
+		}
+		if c.format != VCard && b.Service != restaurant.Delivery {
+			t.Logf("Expected the %s row's service to be read, got %q", c.format, b.Service)
+			t.Fail()
+		}
+	}
+
+	if _, err := ParseRestaurants(CSV, strings.NewReader("address\n1 High St\n")); err == nil {
+		t.Log("Expected a CSV file without a name column to be rejected")
+		t.Fail()
+	}
+}
+
+func TestImportDeduplicates(t *testing.T) {
+	model := &MockRestaurantModel{}
+	Init(&Container{Model: model})
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}})
+	model.NewRestaurant(&restaurant.Building{Name: "Pizza Place", Address: "1 High Street"})
+
+	rows, _ := ParseRestaurants(CSV, strings.NewReader(strings.Join([]string{
+		"name,address,deliveryRadius,lat,lng",
+		"Pizza Place,1 High Street.,2000,,",
+		"The Curry House,5 Low Road,,,",
+		"Curry House,5 Low Road,,,",
+		"pizza place,1 high street,,,",
+		",No Name,,,",
+		"Bad Location,,,91,0",
+	}, "\n")))
+
+	report, _, err := Import("", rows, false)
+	if err != nil || report.Created != 1 || report.Updated != 1 || report.Skipped != 4 {
+		t.Logf("Expected 1 created, 1 updated and 4 skipped, got %+v %v", report, err)
+		t.FailNow()
+	}
+
+	restaurants, _, _ := model.GetRestaurants("")
+	if len(restaurants) != 2 || restaurants[0].DeliveryRadius != 2000 || restaurants[0].Address != "1 High Street" {
+		t.Logf("Expected the existing restaurant to be updated, got %+v", restaurants[0])
+		t.Fail()
+	}
+	if r := report.Rows[2]; r.Action != Skipped || r.ID != restaurants[1].ID {
+		t.Logf("Expected the similar curry house to match the one created by the import, got %+v", r)
+		t.Fail()
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	model := &MockRestaurantModel{}
+	Init(&Container{Model: model})
+
+	rows, _ := ParseRestaurants(JSON, strings.NewReader(`[{"name": "Pizza Place"}, {"name": "Curry House"}]`))
+	report, _, err := Import("", rows, true)
+
+	restaurants, _, _ := model.GetRestaurants("")
+	if err != nil || !report.DryRun || report.Created != 2 || len(restaurants) != 0 {
+		t.Logf("Expected a dry run to report without creating restaurants, got %+v %v", report, restaurants)
+		t.Fail()
+	}
+}
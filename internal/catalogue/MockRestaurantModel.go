@@ -0,0 +1,105 @@
+package catalogue
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockRestaurantModel provides an in memory implementation of the RestaurantModel interface.
+type MockRestaurantModel struct {
+	mutex       sync.Mutex
+	restaurants []*restaurant.Building
+	nextID      int
+}
+
+// GetRestaurant returns the stored restaurant with the given ID within the given workspace, returning an error along with a 'NotFound' status should no such restaurant exist.
+func (rm *MockRestaurantModel) GetRestaurant(workspace string, id string) (b *restaurant.Building, status vote.Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	i := rm.indexOf(workspace, id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	b = rm.restaurants[i]
+	return
+}
+
+// GetRestaurants returns all stored restaurants within the given workspace.
+func (rm *MockRestaurantModel) GetRestaurants(workspace string) (restaurants []*restaurant.Building, status vote.Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	restaurants = make([]*restaurant.Building, 0)
+	for _, b := range rm.restaurants {
+		if b.Workspace == workspace {
+			restaurants = append(restaurants, b)
+		}
+	}
+	return
+}
+
+// NewRestaurant stores the given restaurant, assigning it a sequential ID.
+func (rm *MockRestaurantModel) NewRestaurant(b *restaurant.Building) (status vote.Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	rm.nextID++
+	b.ID = "restaurant" + strconv.Itoa(rm.nextID)
+	rm.restaurants = append(rm.restaurants, b)
+	return
+}
+
+// UpdateRestaurant replaces the stored restaurant with the same ID and workspace as the given restaurant, returning an error along with a 'NotFound' status should no such
+// restaurant exist.
+func (rm *MockRestaurantModel) UpdateRestaurant(b *restaurant.Building) (status vote.Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	i := rm.indexOf(b.Workspace, b.ID)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", b.ID)
+		status = vote.NotFound
+		return
+	}
+
+	rm.restaurants[i] = b
+	return
+}
+
+// DeleteRestaurant removes the stored restaurant with the given ID from the given workspace, returning an error along with a 'NotFound' status should no such restaurant exist.
+func (rm *MockRestaurantModel) DeleteRestaurant(workspace string, id string) (status vote.Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	i := rm.indexOf(workspace, id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	rm.restaurants = append(rm.restaurants[:i], rm.restaurants[i+1:]...)
+	return
+}
+
+// Close has been added to ensure the mock meets the RestaurantModel interface, it does not need to actually complete anything.
+func (rm *MockRestaurantModel) Close() (err error) {
+	return
+}
+
+func (rm *MockRestaurantModel) indexOf(workspace string, id string) int {
+	for i, b := range rm.restaurants {
+		if b.ID == id && b.Workspace == workspace {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package catalogue
+
+import (
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoRestaurantModel provides a mongo based implementation to the RestaurantModel interface.
+type MongoRestaurantModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// GetRestaurant gets the restaurant with the specified id within the given workspace from the mongo database.
+func (rm *MongoRestaurantModel) GetRestaurant(workspace string, id string) (b *restaurant.Building, status vote.Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := restaurant.Building{}
+	c := rm.session.DB(rm.DBName).C("restaurants")
+	err = c.Find(db.Scope(workspace, bson.M{"id": id})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	b = &data
+	return
+}
+
+// GetRestaurants returns all restaurants stored within the mongo database for the given workspace, ordered by name.
+func (rm *MongoRestaurantModel) GetRestaurants(workspace string) (restaurants []*restaurant.Building, status vote.Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	restaurants = make([]*restaurant.Building, 0)
+	c := rm.session.DB(rm.DBName).C("restaurants")
+	err = c.Find(db.Scope(workspace, nil)).Sort("name").All(&restaurants)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// NewRestaurant stores the given restaurant within the mongo database, assigning it a new ID.
+func (rm *MongoRestaurantModel) NewRestaurant(b *restaurant.Building) (status vote.Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	b.ID = bson.NewObjectId().Hex()
+	c := rm.session.DB(rm.DBName).C("restaurants")
+	err = c.Insert(b)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// UpdateRestaurant replaces the restaurant stored within the mongo database with the same ID and workspace as the given restaurant.
+func (rm *MongoRestaurantModel) UpdateRestaurant(b *restaurant.Building) (status vote.Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := rm.session.DB(rm.DBName).C("restaurants")
+	err = c.Update(db.Scope(b.Workspace, bson.M{"id": b.ID}), b)
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// DeleteRestaurant removes the restaurant with the given ID within the given workspace from the mongo database.
+func (rm *MongoRestaurantModel) DeleteRestaurant(workspace string, id string) (status vote.Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := rm.session.DB(rm.DBName).C("restaurants")
+	err = c.Remove(db.Scope(workspace, bson.M{"id": id}))
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (rm *MongoRestaurantModel) Close() (err error) {
+	if rm.session != nil {
+		rm.session.Close()
+	}
+	return
+}
+
+func (rm *MongoRestaurantModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&rm.session, rm.URL, rm.Username, rm.Password)
+}
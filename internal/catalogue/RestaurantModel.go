@@ -0,0 +1,38 @@
+package catalogue
+
+import (
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var instance *Container
+
+// RestaurantModel defines a contract for how the system should interact with the database for accessing the restaurant catalogue. Every restaurant within the catalogue belongs
+// to a workspace, the default workspace being represented by an empty string.
+type RestaurantModel interface {
+	// GetRestaurant allows for a singular restaurant within the given workspace to be accessed using its ID. Should any issue occur while attempting to access the restaurant an
+	// error will be returned along with a status detailing the issue.
+	GetRestaurant(workspace string, id string) (*restaurant.Building, vote.Status, error)
+	// GetRestaurants returns all restaurants within the catalogue of the given workspace.
+	GetRestaurants(workspace string) ([]*restaurant.Building, vote.Status, error)
+	// NewRestaurant adds the given restaurant to the catalogue of its workspace, assigning it a new ID.
+	NewRestaurant(b *restaurant.Building) (vote.Status, error)
+	// UpdateRestaurant replaces the stored restaurant with the same ID and workspace as the given restaurant.
+	UpdateRestaurant(b *restaurant.Building) (vote.Status, error)
+	// DeleteRestaurant attempts to remove the restaurant with the given ID from the catalogue of the given workspace.
+	DeleteRestaurant(workspace string, id string) (vote.Status, error)
+	// Close allows for a RestaurantModel connection to be closed.
+	Close() error
+}
+
+// Container provides access to injected implementation of RestaurantModel for the application, along with an optional Geocoder used to locate restaurants given by their
+// address.
+type Container struct {
+	Model    RestaurantModel `inject:""`
+	Geocoder restaurant.Geocoder
+}
+
+// Init allows the catalogue package to be initialised with the Container c.
+func Init(c *Container) {
+	instance = c
+}
@@ -0,0 +1,270 @@
+package catalogue
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// Format represents a file format restaurants can be imported from.
+type Format string
+
+const (
+	// CSV files list a restaurant on each row after a header row naming the columns, which may be name, address, lat, lng, deliveryRadius, service and timeZone in any order.
+	CSV Format = "csv"
+	// JSON files hold an array of restaurants as accepted by PUT /restaurant.
+	JSON Format = "json"
+	// GeoJSON files hold a FeatureCollection of Point features, each feature's properties giving the restaurant's name, address, deliveryRadius, service and timeZone.
+	GeoJSON Format = "geojson"
+	// VCard files hold a vCard for each restaurant, the name being taken from FN or ORG, the address from ADR and the location from GEO.
+	VCard Format = "vcard"
+)
+
+// DetectFormat returns the format of a file from its name, or from its content type should its name not have a known extension, returning false should neither be known.
+func DetectFormat(name string, contentType string) (Format, bool) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return CSV, true
+	case ".json":
+		return JSON, true
+	case ".geojson":
+		return GeoJSON, true
+	case ".vcf", ".vcard":
+		return VCard, true
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0])) {
+	case "text/csv":
+		return CSV, true
+	case "application/json":
+		return JSON, true
+	case "application/geo+json":
+		return GeoJSON, true
+	case "text/vcard", "text/x-vcard":
+		return VCard, true
+	}
+	return "", false
+}
+
+// ImportRow represents a single restaurant read from an imported file, Row being its position within the file starting from 1. Rows which could not be read hold the reason
+// within Err rather than failing the whole import.
+type ImportRow struct {
+	Row        int
+	Restaurant *restaurant.Building
+	Err        string
+}
+
+// ParseRestaurants reads the restaurants held within the given file of the given format, returning an error should the file itself not be readable in the format.
+func ParseRestaurants(format Format, r io.Reader) ([]*ImportRow, error) {
+	switch format {
+	case CSV:
+		return parseCSV(r)
+	case JSON:
+		return parseJSON(r)
+	case GeoJSON:
+		return parseGeoJSON(r)
+	case VCard:
+		return parseVCard(r)
+	}
+	return nil, fmt.Errorf("%s is not a known format, expected one of %s, %s, %s or %s", format, CSV, JSON, GeoJSON, VCard)
+}
+
+func parseCSV(r io.Reader) (rows []*ImportRow, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the header row: %s", err.Error())
+	}
+	columns := make(map[string]int)
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("the header row must include a name column")
+	}
+
+	for n := 1; ; n++ {
+		record, rerr := cr.Read()
+		if rerr == io.EOF {
+			return rows, nil
+		}
+		if rerr != nil {
+			return nil, rerr
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[strings.ToLower(column)]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &ImportRow{Row: n, Restaurant: &restaurant.Building{
+			Name:     get("name"),
+			Address:  get("address"),
+			Service:  restaurant.Service(strings.ToLower(get("service"))),
+			TimeZone: get("timeZone"),
+		}}
+		row.Err = setNumbers(row.Restaurant, get("lat"), get("lng"), get("deliveryRadius"))
+		rows = append(rows, row)
+	}
+}
+
+// setNumbers parses the location and delivery radius of the given restaurant from text, returning why should any not be a number. Blank values are left unknown.
+func setNumbers(b *restaurant.Building, lat string, lng string, radius string) string {
+	if lat != "" || lng != "" {
+		la, err1 := strconv.ParseFloat(lat, 64)
+		ln, err2 := strconv.ParseFloat(lng, 64)
+		if err1 != nil || err2 != nil {
+			return "lat and lng must both be numbers"
+		}
+		b.Location = &restaurant.Location{Lat: la, Lng: ln}
+	}
+
+	if radius != "" {
+		d, err := strconv.ParseFloat(radius, 64)
+		if err != nil {
+			return "deliveryRadius must be a number"
+		}
+		b.DeliveryRadius = d
+	}
+	return ""
+}
+
+func parseJSON(r io.Reader) (rows []*ImportRow, err error) {
+	var restaurants []*restaurant.Building
+	if err = json.NewDecoder(r).Decode(&restaurants); err != nil {
+		return nil, fmt.Errorf("expected an array of restaurants: %s", err.Error())
+	}
+
+	for i, b := range restaurants {
+		row := &ImportRow{Row: i + 1, Restaurant: b}
+		if b == nil {
+			row.Restaurant, row.Err = &restaurant.Building{}, "must not be null"
+		}
+		rows = append(rows, row)
+	}
+	return
+}
+
+func parseGeoJSON(r io.Reader) (rows []*ImportRow, err error) {
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry *struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err = json.NewDecoder(r).Decode(&collection); err != nil || collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("expected a GeoJSON FeatureCollection")
+	}
+
+	for i, f := range collection.Features {
+		text := func(key string) string {
+			s, _ := f.Properties[key].(string)
+			return strings.TrimSpace(s)
+		}
+
+		b := &restaurant.Building{
+			Name:     text("name"),
+			Address:  text("address"),
+			Service:  restaurant.Service(strings.ToLower(text("service"))),
+			TimeZone: text("timeZone"),
+		}
+		if radius, ok := f.Properties["deliveryRadius"].(float64); ok {
+			b.DeliveryRadius = radius
+		}
+
+		row := &ImportRow{Row: i + 1, Restaurant: b}
+		switch {
+		case f.Geometry == nil:
+		case f.Geometry.Type != "Point" || len(f.Geometry.Coordinates) < 2:
+			row.Err = "geometry must be a Point"
+		default:
+			// GeoJSON orders coordinates by longitude first.
+			b.Location = &restaurant.Location{Lat: f.Geometry.Coordinates[1], Lng: f.Geometry.Coordinates[0]}
+		}
+		rows = append(rows, row)
+	}
+	return
+}
+
+func parseVCard(r io.Reader) (rows []*ImportRow, err error) {
+	scanner := bufio.NewScanner(r)
+
+	// lines beginning with whitespace continue the previous line, so are unfolded before being parsed.
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	var row *ImportRow
+	var org string
+	for _, line := range lines {
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		// properties may carry parameters, such as ADR;TYPE=work, which are ignored.
+		name := strings.ToUpper(strings.Split(line[:i], ";")[0])
+		value := strings.TrimSpace(line[i+1:])
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			row, org = &ImportRow{Row: len(rows) + 1, Restaurant: &restaurant.Building{}}, ""
+		case row == nil:
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if row.Restaurant.Name == "" {
+				row.Restaurant.Name = org
+			}
+			rows = append(rows, row)
+			row = nil
+		case name == "FN":
+			row.Restaurant.Name = value
+		case name == "ORG":
+			org = strings.TrimSpace(strings.Split(value, ";")[0])
+		case name == "ADR":
+			parts := make([]string, 0)
+			for _, p := range strings.Split(value, ";") {
+				if p = strings.TrimSpace(p); p != "" {
+					parts = append(parts, p)
+				}
+			}
+			row.Restaurant.Address = strings.Join(parts, ", ")
+		case name == "GEO":
+			// vCard 3 separates the coordinates with a semicolon, while vCard 4 gives a geo URI separating them with a comma.
+			coords := strings.FieldsFunc(strings.TrimPrefix(strings.ToLower(value), "geo:"), func(r rune) bool { return r == ',' || r == ';' })
+			if len(coords) < 2 {
+				row.Err = "GEO must give a latitude and longitude"
+			} else if e := setNumbers(row.Restaurant, strings.TrimSpace(coords[0]), strings.TrimSpace(coords[1]), ""); e != "" {
+				row.Err = e
+			}
+		}
+	}
+
+	if row != nil {
+		return nil, fmt.Errorf("vCard %d is missing END:VCARD", row.Row)
+	}
+	return
+}
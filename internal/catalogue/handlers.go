@@ -0,0 +1,241 @@
+package catalogue
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetRestaurant provides a http handler for accessing a specified restaurant within the caller's catalogue.
+func GetRestaurant(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b, status, err := instance.Model.GetRestaurant(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find restaurant %s, returning not found status.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find restaurant due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, b)
+}
+
+// GetRestaurants provides a http handler for listing every restaurant within the caller's catalogue.
+func GetRestaurants(w http.ResponseWriter, r *http.Request) {
+	restaurants, _, err := instance.Model.GetRestaurants(caller.Workspace(r.Context()))
+	if err != nil {
+		log.Printf("Could not retrieve restaurants due to: %s\n", err.Error())
+		http.Error(w, "Could not retrieve restaurants", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, restaurants)
+}
+
+// NewRestaurant provides a http handler for adding a restaurant to the caller's catalogue.
+func NewRestaurant(w http.ResponseWriter, r *http.Request) {
+	b, ok := readRestaurant(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := instance.Model.NewRestaurant(b)
+	if err != nil {
+		log.Printf("Could not create a new restaurant due to: %s\n", err.Error())
+		if status == vote.Invalid {
+			http.Error(w, "Supplied restaurant invalid", http.StatusBadRequest)
+		} else {
+			http.Error(w, "Restaurant could not be created", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("Created restaurant with id %v\n", b.ID)
+	writeJSON(w, http.StatusCreated, b)
+}
+
+// UpdateRestaurant provides a http handler for updating a restaurant within the caller's catalogue, copying its opening hours into the open polls it is an option within.
+func UpdateRestaurant(w http.ResponseWriter, r *http.Request) {
+	b, ok := readRestaurant(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := instance.Model.UpdateRestaurant(b)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find a restaurant with specified ID = %s\n", b.ID)
+			http.Error(w, "Could not find restaurant with specified ID", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Could not update restaurant with id %s due to internal model error %s\n", b.ID, err.Error())
+		http.Error(w, "Could not update restaurant", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("successfully updated restaurant with id %s\n", b.ID)
+	// open polls with the restaurant as an option are given its new opening hours, which may leave the option unavailable.
+	vote.RefreshRestaurant(b.Workspace, b)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DeleteRestaurant provides a http handler for removing a restaurant from the caller's catalogue. Polls the restaurant is an option within are unaffected.
+func DeleteRestaurant(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status, err := instance.Model.DeleteRestaurant(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find the ID %s\n", id)
+			http.Error(w, "ID not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+// readRestaurant reads a restaurant from the body of the given request, placing it within the caller's workspace and locating it using the injected Geocoder should it have no
+// location. Should the restaurant not be valid, as checked for imported restaurants, a bad request response is written and false returned.
+func readRestaurant(w http.ResponseWriter, r *http.Request) (b *restaurant.Building, ok bool) {
+	body, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	// if request body could not be parsed, return an internal server error to the client.
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	b = &restaurant.Building{}
+	err = json.Unmarshal(body, b)
+	if err != nil {
+		log.Printf("Could not parse %s into a restaurant", body)
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	// restaurants are validated as they would be when imported, keeping restaurants which could not be added to a poll out of the catalogue.
+	if reason := checkImported(b); reason != "" {
+		log.Printf("Restaurant %s is not valid: %s\n", body, reason)
+		http.Error(w, reason, http.StatusBadRequest)
+		return
+	}
+
+	// restaurants can only be created and updated within the caller's workspace.
+	b.Workspace = caller.Workspace(r.Context())
+
+	// restaurants are located using their address should no location be given, restaurants which cannot be located simply being stored without a location.
+	if b.Location == nil && b.Address != "" && instance.Geocoder != nil {
+		if b.Location, err = instance.Geocoder.Geocode(b.Address); err != nil {
+			log.Printf("Could not locate restaurant %s due to: %s\n", b.Name, err.Error())
+		}
+	}
+
+	ok = true
+	return
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// MaxImportSize is the largest file, in bytes, that can be uploaded to ImportRestaurants.
+const MaxImportSize = 10 << 20
+
+// ImportRestaurants provides a http handler for importing restaurants into the caller's catalogue from an uploaded CSV, JSON, GeoJSON or vCard file, reporting which rows were
+// created, updated or skipped. The file is either the request body or the 'file' field of a multipart form, its format being given by the 'format' query parameter or otherwise
+// detected from the file's name or content type. Setting the 'dryRun' query parameter to true reports what would be imported without changing the catalogue.
+func ImportRestaurants(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportSize)
+	defer r.Body.Close()
+
+	var file io.Reader = r.Body
+	name := ""
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			log.Printf("Could not read the uploaded file due to: %s\n", err.Error())
+			http.Error(w, "Could not read the uploaded file", http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		file, name, contentType = f, header.Filename, header.Header.Get("Content-Type")
+	}
+
+	format := Format(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		var ok bool
+		if format, ok = DetectFormat(name, contentType); !ok {
+			log.Printf("Could not detect the format of the uploaded file %s with content type %s\n", name, contentType)
+			http.Error(w, "Could not detect the format of the uploaded file, specify one using the format parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := ParseRestaurants(format, file)
+	if err != nil {
+		log.Printf("Could not parse the uploaded %s file due to: %s\n", format, err.Error())
+		http.Error(w, "Could not parse the uploaded file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, _, err := Import(caller.Workspace(r.Context()), rows, r.URL.Query().Get("dryRun") == "true")
+	if err != nil {
+		log.Printf("Could not import restaurants due to: %s\n", err.Error())
+		http.Error(w, "Could not import restaurants", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Imported restaurants from a %s file: %v created, %v updated, %v skipped\n", format, report.Created, report.Updated, report.Skipped)
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,15 @@
+package chatops
+
+import "takeaway/takeaway-server/internal/vote"
+
+// ChannelModel defines a contract for how the system should interact with the database for recording the poll each chat channel is currently running. As with polls, every
+// channel belongs to a workspace, the default workspace being represented by an empty string.
+type ChannelModel interface {
+	// GetChannelPoll returns the ID of the poll the given channel within the given workspace is currently running, returning an error along with a 'NotFound' status should the
+	// channel never have started a poll.
+	GetChannelPoll(workspace string, channel string) (string, vote.Status, error)
+	// SetChannelPoll records the poll with the given ID as the poll the given channel within the given workspace is currently running, replacing any earlier poll.
+	SetChannelPoll(workspace string, channel string, pollID string) (vote.Status, error)
+	// Close allows for a ChannelModel connection to be closed.
+	Close() error
+}
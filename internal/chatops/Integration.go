@@ -0,0 +1,25 @@
+package chatops
+
+import (
+	"takeaway/takeaway-server/internal/catalogue"
+)
+
+var instance *Integration
+
+// Integration connects the poll service to a chat platform such as Slack or Mattermost, accepting slash commands and interactive message actions and posting poll messages back
+// to the chat. Requests are verified using the SigningSecret shared with the platform should one be given, otherwise using the verification Token the platform sends with each
+// request, requests being rejected should neither be given.
+type Integration struct {
+	SigningSecret string
+	Token         string
+	// Channels records the poll each chat channel is currently running, while Restaurants provides the options of polls created without naming any.
+	Channels    ChannelModel
+	Restaurants catalogue.RestaurantModel
+	// Poster posts messages back to the chat.
+	Poster Poster
+}
+
+// Init allows the chatops package to be initialised with the Integration i.
+func Init(i *Integration) {
+	instance = i
+}
@@ -0,0 +1,45 @@
+package chatops
+
+import (
+	"fmt"
+	"sync"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockChannelModel provides an in memory implementation of the ChannelModel interface.
+type MockChannelModel struct {
+	mutex sync.Mutex
+	polls map[string]string
+}
+
+// GetChannelPoll returns the ID of the poll recorded in memory for the given channel within the given workspace, returning an error along with a 'NotFound' status should there
+// be none.
+func (cm *MockChannelModel) GetChannelPoll(workspace string, channel string) (id string, status vote.Status, err error) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	id, ok := cm.polls[workspace+"/"+channel]
+	if !ok {
+		err = fmt.Errorf("the channel %s has not started a poll", channel)
+		status = vote.NotFound
+	}
+	return
+}
+
+// SetChannelPoll records the poll with the given ID in memory as the poll the given channel within the given workspace is running.
+func (cm *MockChannelModel) SetChannelPoll(workspace string, channel string, pollID string) (status vote.Status, err error) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if cm.polls == nil {
+		cm.polls = make(map[string]string)
+	}
+	cm.polls[workspace+"/"+channel] = pollID
+	return
+}
+
+// Close has been added to ensure the mock meets the ChannelModel interface, it does not need to actually complete anything.
+func (cm *MockChannelModel) Close() (err error) {
+	return
+}
@@ -0,0 +1,74 @@
+package chatops
+
+import (
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoChannelModel provides a mongo based implementation to the ChannelModel interface.
+type MongoChannelModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// channel represents the document stored for each chat channel.
+type channel struct {
+	Workspace string `bson:"workspace,omitempty"`
+	Channel   string `bson:"channel"`
+	PollID    string `bson:"pollId"`
+}
+
+// GetChannelPoll returns the ID of the poll stored within the mongo database for the given channel within the given workspace.
+func (cm *MongoChannelModel) GetChannelPoll(workspace string, ch string) (id string, status vote.Status, err error) {
+	err = cm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := channel{}
+	c := cm.session.DB(cm.DBName).C("channels")
+	err = c.Find(db.Scope(workspace, bson.M{"channel": ch})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	id = data.PollID
+	return
+}
+
+// SetChannelPoll upserts the poll the given channel within the given workspace is running within the mongo database.
+func (cm *MongoChannelModel) SetChannelPoll(workspace string, ch string, pollID string) (status vote.Status, err error) {
+	err = cm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := cm.session.DB(cm.DBName).C("channels")
+	_, err = c.Upsert(db.Scope(workspace, bson.M{"channel": ch}), &channel{Workspace: workspace, Channel: ch, PollID: pollID})
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (cm *MongoChannelModel) Close() (err error) {
+	if cm.session != nil {
+		cm.session.Close()
+	}
+	return
+}
+
+func (cm *MongoChannelModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&cm.session, cm.URL, cm.Username, cm.Password)
+}
@@ -0,0 +1,101 @@
+package chatops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Message represents a message posted to the chat, following the format of Slack's and Mattermost's message attachments. ResponseType is in_channel for messages seen by the
+// whole channel, or ephemeral for messages only seen by the user who sent the command.
+type Message struct {
+	ResponseType    string        `json:"response_type,omitempty"`
+	ReplaceOriginal bool          `json:"replace_original,omitempty"`
+	Text            string        `json:"text"`
+	Attachments     []*Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment represents a block of a message holding the buttons users interact with, CallbackID identifying the poll the buttons belong to.
+type Attachment struct {
+	Text       string    `json:"text,omitempty"`
+	CallbackID string    `json:"callback_id,omitempty"`
+	Actions    []*Action `json:"actions,omitempty"`
+}
+
+// Action represents a button within an attachment, Value being the ID of the option the button votes for.
+type Action struct {
+	Name  string `json:"name"`
+	Text  string `json:"text"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Poster defines a contract for components able to post messages back to the chat using the outbound webhook URL given with each command.
+type Poster interface {
+	// Post sends the given message to the given webhook URL, returning an error should the chat not accept it.
+	Post(url string, m *Message) error
+}
+
+// WebhookPoster posts messages to the chat over http, using the given client or a client timing out after 10 seconds should none be given.
+type WebhookPoster struct {
+	Client *http.Client
+}
+
+// Post sends the given message to the given webhook URL as JSON.
+func (wp *WebhookPoster) Post(url string, m *Message) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	client := wp.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("the webhook %s responded with status %v", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// StubPoster records the messages posted to it rather than sending them, allowing the integration to be ran locally and tested without a chat platform.
+type StubPoster struct {
+	mutex sync.Mutex
+	Posts []*Post
+}
+
+// Post represents a single message posted to a StubPoster along with the URL it was posted to.
+type Post struct {
+	URL     string
+	Message *Message
+}
+
+// Post records the given message as having been posted to the given URL.
+func (sp *StubPoster) Post(url string, m *Message) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	sp.Posts = append(sp.Posts, &Post{URL: url, Message: m})
+	return nil
+}
+
+// Last returns the message most recently posted to the stub, or nil should no message have been posted.
+func (sp *StubPoster) Last() *Message {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	if len(sp.Posts) == 0 {
+		return nil
+	}
+	return sp.Posts[len(sp.Posts)-1].Message
+}
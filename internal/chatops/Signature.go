@@ -0,0 +1,56 @@
+package chatops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// The headers carrying the signature of a request and the time it was signed at, along with how far the signing time may be from the current time before the request is
+// rejected as a possible replay.
+const (
+	SignatureHeader = "X-Slack-Signature"
+	TimestampHeader = "X-Slack-Request-Timestamp"
+	MaxSkew         = 5 * time.Minute
+)
+
+// Sign returns the signature of the given request body signed at the given unix time using the given secret, as sent within the SignatureHeader.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a request was sent by the chat platform, returning why should it not have been. Requests are checked against the integration's SigningSecret using the given
+// signature and timestamp headers should a secret be given, otherwise the token sent with the request is compared with the integration's Token.
+func (i *Integration) Verify(signature string, timestamp string, token string, body []byte, now time.Time) error {
+	if i.SigningSecret != "" {
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return errors.New("the request timestamp is missing or not a number")
+		}
+		if math.Abs(now.Sub(time.Unix(ts, 0)).Seconds()) > MaxSkew.Seconds() {
+			return errors.New("the request timestamp is too far from the current time")
+		}
+		if !hmac.Equal([]byte(signature), []byte(Sign(i.SigningSecret, timestamp, body))) {
+			return errors.New("the request signature does not match")
+		}
+		return nil
+	}
+
+	if i.Token != "" {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(i.Token)) != 1 {
+			return errors.New("the request token does not match")
+		}
+		return nil
+	}
+
+	return errors.New("neither a signing secret nor a token has been configured")
+}
@@ -0,0 +1,181 @@
+package chatops
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+var hub sync.Once
+
+// newIntegration initialises the vote package and an integration verified using the given secret, with a catalogue holding a single restaurant.
+func newIntegration(secret string) (*Integration, *StubPoster) {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}})
+
+	restaurants := &catalogue.MockRestaurantModel{}
+	restaurants.NewRestaurant(&restaurant.Building{Name: "Pizza Place", Address: "1 High Street"})
+
+	poster := &StubPoster{}
+	i := &Integration{SigningSecret: secret, Token: "token", Channels: &MockChannelModel{}, Restaurants: restaurants, Poster: poster}
+	Init(i)
+	return i, poster
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("text=status&channel_id=C1")
+	signed := &Integration{SigningSecret: "secret"}
+
+	if err := signed.Verify(Sign("secret", ts, body), ts, "", body, now); err != nil {
+		t.Logf("Expected a correctly signed request to be verified, got %s", err)
+		t.Fail()
+	}
+	if signed.Verify(Sign("other", ts, body), ts, "", body, now) == nil {
+		t.Log("Expected a request signed with another secret to be rejected")
+		t.Fail()
+	}
+	if signed.Verify(Sign("secret", ts, body), ts, "", []byte("text=new"), now) == nil {
+		t.Log("Expected a request whose body has changed to be rejected")
+		t.Fail()
+	}
+	if signed.Verify(Sign("secret", ts, body), ts, "", body, now.Add(MaxSkew+time.Minute)) == nil {
+		t.Log("Expected a request signed too long ago to be rejected")
+		t.Fail()
+	}
+
+	token := &Integration{Token: "token"}
+	if token.Verify("", "", "token", body, now) != nil || token.Verify("", "", "wrong", body, now) == nil {
+		t.Log("Expected requests to be verified by their token should no secret be configured")
+		t.Fail()
+	}
+	if (&Integration{}).Verify("", "", "", body, now) == nil {
+		t.Log("Expected requests to be rejected should neither a secret nor token be configured")
+		t.Fail()
+	}
+}
+
+func TestCommands(t *testing.T) {
+	i, poster := newIntegration("")
+	c := &Command{Channel: "C1", User: "Jack", ResponseURL: "https://chat.example.com/hooks/1"}
+
+	c.Text = "vote 1"
+	if m := i.Run(c); !strings.Contains(m.Text, "no poll") {
+		t.Logf("Expected voting without a poll to be rejected, got %q", m.Text)
+		t.Fail()
+	}
+
+	c.Text = "new pizza place, Curry House"
+	if m := i.Run(c); m.ResponseType != Ephemeral {
+		t.Logf("Expected starting a poll to be confirmed to the user, got %+v", m)
+		t.Fail()
+	}
+
+	m := poster.Last()
+	if m == nil || m.ResponseType != InChannel || len(m.Attachments) != 1 || len(m.Attachments[0].Actions) != 2 {
+		t.Fatalf("Expected the poll to be posted to the channel with a button for each option, got %+v", m)
+	}
+	if poster.Posts[0].URL != c.ResponseURL {
+		t.Logf("Expected the poll to be posted to %s, got %s", c.ResponseURL, poster.Posts[0].URL)
+		t.Fail()
+	}
+
+	id := m.Attachments[0].CallbackID
+	poll, _, _ := vote.FindPoll("", id)
+	if poll == nil || poll.Creator != "Jack" || poll.Options[0].Address != "1 High Street" || poll.Options[1].ID != "curry-house" {
+		t.Fatalf("Expected a poll between the catalogue's restaurant and a new restaurant, got %+v", poll)
+	}
+
+	for _, text := range []string{"vote 2", "vote pizza place"} {
+		c.Text = text
+		if m := i.Run(c); m.ResponseType != Ephemeral || !strings.HasPrefix(m.Text, "You voted for") {
+			t.Logf("Expected %q to be confirmed, got %q", text, m.Text)
+			t.Fail()
+		}
+	}
+
+	poll, _, _ = vote.FindPoll("", id)
+	if len(poll.Votes[poll.Options[0].ID]) != 1 || len(poll.Votes["curry-house"]) != 0 {
+		t.Logf("Expected Jack's vote to have moved to the first option, got %v", poll.Votes)
+		t.Fail()
+	}
+
+	c.Text = "vote 3"
+	if m := i.Run(c); !strings.Contains(m.Text, "no option") {
+		t.Logf("Expected a vote for an unknown option to be rejected, got %q", m.Text)
+		t.Fail()
+	}
+
+	c.Text = "status"
+	if m := i.Run(c); len(m.Attachments) != 1 || !strings.Contains(m.Attachments[0].Text, "1. Pizza Place (1)") {
+		t.Logf("Expected the status to show the poll's votes, got %+v", m)
+		t.Fail()
+	}
+
+	c.Text = "order"
+	if m := i.Run(c); m.Text != Help {
+		t.Logf("Expected an unknown command to be answered with help, got %q", m.Text)
+		t.Fail()
+	}
+}
+
+func TestSlashCommandAndInteract(t *testing.T) {
+	newIntegration("secret")
+	form := url.Values{"channel_id": {"C1"}, "user_id": {"U1"}, "text": {"new"}, "response_url": {"https://chat.example.com/hooks/1"}}
+	poster := instance.Poster.(*StubPoster)
+
+	send := func(handler http.HandlerFunc, body string, secret string) *httptest.ResponseRecorder {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/chat", strings.NewReader(body))
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, Sign(secret, ts, []byte(body)))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	if w := send(SlashCommand, form.Encode(), "wrong"); w.Code != http.StatusUnauthorized {
+		t.Logf("Expected a command with the wrong signature to be rejected, got %v", w.Code)
+		t.Fail()
+	}
+	if w := send(SlashCommand, form.Encode(), "secret"); w.Code != http.StatusOK || poster.Last() == nil {
+		t.Fatalf("Expected the command to start a poll, got %v: %s", w.Code, w.Body.String())
+	}
+
+	id := poster.Last().Attachments[0].CallbackID
+	payload := `{"type": "interactive_message", "callback_id": "` + id + `", "actions": [{"name": "vote", "value": "unknown"}], "user": {"id": "U2", "name": "Tom"}}`
+	w := send(Interact, url.Values{"payload": {payload}}.Encode(), "secret")
+	m := &Message{}
+	json.Unmarshal(w.Body.Bytes(), m)
+	if w.Code != http.StatusOK || m.ReplaceOriginal || !strings.HasPrefix(m.Text, "Could not cast your vote") {
+		t.Logf("Expected a vote for an unknown option to be reported to the user, got %v: %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	payload = strings.Replace(payload, "unknown", poster.Last().Attachments[0].Actions[0].Value, 1)
+	w = send(Interact, url.Values{"payload": {payload}}.Encode(), "secret")
+	m = &Message{}
+	json.Unmarshal(w.Body.Bytes(), m)
+	if w.Code != http.StatusOK || !m.ReplaceOriginal || !strings.Contains(m.Attachments[0].Text, "Pizza Place (1)") {
+		t.Logf("Expected the poll message to be replaced with the updated poll, got %v: %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	poll, _, _ := vote.FindPoll("", id)
+	if poll == nil || len(poll.Votes[poll.Options[0].ID]) != 1 || poll.Votes[poll.Options[0].ID][0] != "U2" {
+		t.Logf("Expected Tom's vote to be recorded under their user ID, got %+v", poll)
+		t.Fail()
+	}
+}
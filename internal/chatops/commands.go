@@ -0,0 +1,273 @@
+package chatops
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// The response types of messages, in_channel messages being seen by the whole channel while ephemeral messages are only seen by the user who sent the command.
+const (
+	InChannel = "in_channel"
+	Ephemeral = "ephemeral"
+)
+
+// Help describes the commands understood by the integration, being sent in reply to the help command or any command which is not understood.
+const Help = "Usage:\n" +
+	"• `/lunch new [restaurant, restaurant, ...]` starts a poll in this channel, between the given restaurants or the workspace's catalogue should none be given\n" +
+	"• `/lunch vote <number|name>` votes for an option of the channel's poll\n" +
+	"• `/lunch status` shows the channel's poll"
+
+// Command represents a slash command sent from a chat channel, ResponseURL being the outbound webhook messages about the command may be posted to. User holds the ID the chat
+// platform gives the sender, which cannot be chosen by the sender so is used as their identity, while Name is their chosen name and only used for display.
+type Command struct {
+	Workspace   string
+	Channel     string
+	User        string
+	Name        string
+	Text        string
+	ResponseURL string
+}
+
+// displayName returns the name to show for the sender of the command, falling back to their ID should the platform not give a name.
+func (c *Command) displayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.User
+}
+
+// Run carries out the given command, returning the message to reply to the user with. Starting a poll posts the poll to the whole channel using the command's ResponseURL,
+// while every other reply is only seen by the user.
+func (i *Integration) Run(c *Command) *Message {
+	fields := strings.Fields(c.Text)
+	if len(fields) == 0 {
+		return reply(Help)
+	}
+
+	arg := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(c.Text), fields[0]))
+	switch strings.ToLower(fields[0]) {
+	case "new":
+		return i.newPoll(c, arg)
+	case "vote":
+		return i.vote(c, arg)
+	case "status":
+		return i.status(c)
+	default:
+		return reply(Help)
+	}
+}
+
+func (i *Integration) newPoll(c *Command, arg string) *Message {
+	options, err := i.options(c.Workspace, vote.SplitList(arg))
+	if err != nil {
+		return reply(err.Error())
+	}
+
+	poll, _, err := vote.CreatePoll(c.Workspace, c.User, options, nil)
+	if err != nil {
+		log.Printf("Could not create a poll for channel %s due to: %s\n", c.Channel, err.Error())
+		return reply("Could not start a poll: " + err.Error())
+	}
+
+	if _, err = i.Channels.SetChannelPoll(c.Workspace, c.Channel, poll.ID); err != nil {
+		log.Printf("Could not record poll %s as the poll of channel %s due to: %s\n", poll.ID, c.Channel, err.Error())
+		return reply("Could not start a poll in this channel")
+	}
+
+	m := PollMessage(poll, time.Now())
+	m.ResponseType = InChannel
+	m.Text = fmt.Sprintf("%s started a lunch poll! %s", c.displayName(), m.Text)
+	if err = i.Poster.Post(c.ResponseURL, m); err != nil {
+		log.Printf("Could not post poll %s to channel %s due to: %s\n", poll.ID, c.Channel, err.Error())
+		return m
+	}
+
+	log.Printf("Created poll %s for channel %s\n", poll.ID, c.Channel)
+	return reply(fmt.Sprintf("Started poll %s", poll.ID))
+}
+
+// options returns the options of a poll between the restaurants with the given names, taking each restaurant from the workspace's catalogue should it be found there. Should
+// no names be given the catalogue's restaurants are used, up to the maximum number of options a poll may have.
+func (i *Integration) options(workspace string, names []string) ([]*restaurant.Building, error) {
+	var catalogue []*restaurant.Building
+	if i.Restaurants != nil {
+		restaurants, _, err := i.Restaurants.GetRestaurants(workspace)
+		if err != nil {
+			log.Printf("Could not retrieve restaurants for a chat poll due to: %s\n", err.Error())
+		}
+		catalogue = restaurants
+	}
+
+	options := make([]*restaurant.Building, 0)
+	if len(names) == 0 {
+		for _, b := range catalogue {
+			if len(options) == vote.MaxOptions {
+				break
+			}
+			c := *b
+			options = append(options, &c)
+		}
+		if len(options) == 0 {
+			return nil, errors.New("There are no restaurants in the catalogue, name the restaurants to vote between: `/lunch new Pizza Place, Curry House`")
+		}
+		return options, nil
+	}
+
+	for _, name := range names {
+		opt := &restaurant.Building{ID: slug(name), Name: name}
+		for _, b := range catalogue {
+			if strings.EqualFold(b.Name, name) {
+				c := *b
+				opt = &c
+				break
+			}
+		}
+		options = append(options, opt)
+	}
+	return options, nil
+}
+
+func (i *Integration) vote(c *Command, arg string) *Message {
+	poll, err := i.channelPoll(c.Workspace, c.Channel)
+	if err != nil {
+		return reply(err.Error())
+	}
+
+	opt := findOption(poll, arg)
+	if opt == nil {
+		return reply(fmt.Sprintf("There is no option %q in this channel's poll, vote using an option's number or name", arg))
+	}
+
+	_, m := castVote(c.Workspace, poll.ID, c.User, opt.ID)
+	return m
+}
+
+func (i *Integration) status(c *Command) *Message {
+	poll, err := i.channelPoll(c.Workspace, c.Channel)
+	if err != nil {
+		return reply(err.Error())
+	}
+
+	m := PollMessage(poll, time.Now())
+	m.ResponseType = Ephemeral
+	return m
+}
+
+// channelPoll returns the poll the given channel is currently running.
+func (i *Integration) channelPoll(workspace string, channel string) (*vote.Poll, error) {
+	id, status, err := i.Channels.GetChannelPoll(workspace, channel)
+	if err != nil {
+		if status == vote.NotFound {
+			return nil, errors.New("This channel has no poll, start one using `/lunch new`")
+		}
+		log.Printf("Could not find the poll of channel %s due to: %s\n", channel, err.Error())
+		return nil, errors.New("Could not find this channel's poll")
+	}
+
+	poll, _, err := vote.FindPoll(workspace, id)
+	if err != nil {
+		log.Printf("Could not find poll %s of channel %s due to: %s\n", id, channel, err.Error())
+		return nil, errors.New("Could not find this channel's poll")
+	}
+	return poll, nil
+}
+
+// castVote records the given user's vote, returning the updated poll along with a reply confirming the vote. Should the vote not be cast a nil poll is returned, with the reply
+// stating why.
+func castVote(workspace string, id string, user string, optionID string) (*vote.Poll, *Message) {
+	poll, _, err := vote.CastVote(workspace, id, user, optionID)
+	if err != nil {
+		log.Printf("Could not cast vote for %s in poll %s due to: %s\n", optionID, id, err.Error())
+		return nil, reply("Could not cast your vote: " + err.Error())
+	}
+
+	log.Printf("%s voted for %s in poll %s from chat\n", user, optionID, id)
+	return poll, reply(fmt.Sprintf("You voted for %s", poll.Option(optionID).Name))
+}
+
+// PollMessage returns a message showing the given poll as it is seen at the given time, with a button to vote for each of its options. The votes for each option are left out
+// of secret polls until they close.
+func PollMessage(p *vote.Poll, now time.Time) *Message {
+	view := p.ViewFor("", now)
+	tally := make(map[string]int)
+	for _, t := range view.Results() {
+		tally[t.OptionID] = t.Votes
+	}
+
+	lines := make([]string, 0, len(p.Options))
+	actions := make([]*Action, 0, len(p.Options))
+	for n, opt := range p.Options {
+		line := fmt.Sprintf("%d. %s", n+1, opt.Name)
+		if !view.ResultsHidden {
+			line += fmt.Sprintf(" (%d)", tally[opt.ID])
+		}
+		if !p.IsAvailable(opt.ID, now) {
+			line += " closed"
+		} else {
+			actions = append(actions, &Action{Name: "vote", Text: opt.Name, Type: "button", Value: opt.ID})
+		}
+		lines = append(lines, line)
+	}
+
+	text := "Where should we order lunch from?"
+	if state := p.State(now); state != vote.PollOpen {
+		text = fmt.Sprintf("This poll is %s.", state)
+		actions = nil
+		if winner := view.Winner(); winner != nil {
+			text += " The winner is " + winner.Name + "!"
+		}
+	}
+
+	return &Message{
+		Text: text,
+		Attachments: []*Attachment{{
+			Text:       strings.Join(lines, "\n"),
+			CallbackID: p.ID,
+			Actions:    actions,
+		}},
+	}
+}
+
+// findOption returns the option of the poll numbered or named by the given argument, numbers starting from 1 in the order the options are listed.
+func findOption(p *vote.Poll, arg string) *restaurant.Building {
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > len(p.Options) {
+			return nil
+		}
+		return p.Options[n-1]
+	}
+
+	for _, opt := range p.Options {
+		if strings.EqualFold(opt.Name, arg) || opt.ID == arg {
+			return opt
+		}
+	}
+	return nil
+}
+
+func reply(text string) *Message {
+	return &Message{ResponseType: Ephemeral, Text: text}
+}
+
+// slug returns an option ID for a restaurant not within the catalogue, derived from its name.
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
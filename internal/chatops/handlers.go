@@ -0,0 +1,139 @@
+package chatops
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+// MaxRequestSize is the largest request body accepted from the chat platform, in bytes.
+const MaxRequestSize = 1 << 20
+
+// interaction represents the payload sent by the chat platform when a user presses a button of a message, CallbackID being the ID of the poll the button belongs to.
+type interaction struct {
+	Type       string `json:"type"`
+	CallbackID string `json:"callback_id"`
+	Token      string `json:"token"`
+	Actions    []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"actions"`
+	User struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+}
+
+// SlashCommand provides a http handler for the slash commands sent by the chat platform, replying with the message to show the user who sent the command. Replies are sent with
+// an OK status even when the command fails, as the chat platform only shows the user the replies it considers successful.
+func SlashCommand(w http.ResponseWriter, r *http.Request) {
+	body, form, ok := readVerified(w, r)
+	if !ok {
+		return
+	}
+
+	c := &Command{
+		Workspace:   caller.Workspace(r.Context()),
+		Channel:     form.Get("channel_id"),
+		User:        form.Get("user_id"),
+		Name:        form.Get("user_name"),
+		Text:        form.Get("text"),
+		ResponseURL: form.Get("response_url"),
+	}
+	if c.Channel == "" || c.User == "" {
+		log.Printf("Chat command %s did not give a channel and user\n", body)
+		http.Error(w, "A channel_id and user_id must be given", http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("Received chat command %q from %s in channel %s\n", c.Text, c.User, c.Channel)
+	writeJSON(w, instance.Run(c))
+}
+
+// Interact provides a http handler for the actions sent by the chat platform when a user presses a button of a poll message, voting for the button's option and replacing the
+// message with the updated poll. Should the vote not be cast the message is kept, the user instead being shown why.
+func Interact(w http.ResponseWriter, r *http.Request) {
+	body, form, ok := readVerified(w, r)
+	if !ok {
+		return
+	}
+
+	a := &interaction{}
+	if err := json.Unmarshal([]byte(form.Get("payload")), a); err != nil {
+		log.Printf("Could not parse chat action %s due to: %s\n", body, err.Error())
+		http.Error(w, "Could not parse the action payload", http.StatusBadRequest)
+		return
+	}
+
+	// the user's ID is used as their identity, as their name may be changed to that of another user.
+	user := a.User.ID
+	if a.CallbackID == "" || user == "" || len(a.Actions) == 0 {
+		log.Printf("Chat action %s did not give a poll, user and action\n", body)
+		http.Error(w, "A callback_id, user and action must be given", http.StatusBadRequest)
+		return
+	}
+
+	poll, m := castVote(caller.Workspace(r.Context()), a.CallbackID, user, a.Actions[0].Value)
+	if poll == nil {
+		writeJSON(w, m)
+		return
+	}
+
+	updated := PollMessage(poll, time.Now())
+	updated.ReplaceOriginal = true
+	writeJSON(w, updated)
+}
+
+// readVerified reads the body of a request sent by the chat platform, checking the request was sent by the platform before parsing it as a form. Should the request not be
+// verified an Unauthorized status is written and false returned.
+func readVerified(w http.ResponseWriter, r *http.Request) ([]byte, url.Values, bool) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+	if err != nil {
+		log.Printf("Could not read chat request due to: %s\n", err.Error())
+		http.Error(w, "Could not read the request", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		log.Printf("Could not parse chat request due to: %s\n", err.Error())
+		http.Error(w, "Could not parse the request", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	// interactive messages carry their token within the payload rather than as a field of the form.
+	token := form.Get("token")
+	if token == "" && form.Get("payload") != "" {
+		a := &interaction{}
+		if json.Unmarshal([]byte(form.Get("payload")), a) == nil {
+			token = a.Token
+		}
+	}
+
+	err = instance.Verify(r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), token, body, time.Now())
+	if err != nil {
+		log.Printf("Rejecting chat request due to: %s\n", err.Error())
+		http.Error(w, "The request could not be verified", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+	return body, form, true
+}
+
+func writeJSON(w http.ResponseWriter, m *Message) {
+	rtn, err := json.Marshal(m)
+	if err != nil {
+		http.Error(w, "Could not write the reply", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(rtn)
+}
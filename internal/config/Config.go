@@ -0,0 +1,232 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The stores the server may keep its data within.
+const (
+	Mongo = "mongo"
+	Mock  = "mock"
+)
+
+// Redacted replaces the value of every secret set within a redacted configuration.
+const Redacted = "REDACTED"
+
+// Config holds every setting of the server. Settings are layered, each layer overriding the last: the defaults returned by Default, a YAML or TOML configuration file,
+// environment variables and finally command-line flags.
+type Config struct {
+	// Store states where the server keeps its data, either Mongo or Mock, the mock store holding a fixed set of data in memory.
+	Store         string       `yaml:"store" toml:"store"`
+	Mongo         MongoConfig  `yaml:"mongo" toml:"mongo"`
+	HTTP          HTTPConfig   `yaml:"http" toml:"http"`
+	GRPC          GRPCConfig   `yaml:"grpc" toml:"grpc"`
+	TLS           TLSConfig    `yaml:"tls" toml:"tls"`
+	Chat          ChatConfig   `yaml:"chat" toml:"chat"`
+	Notifications NotifyConfig `yaml:"notifications" toml:"notifications"`
+}
+
+// MongoConfig holds the settings for connecting to the mongo database, authenticating only should both a username and password be given.
+type MongoConfig struct {
+	Host     string `yaml:"host" toml:"host"`
+	Port     int    `yaml:"port" toml:"port"`
+	Database string `yaml:"database" toml:"database"`
+	Username string `yaml:"username" toml:"username"`
+	Password string `yaml:"password" toml:"password"`
+}
+
+// URL returns the address the mongo database is dialled at.
+func (mc MongoConfig) URL() string {
+	return net.JoinHostPort(mc.Host, fmt.Sprint(mc.Port))
+}
+
+// HTTPConfig holds the settings of the HTTP server. A zero timeout is disabled; ReadTimeout and WriteTimeout also bound websocket and event stream connections, so are
+// disabled by default.
+type HTTPConfig struct {
+	Addr string `yaml:"addr" toml:"addr"`
+	// CORSOrigins lists the origins browsers may call the server from, "*" allowing every origin.
+	CORSOrigins       []string      `yaml:"corsOrigins" toml:"corsOrigins"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" toml:"readTimeout"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" toml:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" toml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" toml:"idleTimeout"`
+}
+
+// GRPCConfig holds the settings of the gRPC poll service.
+type GRPCConfig struct {
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+// TLSConfig holds the certificate and key both the HTTP server and gRPC poll service are served over TLS with, TLS being disabled should neither be given.
+type TLSConfig struct {
+	CertFile string `yaml:"certFile" toml:"certFile"`
+	KeyFile  string `yaml:"keyFile" toml:"keyFile"`
+}
+
+// Enabled returns whether the server should be served over TLS.
+func (tc TLSConfig) Enabled() bool {
+	return tc.CertFile != "" || tc.KeyFile != ""
+}
+
+// ChatConfig holds the secrets slash commands sent by Slack or Mattermost are verified with, the signing secret being preferred should both be given.
+type ChatConfig struct {
+	SigningSecret string `yaml:"signingSecret" toml:"signingSecret"`
+	Token         string `yaml:"token" toml:"token"`
+}
+
+// NotifyConfig holds the settings of the notifications reminding users to vote and announcing poll results.
+type NotifyConfig struct {
+	RemindBefore time.Duration `yaml:"remindBefore" toml:"remindBefore"`
+	SMTP         SMTPConfig    `yaml:"smtp" toml:"smtp"`
+	// VAPIDPrivateKey is the base64url encoded key identifying the server to web push services, with VAPIDSubject the mailto: or https: URL they may contact the operator at.
+	VAPIDPrivateKey string `yaml:"vapidPrivateKey" toml:"vapidPrivateKey"`
+	VAPIDSubject    string `yaml:"vapidSubject" toml:"vapidSubject"`
+	// Log names the file notifications sent through the log channel are written to, defaulting to the server's log.
+	Log string `yaml:"log" toml:"log"`
+}
+
+// SMTPConfig holds the settings of the SMTP server notifications are emailed through, email notifications being disabled should no Addr be given.
+type SMTPConfig struct {
+	Addr     string `yaml:"addr" toml:"addr"`
+	From     string `yaml:"from" toml:"from"`
+	Username string `yaml:"username" toml:"username"`
+	Password string `yaml:"password" toml:"password"`
+}
+
+// Default returns the configuration used for any setting not given by a file, the environment or a flag.
+func Default() *Config {
+	return &Config{
+		Store: Mongo,
+		Mongo: MongoConfig{
+			Host:     "localhost",
+			Port:     27017,
+			Database: "takeawayServer",
+		},
+		HTTP: HTTPConfig{
+			Addr:              ":8080",
+			CORSOrigins:       []string{"*"},
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		},
+		GRPC: GRPCConfig{Addr: ":9090"},
+		Notifications: NotifyConfig{
+			RemindBefore: 15 * time.Minute,
+			SMTP:         SMTPConfig{From: "takeaway@localhost"},
+			VAPIDSubject: "mailto:takeaway@localhost",
+		},
+	}
+}
+
+// Validate checks the configuration can be used to start the server, returning an error listing every setting which cannot.
+func (c *Config) Validate() error {
+	var problems []string
+	fail := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch c.Store {
+	case Mongo:
+		if c.Mongo.Host == "" {
+			fail("mongo.host must be given")
+		}
+		if c.Mongo.Port < 1 || c.Mongo.Port > 65535 {
+			fail("mongo.port must be between 1 and 65535, got %v", c.Mongo.Port)
+		}
+		if c.Mongo.Database == "" {
+			fail("mongo.database must be given")
+		}
+		if (c.Mongo.Username == "") != (c.Mongo.Password == "") {
+			fail("mongo.username and mongo.password must be given together")
+		}
+	case Mock:
+	default:
+		fail("store must be one of %s or %s, got %q", Mongo, Mock, c.Store)
+	}
+
+	for _, l := range []struct{ name, addr string }{{"http.addr", c.HTTP.Addr}, {"grpc.addr", c.GRPC.Addr}} {
+		if _, _, err := net.SplitHostPort(l.addr); err != nil {
+			fail("%s must be a host:port address to listen on, got %q", l.name, l.addr)
+		}
+	}
+	if c.HTTP.Addr == c.GRPC.Addr {
+		fail("http.addr and grpc.addr must differ, both are %q", c.HTTP.Addr)
+	}
+
+	if len(c.HTTP.CORSOrigins) == 0 {
+		fail("http.corsOrigins must list at least one origin")
+	}
+	for i, origin := range c.HTTP.CORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			fail("http.corsOrigins[%d] must be \"*\" or an origin such as https://lunch.example.com, got %q", i, origin)
+		}
+	}
+
+	for _, t := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"http.readTimeout", c.HTTP.ReadTimeout},
+		{"http.readHeaderTimeout", c.HTTP.ReadHeaderTimeout},
+		{"http.writeTimeout", c.HTTP.WriteTimeout},
+		{"http.idleTimeout", c.HTTP.IdleTimeout},
+		{"notifications.remindBefore", c.Notifications.RemindBefore},
+	} {
+		if t.d < 0 {
+			fail("%s must not be negative, got %s", t.name, t.d)
+		}
+	}
+
+	if c.TLS.Enabled() {
+		if c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
+			fail("tls.certFile and tls.keyFile must be given together")
+		} else if _, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile); err != nil {
+			fail("tls.certFile and tls.keyFile could not be loaded: %s", err.Error())
+		}
+	}
+
+	if c.Notifications.SMTP.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.Notifications.SMTP.Addr); err != nil {
+			fail("notifications.smtp.addr must be a host:port address, got %q", c.Notifications.SMTP.Addr)
+		}
+		if _, err := mail.ParseAddress(c.Notifications.SMTP.From); err != nil {
+			fail("notifications.smtp.from must be an email address, got %q", c.Notifications.SMTP.From)
+		}
+	}
+	if s := c.Notifications.VAPIDSubject; !strings.HasPrefix(s, "mailto:") && !strings.HasPrefix(s, "https://") {
+		fail("notifications.vapidSubject must be a mailto: or https: URL, got %q", s)
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Redact returns a copy of the configuration with every secret which has been set replaced by Redacted, allowing it to be shown without revealing them.
+func (c *Config) Redact() *Config {
+	r := *c
+	r.HTTP.CORSOrigins = append([]string(nil), c.HTTP.CORSOrigins...)
+	for _, secret := range []*string{
+		&r.Mongo.Password,
+		&r.Chat.SigningSecret,
+		&r.Chat.Token,
+		&r.Notifications.SMTP.Password,
+		&r.Notifications.VAPIDPrivateKey,
+	} {
+		if *secret != "" {
+			*secret = Redacted
+		}
+	}
+	return &r
+}
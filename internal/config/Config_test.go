@@ -0,0 +1,204 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func load(t *testing.T, args []string, vars map[string]string) (*Config, []string) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	c, rest, err := Load(fs, args, env(vars))
+	if err != nil {
+		t.Fatalf("Could not load configuration: %s", err)
+	}
+	return c, rest
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("Could not create directory: %s", err)
+	}
+	path := filepath.Join(dir, name)
+	if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Could not write %s: %s", path, err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	c, rest := load(t, []string{"import", "restaurants.csv"}, nil)
+
+	if c.Store != Mongo || c.Mongo.URL() != "localhost:27017" || c.HTTP.Addr != ":8080" || c.GRPC.Addr != ":9090" || len(c.HTTP.CORSOrigins) != 1 {
+		t.Logf("Expected the defaults, got %+v", c)
+		t.Fail()
+	}
+	if len(rest) != 2 || rest[0] != "import" {
+		t.Logf("Expected the arguments after the flags to be returned, got %v", rest)
+		t.Fail()
+	}
+	if err := c.Validate(); err != nil {
+		t.Logf("Expected the defaults to be valid, got %s", err)
+		t.Fail()
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	path := writeFile(t, "takeaway.yaml", `
+mongo:
+  host: db.internal
+  port: 27018
+  database: lunch
+http:
+  addr: ":8443"
+  corsOrigins: ["https://lunch.example.com"]
+  writeTimeout: 30s
+`)
+
+	c, _ := load(t, []string{"-config", path, "-mongoPort", "27019", "-corsOrigins", "https://a.example.com, https://b.example.com"}, map[string]string{
+		"TAKEAWAY_MONGO_HOST":          "env.internal",
+		"TAKEAWAY_MONGO_PORT":          "27020",
+		"TAKEAWAY_HTTP_READ_TIMEOUT":   "5s",
+		"TAKEAWAY_CHAT_SIGNING_SECRET": "secret",
+	})
+
+	if c.Mongo.Database != "lunch" || c.HTTP.Addr != ":8443" || c.HTTP.WriteTimeout != 30*time.Second {
+		t.Logf("Expected the file to override the defaults, got %+v", c)
+		t.Fail()
+	}
+	if c.Mongo.Host != "env.internal" || c.HTTP.ReadTimeout != 5*time.Second || c.Chat.SigningSecret != "secret" {
+		t.Logf("Expected the environment to override the file, got %+v", c)
+		t.Fail()
+	}
+	if c.Mongo.Port != 27019 || strings.Join(c.HTTP.CORSOrigins, " ") != "https://a.example.com https://b.example.com" {
+		t.Logf("Expected the flags to override the environment, got %+v", c)
+		t.Fail()
+	}
+	if c.GRPC.Addr != ":9090" {
+		t.Logf("Expected settings given by no layer to keep their defaults, got %+v", c)
+		t.Fail()
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "takeaway.toml", `
+store = "mock"
+
+[notifications]
+remindBefore = "5m"
+
+[notifications.smtp]
+addr = "mail.example.com:587"
+`)
+
+	c, _ := load(t, nil, map[string]string{FileEnv: path})
+	if c.Store != Mock || c.Notifications.RemindBefore != 5*time.Minute || c.Notifications.SMTP.Addr != "mail.example.com:587" || c.Notifications.SMTP.From != "takeaway@localhost" {
+		t.Fatalf("Expected the file given by %s to be read, got %+v", FileEnv, c)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	misspelt := writeFile(t, "takeaway.yaml", "mongo:\n  hots: db.internal\n")
+	unknown := writeFile(t, "takeaway.toml", "[mongo]\nhots = \"db.internal\"\n")
+	ini := writeFile(t, "takeaway.ini", "store = mock\n")
+
+	cases := []struct {
+		args []string
+		vars map[string]string
+	}{
+		{[]string{"-config", misspelt}, nil},
+		{[]string{"-config", unknown}, nil},
+		{[]string{"-config", ini}, nil},
+		{[]string{"-config", filepath.Join(os.TempDir(), "missing.yaml")}, nil},
+		{nil, map[string]string{"TAKEAWAY_MONGO_PORT": "mongo"}},
+		{[]string{"-remindBefore", "soon"}, nil},
+	}
+
+	for i, c := range cases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		if _, _, err := Load(fs, c.args, env(c.vars)); err == nil {
+			t.Logf("Expected case %v to fail to load", i)
+			t.Fail()
+		}
+	}
+}
+
+func TestUseMockData(t *testing.T) {
+	c, _ := load(t, []string{"-useMockData"}, map[string]string{"TAKEAWAY_STORE": "mongo"})
+	if c.Store != Mock {
+		t.Fatalf("Expected -useMockData to select the mock store, got %s", c.Store)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		change  func(c *Config)
+		problem string
+	}{
+		{func(c *Config) { c.Store = "postgres" }, "store"},
+		{func(c *Config) { c.Mongo.Port = 0 }, "mongo.port"},
+		{func(c *Config) { c.Mongo.Username = "root" }, "mongo.username"},
+		{func(c *Config) { c.HTTP.Addr = "8080" }, "http.addr"},
+		{func(c *Config) { c.GRPC.Addr = ":8080" }, "grpc.addr"},
+		{func(c *Config) { c.HTTP.CORSOrigins = nil }, "http.corsOrigins"},
+		{func(c *Config) { c.HTTP.CORSOrigins = []string{"lunch.example.com"} }, "http.corsOrigins[0]"},
+		{func(c *Config) { c.HTTP.IdleTimeout = -time.Second }, "http.idleTimeout"},
+		{func(c *Config) { c.TLS.CertFile = "cert.pem" }, "tls.certFile"},
+		{func(c *Config) { c.TLS.CertFile, c.TLS.KeyFile = "missing.pem", "missing.key" }, "could not be loaded"},
+		{func(c *Config) { c.Notifications.SMTP.Addr = "mail.example.com" }, "notifications.smtp.addr"},
+		{func(c *Config) { c.Notifications.VAPIDSubject = "takeaway@localhost" }, "notifications.vapidSubject"},
+	}
+
+	for i, tc := range cases {
+		c := Default()
+		tc.change(c)
+		err := c.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.problem) {
+			t.Logf("Expected case %v to be invalid due to %s, got %v", i, tc.problem, err)
+			t.Fail()
+		}
+	}
+
+	mock := Default()
+	mock.Store, mock.Mongo.Host = Mock, ""
+	if err := mock.Validate(); err != nil {
+		t.Logf("Expected mongo settings to be ignored by the mock store, got %s", err)
+		t.Fail()
+	}
+}
+
+func TestRedact(t *testing.T) {
+	c := Default()
+	c.Mongo.Username, c.Mongo.Password = "root", "example"
+	c.Chat.Token = "token"
+	c.Notifications.SMTP.Password = "smtp"
+	c.Notifications.VAPIDPrivateKey = "key"
+
+	r := c.Redact()
+	if r.Mongo.Password != Redacted || r.Chat.Token != Redacted || r.Notifications.SMTP.Password != Redacted || r.Notifications.VAPIDPrivateKey != Redacted {
+		t.Logf("Expected every secret to be redacted, got %+v", r)
+		t.Fail()
+	}
+	if r.Chat.SigningSecret != "" || r.Mongo.Username != "root" {
+		t.Logf("Expected unset secrets and other settings to be kept, got %+v", r)
+		t.Fail()
+	}
+	if c.Mongo.Password != "example" {
+		t.Logf("Expected the configuration redacted to be left unchanged, got %s", c.Mongo.Password)
+		t.Fail()
+	}
+}
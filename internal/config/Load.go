@@ -0,0 +1,203 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// FileEnv names the environment variable giving the configuration file, should the -config flag not be given.
+const FileEnv = "TAKEAWAY_CONFIG"
+
+// setting ties a command-line flag to the environment variable which may also set it.
+type setting struct {
+	flag string
+	env  string
+}
+
+// settings lists every flag registered by bind along with its environment variable.
+var settings = []setting{
+	{"store", "TAKEAWAY_STORE"},
+	{"mongoHost", "TAKEAWAY_MONGO_HOST"},
+	{"mongoPort", "TAKEAWAY_MONGO_PORT"},
+	{"mongoDB", "TAKEAWAY_MONGO_DATABASE"},
+	{"mongoUsername", "TAKEAWAY_MONGO_USERNAME"},
+	{"mongoPassword", "TAKEAWAY_MONGO_PASSWORD"},
+	{"httpAddr", "TAKEAWAY_HTTP_ADDR"},
+	{"corsOrigins", "TAKEAWAY_HTTP_CORS_ORIGINS"},
+	{"readTimeout", "TAKEAWAY_HTTP_READ_TIMEOUT"},
+	{"readHeaderTimeout", "TAKEAWAY_HTTP_READ_HEADER_TIMEOUT"},
+	{"writeTimeout", "TAKEAWAY_HTTP_WRITE_TIMEOUT"},
+	{"idleTimeout", "TAKEAWAY_HTTP_IDLE_TIMEOUT"},
+	{"grpcAddr", "TAKEAWAY_GRPC_ADDR"},
+	{"tlsCert", "TAKEAWAY_TLS_CERT_FILE"},
+	{"tlsKey", "TAKEAWAY_TLS_KEY_FILE"},
+	{"chatSigningSecret", "TAKEAWAY_CHAT_SIGNING_SECRET"},
+	{"chatToken", "TAKEAWAY_CHAT_TOKEN"},
+	{"remindBefore", "TAKEAWAY_NOTIFICATIONS_REMIND_BEFORE"},
+	{"smtpAddr", "TAKEAWAY_SMTP_ADDR"},
+	{"smtpFrom", "TAKEAWAY_SMTP_FROM"},
+	{"smtpUsername", "TAKEAWAY_SMTP_USERNAME"},
+	{"smtpPassword", "TAKEAWAY_SMTP_PASSWORD"},
+	{"vapidPrivateKey", "TAKEAWAY_VAPID_PRIVATE_KEY"},
+	{"vapidSubject", "TAKEAWAY_VAPID_SUBJECT"},
+	{"notificationLog", "TAKEAWAY_NOTIFICATION_LOG"},
+}
+
+// Load builds the server's configuration from the defaults, the configuration file named by the -config flag or FileEnv, the environment variables listed by settings and the
+// flags within args, registering its flags on fs. The arguments remaining after the flags are returned, along with an error should any layer not be understood. The returned
+// configuration has not been validated.
+func Load(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (*Config, []string, error) {
+	c := Default()
+	file := fs.String("config", "", "YAML or TOML file to read settings from, overridden by environment variables and flags. Can also be given by "+FileEnv+".")
+	bind(fs, c)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	// the flags given are put aside, then applied again once the file and environment have been read so they override both.
+	given := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = f.Value.String()
+	})
+
+	*c = *Default()
+	path := *file
+	if path == "" {
+		path, _ = lookupEnv(FileEnv)
+	}
+	if path != "" {
+		if err := c.readFile(path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if v, ok := lookupEnv(s.env); ok {
+			if err := fs.Lookup(s.flag).Value.Set(v); err != nil {
+				return nil, nil, fmt.Errorf("could not parse %s: %s", s.env, err.Error())
+			}
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "config" {
+			f.Value.Set(given[f.Name])
+		}
+	})
+	return c, fs.Args(), nil
+}
+
+// readFile reads the YAML or TOML file at the given path into the configuration, the format being chosen by the file's extension. Settings the configuration does not have are
+// rejected, catching misspelt settings.
+func (c *Config) readFile(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(b, c)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.NewDecoder(bytes.NewReader(b)).Decode(c)
+		if err == nil {
+			if undecoded := md.Undecoded(); len(undecoded) > 0 {
+				err = fmt.Errorf("unknown setting %s", undecoded[0])
+			}
+		}
+	default:
+		return fmt.Errorf("could not read %s, configuration files must end in .yaml, .yml or .toml", path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not read %s: %s", path, err.Error())
+	}
+	return nil
+}
+
+// bind registers a flag on fs for each setting listed by settings, setting the given configuration.
+func bind(fs *flag.FlagSet, c *Config) {
+	fs.StringVar(&c.Store, "store", c.Store, "specify where the server should store data, either mongo or mock. The mock store holds a fixed set of data in memory.")
+	fs.Var(mockFlag{c}, "useMockData", "specify whether or not the server should utilise mock data sources. Equivalent to -store mock.")
+	fs.StringVar(&c.Mongo.Host, "mongoHost", c.Mongo.Host, "specify the host the mongo server is currently running on.")
+	fs.IntVar(&c.Mongo.Port, "mongoPort", c.Mongo.Port, "specify the port the mongo server is currently running on.")
+	fs.StringVar(&c.Mongo.Database, "mongoDB", c.Mongo.Database, "name of the mongo database where the server should be storing data to.")
+	fs.StringVar(&c.Mongo.Username, "mongoUsername", c.Mongo.Username, "username for authenticating with specified mongo database. Can be omitted if authentication is not required.")
+	fs.StringVar(&c.Mongo.Password, "mongoPassword", c.Mongo.Password, "password for authenticating with specified mongo datbase. Can be omitted if authentication is not required.")
+	fs.StringVar(&c.HTTP.Addr, "httpAddr", c.HTTP.Addr, "specify the address the HTTP server should listen on.")
+	fs.Var((*listFlag)(&c.HTTP.CORSOrigins), "corsOrigins", "comma separated origins browsers may call the server from, * allowing every origin.")
+	fs.DurationVar(&c.HTTP.ReadTimeout, "readTimeout", c.HTTP.ReadTimeout, "how long the HTTP server may take to read a request, including its body. Also bounds websocket connections, so 0 disables it.")
+	fs.DurationVar(&c.HTTP.ReadHeaderTimeout, "readHeaderTimeout", c.HTTP.ReadHeaderTimeout, "how long the HTTP server may take to read a request's headers.")
+	fs.DurationVar(&c.HTTP.WriteTimeout, "writeTimeout", c.HTTP.WriteTimeout, "how long the HTTP server may take to write a response. Also bounds websocket and event stream connections, so 0 disables it.")
+	fs.DurationVar(&c.HTTP.IdleTimeout, "idleTimeout", c.HTTP.IdleTimeout, "how long the HTTP server keeps idle keep-alive connections open.")
+	fs.StringVar(&c.GRPC.Addr, "grpcAddr", c.GRPC.Addr, "specify the address the gRPC poll service should listen on.")
+	fs.StringVar(&c.TLS.CertFile, "tlsCert", c.TLS.CertFile, "certificate file the HTTP server and gRPC poll service are served over TLS with. TLS is disabled should it be omitted along with tlsKey.")
+	fs.StringVar(&c.TLS.KeyFile, "tlsKey", c.TLS.KeyFile, "private key file of the certificate given by tlsCert.")
+	fs.StringVar(&c.Chat.SigningSecret, "chatSigningSecret", c.Chat.SigningSecret, "signing secret used to verify slash commands sent by Slack. Can be omitted should chatToken be given.")
+	fs.StringVar(&c.Chat.Token, "chatToken", c.Chat.Token, "verification token used to verify slash commands sent by Mattermost or Slack. Ignored should chatSigningSecret be given.")
+	fs.DurationVar(&c.Notifications.RemindBefore, "remindBefore", c.Notifications.RemindBefore, "how long before a poll closes reminders are sent to users who have not voted.")
+	fs.StringVar(&c.Notifications.SMTP.Addr, "smtpAddr", c.Notifications.SMTP.Addr, "host:port of the SMTP server notifications are emailed through. Email notifications are disabled should it be omitted.")
+	fs.StringVar(&c.Notifications.SMTP.From, "smtpFrom", c.Notifications.SMTP.From, "the address notification emails are sent from.")
+	fs.StringVar(&c.Notifications.SMTP.Username, "smtpUsername", c.Notifications.SMTP.Username, "username for authenticating with the SMTP server. Can be omitted if authentication is not required.")
+	fs.StringVar(&c.Notifications.SMTP.Password, "smtpPassword", c.Notifications.SMTP.Password, "password for authenticating with the SMTP server. Can be omitted if authentication is not required.")
+	fs.StringVar(&c.Notifications.VAPIDPrivateKey, "vapidPrivateKey", c.Notifications.VAPIDPrivateKey, "base64url encoded VAPID private key identifying the server to web push services. Can be omitted should push services not require it.")
+	fs.StringVar(&c.Notifications.VAPIDSubject, "vapidSubject", c.Notifications.VAPIDSubject, "the mailto: or https: URL push services may contact the server's operator at.")
+	fs.StringVar(&c.Notifications.Log, "notificationLog", c.Notifications.Log, "file notifications sent through the log channel are written to, defaulting to the server's log.")
+}
+
+// listFlag is a flag holding a comma separated list of values.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = nil
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// mockFlag is the boolean -useMockData flag, kept for existing deployments, which selects the mock store.
+type mockFlag struct {
+	c *Config
+}
+
+func (m mockFlag) String() string {
+	if m.c == nil {
+		return "false"
+	}
+	return fmt.Sprint(m.c.Store == Mock)
+}
+
+func (m mockFlag) Set(s string) error {
+	mock, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if mock {
+		m.c.Store = Mock
+	}
+	return nil
+}
+
+// IsBoolFlag allows -useMockData to be given without a value.
+func (m mockFlag) IsBoolFlag() bool {
+	return true
+}
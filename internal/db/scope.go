@@ -0,0 +1,18 @@
+package db
+
+import "gopkg.in/mgo.v2/bson"
+
+// Scope restricts the given query to documents belonging to the given workspace. Documents stored before workspaces were introduced have no workspace, so are treated as
+// belonging to the default workspace, represented by an empty string.
+func Scope(workspace string, query bson.M) bson.M {
+	if query == nil {
+		query = bson.M{}
+	}
+
+	if workspace == "" {
+		query["workspace"] = bson.M{"$in": []interface{}{"", nil}}
+	} else {
+		query["workspace"] = workspace
+	}
+	return query
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"sync"
+
+	"github.com/globalsign/mgo"
+)
+
+var sessionMutex = &sync.Mutex{}
+
+// OpenSessionIfRequired dials the mongo instance at the given url should the given session not already be open, logging in with the given credentials should they be specified.
+func OpenSessionIfRequired(session **mgo.Session, url string, username string, password string) (err error) {
+	if *session == nil {
+		sessionMutex.Lock()
+		defer sessionMutex.Unlock()
+		if *session == nil {
+			var s *mgo.Session
+			s, err = mgo.Dial(url)
+			if err != nil {
+				return
+			}
+
+			if username != "" && password != "" {
+				err = s.Login(&mgo.Credential{Username: username, Password: password})
+				if err != nil {
+					s.Close()
+					return
+				}
+			}
+			*session = s
+		}
+	}
+	return
+}
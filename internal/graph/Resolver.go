@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"context"
+
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/websocket"
+
+	"github.com/graphql-go/graphql"
+)
+
+var instance *Resolver
+
+// Resolver provides the data sources used to resolve GraphQL requests which are not accessed through the vote package.
+type Resolver struct {
+	Restaurants catalogue.RestaurantModel
+	// Hub is the hub whose broadcasts are forwarded to subscriptions.
+	Hub *websocket.Hub
+}
+
+// Init allows the graph package to be initialised with the Resolver r.
+func Init(r *Resolver) {
+	instance = r
+}
+
+// Do executes the given GraphQL query or mutation against the schema. The caller's workspace and user are read from the given context.
+func Do(ctx context.Context, query string, variables map[string]interface{}, operationName string) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        ctx,
+	})
+}
+
+// Subscribe executes the given GraphQL subscription against the schema, returning a channel receiving a result for each update. The subscription ends once the given context is
+// done.
+func Subscribe(ctx context.Context, query string, variables map[string]interface{}, operationName string) chan *graphql.Result {
+	return graphql.Subscribe(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        ctx,
+	})
+}
@@ -0,0 +1,552 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/graphql-go/graphql"
+)
+
+// schema is the GraphQL schema served by the graph package. Its resolvers read and change polls through the operations of the vote package, ensuring changes made through
+// GraphQL are recorded and broadcast in the same way as changes made through the REST API.
+var schema graphql.Schema
+
+// Voter represents a single user's vote within a poll.
+type Voter struct {
+	User   string               `json:"user"`
+	Option *restaurant.Building `json:"option"`
+}
+
+// Tally represents the votes cast for a single option of a poll.
+type Tally struct {
+	Option   *restaurant.Building `json:"option"`
+	Votes    int                  `json:"votes"`
+	Weighted int                  `json:"weighted"`
+	Vetoed   bool                 `json:"vetoed"`
+	Voters   []string             `json:"voters"`
+}
+
+// Delivery represents whether a single option of a poll can deliver to the poll's office.
+type Delivery struct {
+	Option     *restaurant.Building `json:"option"`
+	Distance   *float64             `json:"distance"`
+	Open       *bool                `json:"open"`
+	CanDeliver bool                 `json:"canDeliver"`
+	Reason     string               `json:"reason"`
+}
+
+var locationType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Location",
+	Description: "A latitude and longitude in degrees.",
+	Fields: graphql.Fields{
+		"lat": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"lng": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+var restaurantType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Restaurant",
+	Description: "A restaurant that can be voted for within a poll.",
+	Fields: graphql.Fields{
+		"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"address":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"location":       &graphql.Field{Type: locationType, Description: "The restaurant's coordinates, null should they be unknown."},
+		"deliveryRadius": &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "The distance in metres the restaurant delivers within, 0 should it be unknown."},
+		"service":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "delivery, collection or both, empty should it be unknown."},
+	},
+})
+
+var deliveryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Delivery",
+	Fields: graphql.Fields{
+		"option":     &graphql.Field{Type: graphql.NewNonNull(restaurantType)},
+		"distance":   &graphql.Field{Type: graphql.Float, Description: "The distance in metres between the option and the poll's office, null should it be unknown."},
+		"open":       &graphql.Field{Type: graphql.Boolean, Description: "Whether the option is open at the poll's closing time, null should its opening hours be unknown."},
+		"canDeliver": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"reason":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Why the option cannot deliver, empty should it be able to."},
+	},
+})
+
+var restaurantInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RestaurantInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"id":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"name":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"address": &graphql.InputObjectFieldConfig{Type: graphql.String},
+	},
+})
+
+var voterType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Voter",
+	Fields: graphql.Fields{
+		"user":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"option": &graphql.Field{Type: restaurantType, Description: "The option voted for, null should the option no longer be within the poll."},
+	},
+})
+
+var tallyType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Tally",
+	Fields: graphql.Fields{
+		"option": &graphql.Field{Type: graphql.NewNonNull(restaurantType)},
+		"votes":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "The number of votes cast for the option."},
+		"weighted": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: "The number of votes cast for the option, each vote counting as many times as the weight of the user who cast it.",
+		},
+		"vetoed": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Description: "Whether the option has been vetoed, preventing it from winning."},
+		"voters": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			Description: "The users who voted for the option, only including the caller within private polls.",
+		},
+	},
+})
+
+var visibilityType = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Visibility",
+	Description: "Who may see the votes cast within a poll.",
+	Values: graphql.EnumValueConfigMap{
+		"PUBLIC":    &graphql.EnumValueConfig{Value: string(vote.Public), Description: "Every user can see who voted for each option."},
+		"ANONYMOUS": &graphql.EnumValueConfig{Value: string(vote.Anonymous), Description: "Only the number of votes for each option is shown."},
+		"SECRET":    &graphql.EnumValueConfig{Value: string(vote.Secret), Description: "The number of votes for each option is hidden until the poll closes."},
+	},
+})
+
+var outcomeType = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Outcome",
+	Description: "How a poll was settled against the quorum and margin set by its organiser.",
+	Values: graphql.EnumValueConfigMap{
+		"DECIDED": &graphql.EnumValueConfig{Value: string(vote.Decided), Description: "The poll met its rules, its winner being the result of the poll."},
+		"FAILED":  &graphql.EnumValueConfig{Value: string(vote.Failed), Description: "The poll closed without meeting its rules, so has no result."},
+	},
+})
+
+// view returns the view of the poll being resolved for the caller, private polls only showing the caller their own vote.
+func view(p graphql.ResolveParams) *vote.Poll {
+	return p.Source.(*vote.Poll).ViewFor(caller.User(p.Context), time.Now())
+}
+
+var pollType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Poll",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"workspace": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"creator":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"closesAt": &graphql.Field{
+			Type:        graphql.DateTime,
+			Description: "When voting ends, null should the poll have no closing time.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if t := p.Source.(*vote.Poll).ClosesAt; !t.IsZero() {
+					return t, nil
+				}
+				return nil, nil
+			},
+		},
+		"state": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "Whether the poll is open or closed.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return string(p.Source.(*vote.Poll).State(time.Now())), nil
+			},
+		},
+		"visibility": &graphql.Field{
+			Type: graphql.NewNonNull(visibilityType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if v := p.Source.(*vote.Poll).Visibility; v != "" {
+					return string(v), nil
+				}
+				return string(vote.Public), nil
+			},
+		},
+		"resultsHidden": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "Whether the number of votes for each option is hidden until the poll closes.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return view(p).ResultsHidden, nil
+			},
+		},
+		"outcome": &graphql.Field{
+			Type:        outcomeType,
+			Description: "How the poll was settled against its rules, null should the poll have no rules or not yet have been settled.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if o := p.Source.(*vote.Poll).Outcome; o != "" {
+					return string(o), nil
+				}
+				return nil, nil
+			},
+		},
+		"extensions": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: "The number of times the poll has been extended for closing without meeting its rules.",
+		},
+		"options":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(restaurantType)))},
+		"participants": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		"voters": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(voterType))),
+			Description: "The users who have voted, only including the caller within private polls.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				poll := view(p)
+				voters := make([]*Voter, 0)
+				for opt, users := range poll.Votes {
+					for _, u := range users {
+						voters = append(voters, &Voter{User: u, Option: poll.Option(opt)})
+					}
+				}
+				return voters, nil
+			},
+		},
+		"tally": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tallyType))),
+			Description: "The votes cast for each option, in the order the options are listed, being empty while the results are hidden.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				poll := view(p)
+				tally := make([]*Tally, 0, len(poll.Options))
+				for _, t := range poll.Results() {
+					voters := append([]string{}, poll.Votes[t.OptionID]...)
+					tally = append(tally, &Tally{Option: poll.Option(t.OptionID), Votes: t.Votes, Weighted: t.Weighted, Vetoed: t.Vetoed, Voters: voters})
+				}
+				return tally, nil
+			},
+		},
+		"office": &graphql.Field{Type: locationType, Description: "The location the order is delivered to, null should it be unknown."},
+		"delivery": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(deliveryType))),
+			Description: "Whether each option can deliver to the poll's office at the poll's closing time, being empty should the poll have no office.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				poll := view(p)
+				delivery := make([]*Delivery, 0, len(poll.Delivery))
+				for _, d := range poll.Delivery {
+					delivery = append(delivery, &Delivery{Option: poll.Option(d.OptionID), Distance: d.Distance, Open: d.Open, CanDeliver: d.CanDeliver, Reason: d.Reason})
+				}
+				return delivery, nil
+			},
+		},
+		"unavailable": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(restaurantType))),
+			Description: "The options whose restaurants are closed at the poll's closing time, which cannot be voted for.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				poll := p.Source.(*vote.Poll)
+				unavailable := make([]*restaurant.Building, 0, len(poll.Unavailable))
+				for _, id := range poll.Unavailable {
+					if opt := poll.Option(id); opt != nil {
+						unavailable = append(unavailable, opt)
+					}
+				}
+				return unavailable, nil
+			},
+		},
+		"winner": &graphql.Field{
+			Type:        restaurantType,
+			Description: "The option with the most votes, null should no votes have been cast.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if w := view(p).Winner(); w != nil {
+					return w, nil
+				}
+				return nil, nil
+			},
+		},
+	},
+})
+
+var pollPageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PollPage",
+	Fields: graphql.Fields{
+		"polls":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pollType)))},
+		"nextCursor": &graphql.Field{Type: graphql.String, Description: "The cursor of the next page, null should there be no more polls."},
+	},
+})
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"poll": &graphql.Field{
+			Type: pollType,
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				poll, _, err := vote.FindPoll(caller.Workspace(p.Context), p.Args["id"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return poll, nil
+			},
+		},
+		"polls": &graphql.Field{
+			Type: graphql.NewNonNull(pollPageType),
+			Args: graphql.FieldConfigArgument{
+				"creator":       &graphql.ArgumentConfig{Type: graphql.String},
+				"participant":   &graphql.ArgumentConfig{Type: graphql.String},
+				"state":         &graphql.ArgumentConfig{Type: graphql.String},
+				"restaurant":    &graphql.ArgumentConfig{Type: graphql.ID},
+				"createdAfter":  &graphql.ArgumentConfig{Type: graphql.DateTime},
+				"createdBefore": &graphql.ArgumentConfig{Type: graphql.DateTime},
+				"sort":          &graphql.ArgumentConfig{Type: graphql.String},
+				"first":         &graphql.ArgumentConfig{Type: graphql.Int},
+				"after":         &graphql.ArgumentConfig{Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				q := &vote.PollQuery{}
+				q.Creator, _ = p.Args["creator"].(string)
+				q.Participant, _ = p.Args["participant"].(string)
+				state, _ := p.Args["state"].(string)
+				q.State = vote.PollState(state)
+				q.Restaurant, _ = p.Args["restaurant"].(string)
+				q.CreatedAfter, _ = p.Args["createdAfter"].(time.Time)
+				q.CreatedBefore, _ = p.Args["createdBefore"].(time.Time)
+				q.Sort, _ = p.Args["sort"].(string)
+				q.Limit, _ = p.Args["first"].(int)
+				q.Cursor, _ = p.Args["after"].(string)
+				return listPolls(p.Context, q)
+			},
+		},
+		"restaurant": &graphql.Field{
+			Type: restaurantType,
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				b, _, err := instance.Restaurants.GetRestaurant(caller.Workspace(p.Context), p.Args["id"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return b, nil
+			},
+		},
+		"restaurants": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(restaurantType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				restaurants, _, err := instance.Restaurants.GetRestaurants(caller.Workspace(p.Context))
+				return restaurants, err
+			},
+		},
+	},
+})
+
+var mutationType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Mutation",
+	Fields: graphql.Fields{
+		"createPoll": &graphql.Field{
+			Type:        graphql.NewNonNull(pollType),
+			Description: "Creates a poll with the catalogue restaurants specified by restaurantIds along with the given options.",
+			Args: graphql.FieldConfigArgument{
+				"restaurantIds": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+				"options":       &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(restaurantInput))},
+				"allowSuggestions": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Whether voters other than the creator may suggest options, pending the creator's approval.",
+				},
+				"visibility": &graphql.ArgumentConfig{Type: visibilityType, DefaultValue: string(vote.Public)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				workspace := caller.Workspace(p.Context)
+
+				options := make([]*restaurant.Building, 0)
+				ids, _ := p.Args["restaurantIds"].([]interface{})
+				for _, id := range ids {
+					b, _, err := instance.Restaurants.GetRestaurant(workspace, id.(string))
+					if err != nil {
+						return nil, err
+					}
+					options = append(options, b)
+				}
+
+				inputs, _ := p.Args["options"].([]interface{})
+				for _, in := range inputs {
+					fields := in.(map[string]interface{})
+					b := &restaurant.Building{}
+					b.ID, _ = fields["id"].(string)
+					b.Name, _ = fields["name"].(string)
+					b.Address, _ = fields["address"].(string)
+					options = append(options, b)
+				}
+
+				if len(options) == 0 {
+					return nil, fmt.Errorf("a poll must have at least one option")
+				}
+
+				settings := &vote.PollSettings{}
+				settings.AllowSuggestions, _ = p.Args["allowSuggestions"].(bool)
+				visibility, _ := p.Args["visibility"].(string)
+				settings.Visibility = vote.Visibility(visibility)
+
+				poll, _, err := vote.CreatePoll(workspace, caller.User(p.Context), options, settings)
+				if err != nil {
+					return nil, err
+				}
+				return poll, nil
+			},
+		},
+		"castVote": &graphql.Field{
+			Type:        graphql.NewNonNull(pollType),
+			Description: "Casts a vote for the given option, replacing any earlier vote made by the user. The user defaults to the caller.",
+			Args: graphql.FieldConfigArgument{
+				"pollId":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				"optionId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				"user":     &graphql.ArgumentConfig{Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				user, err := voter(p)
+				if err != nil {
+					return nil, err
+				}
+
+				if _, err = vote.CheckVoter(caller.Workspace(p.Context), p.Args["pollId"].(string), caller.User(p.Context), user); err != nil {
+					return nil, err
+				}
+
+				poll, _, err := vote.CastVote(caller.Workspace(p.Context), p.Args["pollId"].(string), user, p.Args["optionId"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return poll, nil
+			},
+		},
+		"removeVote": &graphql.Field{
+			Type:        graphql.NewNonNull(pollType),
+			Description: "Removes the votes made by the user, defaulting to the caller.",
+			Args: graphql.FieldConfigArgument{
+				"pollId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				"user":   &graphql.ArgumentConfig{Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				user, err := voter(p)
+				if err != nil {
+					return nil, err
+				}
+
+				if _, err = vote.CheckVoter(caller.Workspace(p.Context), p.Args["pollId"].(string), caller.User(p.Context), user); err != nil {
+					return nil, err
+				}
+
+				poll, _, err := vote.RemoveVoter(caller.Workspace(p.Context), p.Args["pollId"].(string), user)
+				if err != nil {
+					return nil, err
+				}
+				return poll, nil
+			},
+		},
+	},
+})
+
+var subscriptionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Subscription",
+	Fields: graphql.Fields{
+		"pollUpdated": &graphql.Field{
+			Type:        graphql.NewNonNull(pollType),
+			Description: "Receives the poll with the given ID each time it changes.",
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+			},
+			Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+				id := p.Args["id"].(string)
+				// only allow clients to watch polls within their own workspace.
+				if _, _, err := vote.FindPoll(caller.Workspace(p.Context), id); err != nil {
+					return nil, err
+				}
+				return watch(p.Context, id), nil
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source, nil
+			},
+		},
+	},
+})
+
+func init() {
+	// the polls of a restaurant are added once the poll types exist, as polls themselves refer to restaurants.
+	restaurantType.AddFieldConfig("polls", &graphql.Field{
+		Type:        graphql.NewNonNull(pollPageType),
+		Description: "The polls the restaurant is an option within, newest first.",
+		Args: graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int},
+			"after": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			b := p.Source.(*restaurant.Building)
+			q := &vote.PollQuery{Restaurant: b.ID}
+			q.Limit, _ = p.Args["first"].(int)
+			q.Cursor, _ = p.Args["after"].(string)
+			return listPolls(p.Context, q)
+		},
+	})
+
+	var err error
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query:        queryType,
+		Mutation:     mutationType,
+		Subscription: subscriptionType,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("invalid GraphQL schema: %s", err.Error()))
+	}
+}
+
+// listPolls returns the page of polls within the caller's workspace matching the given query.
+func listPolls(ctx context.Context, q *vote.PollQuery) (interface{}, error) {
+	q.Viewer = caller.User(ctx)
+	page, _, err := vote.FindPolls(caller.Workspace(ctx), q)
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing cursor is returned as null rather than an empty string.
+	res := map[string]interface{}{"polls": page.Polls}
+	if page.NextCursor != "" {
+		res["nextCursor"] = page.NextCursor
+	}
+	return res, nil
+}
+
+// voter returns the user specified by the 'user' argument, defaulting to the caller.
+func voter(p graphql.ResolveParams) (string, error) {
+	user, _ := p.Args["user"].(string)
+	if user == "" {
+		user = caller.User(p.Context)
+	}
+	if user == "" {
+		return "", fmt.Errorf("a user must be specified")
+	}
+	return user, nil
+}
+
+// watch forwards each version of the poll with the given ID broadcast by the hub until the given context is done.
+func watch(ctx context.Context, id string) chan interface{} {
+	messages, stop := instance.Hub.Listen(id)
+	updates := make(chan interface{})
+
+	go func() {
+		defer close(updates)
+		defer stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				// the hub also broadcasts events for a poll, which are not part of the subscription.
+				poll, isPoll := msg.Data.(*vote.Poll)
+				if !isPoll {
+					continue
+				}
+				select {
+				case updates <- poll:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return updates
+}
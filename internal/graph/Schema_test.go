@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+// hub ensures the websocket hub is running, as mutations block while broadcasting changes.
+var hub sync.Once
+
+func setup() context.Context {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}})
+	Init(&Resolver{Restaurants: &catalogue.MockRestaurantModel{}, Hub: websocket.HubInstance})
+	return caller.NewContext(context.Background(), "", "Jack")
+}
+
+func TestQueryPoll(t *testing.T) {
+	ctx := setup()
+
+	res := Do(ctx, `{ poll(id: "test") { id options { id name } tally { option { id } votes } } }`, nil, "")
+	if res.HasErrors() {
+		t.Logf("Expected the poll to be queried, got errors %v", res.Errors)
+		t.FailNow()
+	}
+
+	poll := res.Data.(map[string]interface{})["poll"].(map[string]interface{})
+	if poll["id"] != "test" || len(poll["options"].([]interface{})) != 2 {
+		t.Logf("Expected poll test with 2 options, got %v", poll)
+		t.Fail()
+	}
+}
+
+func TestCastVote(t *testing.T) {
+	ctx := setup()
+
+	res := Do(ctx, `mutation { castVote(pollId: "test", optionId: "r2") { voters { user option { id } } } }`, nil, "")
+	if res.HasErrors() {
+		t.Logf("Expected the vote to be cast, got errors %v", res.Errors)
+		t.FailNow()
+	}
+
+	voters := res.Data.(map[string]interface{})["castVote"].(map[string]interface{})["voters"].([]interface{})
+	for _, v := range voters {
+		voter := v.(map[string]interface{})
+		if voter["user"] == "Jack" && voter["option"].(map[string]interface{})["id"] != "r2" {
+			t.Logf("Expected the caller's vote to move to r2, got %v", voter)
+			t.Fail()
+		}
+	}
+
+	res = Do(ctx, `mutation { castVote(pollId: "unknown", optionId: "r2") { id } }`, nil, "")
+	if !res.HasErrors() {
+		t.Log("Expected a vote within an unknown poll to fail")
+		t.Fail()
+	}
+}
+
+func TestSubscribePoll(t *testing.T) {
+	ctx := setup()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := Subscribe(ctx, `subscription { pollUpdated(id: "test") { voters { user option { id } } } }`, nil, "")
+	// the listener is registered once the subscription starts, so keep voting until an update is received.
+	go func() {
+		for ctx.Err() == nil {
+			vote.CastVote("", "test", "Kate", "r1")
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	select {
+	case res := <-results:
+		if res.HasErrors() {
+			t.Logf("Expected an update to the poll, got errors %v", res.Errors)
+			t.Fail()
+		}
+	case <-time.After(5 * time.Second):
+		t.Log("Expected an update to the poll to be received")
+		t.Fail()
+	}
+}
+
+func TestMutationOverGet(t *testing.T) {
+	setup()
+
+	w := httptest.NewRecorder()
+	q := url.Values{"query": {`mutation { removeVote(pollId: "test") { id } }`}}
+	ServeGraphQL(w, httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Logf("Expected a mutation made by a GET request to be rejected, got %v", w.Code)
+		t.Fail()
+	}
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// protocol is the websocket subprotocol used for subscriptions, see https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md.
+const protocol = "graphql-transport-ws"
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	Subprotocols:    []string{protocol},
+}
+
+// request represents the body of a GraphQL request, along with the payload of a subscribe message.
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// message represents a single message of the graphql-transport-ws protocol.
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// ServeGraphQL provides a http handler for the GraphQL API. Queries and mutations are accepted as a JSON body by POST requests, or using the 'query', 'variables' and
+// 'operationName' query parameters by GET requests, while websocket requests are served subscriptions using the graphql-transport-ws protocol.
+func ServeGraphQL(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		serveSubscriptions(w, r)
+		return
+	}
+
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				log.Printf("Could not parse GraphQL variables %s\n", v)
+				http.Error(w, "Could not parse variables", http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		b, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+
+		if err != nil {
+			log.Println("Could not read body of request")
+			http.Error(w, "Could not parse request", http.StatusInternalServerError)
+			return
+		}
+
+		if err = json.Unmarshal(b, &req); err != nil {
+			log.Printf("Could not parse %s into a GraphQL request\n", b)
+			http.Error(w, "Could not parse request", http.StatusBadRequest)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// mutations change state, so cannot be made by GET requests.
+	if r.Method == http.MethodGet && operation(req.Query, req.OperationName) == ast.OperationTypeMutation {
+		http.Error(w, "Mutations must be made using POST requests", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := Do(r.Context(), req.Query, req.Variables, req.OperationName)
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("The GraphQL result %v could not be serialised to JSON.\n", result)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// connection represents a websocket connection serving GraphQL operations, ensuring messages are not written to the connection concurrently.
+type connection struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+	// operations holds the function cancelling each running operation by ID.
+	operations map[string]context.CancelFunc
+	mutex      sync.Mutex
+}
+
+func (c *connection) send(m message) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	if err := c.conn.WriteJSON(m); err != nil {
+		log.Printf("Could not send GraphQL %s message due to: %s\n", m.Type, err.Error())
+	}
+}
+
+func (c *connection) next(id string, result *graphql.Result) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("The GraphQL result %v could not be serialised to JSON.\n", result)
+		return
+	}
+	c.send(message{ID: id, Type: "next", Payload: payload})
+}
+
+// serveSubscriptions serves GraphQL operations over a websocket using the graphql-transport-ws protocol, each operation running until it completes, the client completes it or
+// the connection is closed.
+func serveSubscriptions(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	defer conn.Close()
+
+	c := &connection{conn: conn, operations: make(map[string]context.CancelFunc)}
+
+	for {
+		var m message
+		if err := conn.ReadJSON(&m); err != nil {
+			return
+		}
+
+		switch m.Type {
+		case "connection_init":
+			c.send(message{Type: "connection_ack"})
+		case "ping":
+			c.send(message{Type: "pong"})
+		case "subscribe":
+			var req request
+			if err := json.Unmarshal(m.Payload, &req); err != nil {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(4400, "invalid subscribe payload"), time.Now().Add(time.Second))
+				return
+			}
+
+			opCtx, stop := context.WithCancel(ctx)
+			c.mutex.Lock()
+			c.operations[m.ID] = stop
+			c.mutex.Unlock()
+
+			go func(id string) {
+				defer func() {
+					c.mutex.Lock()
+					delete(c.operations, id)
+					c.mutex.Unlock()
+					stop()
+				}()
+
+				if operation(req.Query, req.OperationName) == ast.OperationTypeSubscription {
+					for result := range Subscribe(opCtx, req.Query, req.Variables, req.OperationName) {
+						c.next(id, result)
+					}
+				} else {
+					c.next(id, Do(opCtx, req.Query, req.Variables, req.OperationName))
+				}
+
+				// operations completed by the client are not completed again by the server.
+				if opCtx.Err() == nil {
+					c.send(message{ID: id, Type: "complete"})
+				}
+			}(m.ID)
+		case "complete":
+			c.mutex.Lock()
+			if stop, ok := c.operations[m.ID]; ok {
+				stop()
+			}
+			c.mutex.Unlock()
+		}
+	}
+}
+
+// operation returns the type of the operation with the given name within the given query, defaulting to a query should the operation not be found.
+func operation(query string, name string) string {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return ast.OperationTypeQuery
+	}
+
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok && (name == "" || op.Name != nil && op.Name.Value == name) {
+			return op.Operation
+		}
+	}
+	return ast.OperationTypeQuery
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Channel defines a contract for the ways notifications may be sent to users.
+type Channel interface {
+	// Send sends the given notification to the given address, returning an error should it not be accepted.
+	Send(address string, n *Notification) error
+}
+
+// SMTPChannel sends notifications as plain text emails through the SMTP server at Addr, authenticating using Username and Password should they be given.
+type SMTPChannel struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// Send emails the given notification to the given address.
+func (sc *SMTPChannel) Send(address string, n *Notification) error {
+	var auth smtp.Auth
+	if sc.Username != "" {
+		auth = smtp.PlainAuth("", sc.Username, sc.Password, strings.Split(sc.Addr, ":")[0])
+	}
+
+	msg := "From: " + sc.From + "\r\n" +
+		"To: " + address + "\r\n" +
+		"Subject: " + n.Subject + "\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" + n.Text + "\r\n"
+	return smtp.SendMail(sc.Addr, auth, sc.From, []string{address}, []byte(msg))
+}
+
+// PushChannel sends notifications as web push messages to the push subscription endpoint given as the address. Messages are sent without a payload, waking the user's service
+// worker to fetch their notifications from the server, and are identified to the push service using VAPID should a private key be given.
+type PushChannel struct {
+	Client *http.Client
+	// Key is the VAPID private key identifying the server to push services, with Subject being the mailto: or https: URL push services may contact the server's operator at.
+	Key     *ecdsa.PrivateKey
+	Subject string
+	// TTL states how long push services should keep messages for users who are offline, defaulting to an hour.
+	TTL time.Duration
+}
+
+// ParseVAPIDKey parses a VAPID private key given as the base64url encoded private scalar of a P-256 key, as generated by web push libraries.
+func ParseVAPIDKey(s string) (*ecdsa.PrivateKey, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(b) != 32 {
+		return nil, errors.New("a VAPID private key must be 32 bytes encoded using base64url")
+	}
+
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(b)}
+	key.Curve = elliptic.P256()
+	key.X, key.Y = key.Curve.ScalarBaseMult(b)
+	return key, nil
+}
+
+// Send pushes a message to the given push subscription endpoint.
+func (pc *PushChannel) Send(address string, n *Notification) error {
+	req, err := http.NewRequest(http.MethodPost, address, nil)
+	if err != nil {
+		return err
+	}
+
+	ttl := pc.TTL
+	if ttl == 0 {
+		ttl = time.Hour
+	}
+	req.Header.Set("TTL", fmt.Sprint(int(ttl.Seconds())))
+	if n.Kind == Reminder {
+		req.Header.Set("Urgency", "high")
+	}
+
+	if pc.Key != nil {
+		auth, err := pc.vapid(address, time.Now())
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", auth)
+	}
+
+	return do(pc.Client, req)
+}
+
+// vapid returns the Authorization header identifying the server to the push service of the given endpoint, as described by RFC 8292.
+func (pc *PushChannel) vapid(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": pc.Subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, pc.Key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	public := elliptic.Marshal(pc.Key.Curve, pc.Key.X, pc.Key.Y)
+	return fmt.Sprintf("vapid t=%s.%s, k=%s", unsigned, base64.RawURLEncoding.EncodeToString(sig), base64.RawURLEncoding.EncodeToString(public)), nil
+}
+
+// WebhookChannel sends notifications by posting them as JSON to the URL given as the address.
+type WebhookChannel struct {
+	Client *http.Client
+}
+
+// Send posts the given notification to the given URL.
+func (wc *WebhookChannel) Send(address string, n *Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(wc.Client, req)
+}
+
+// LogChannel writes notifications to a local file as JSON lines, or to the server's log should no Path be given, allowing notifications to be checked without sending them.
+type LogChannel struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+// Send writes the given notification along with the given address.
+func (lc *LogChannel) Send(address string, n *Notification) error {
+	line, err := json.Marshal(struct {
+		Address string `json:"address"`
+		*Notification
+	}{address, n})
+	if err != nil {
+		return err
+	}
+
+	if lc.Path == "" {
+		log.Printf("Notification to %s: %s\n", address, line)
+		return nil
+	}
+
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	f, err := os.OpenFile(lc.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// do sends the given request using the given client, or a client timing out after 10 seconds should none be given, returning an error should the response not be successful.
+func do(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with status %v", req.URL.Host, resp.StatusCode)
+	}
+	return nil
+}
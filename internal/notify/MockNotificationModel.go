@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockNotificationModel provides an in memory implementation of the NotificationModel interface.
+type MockNotificationModel struct {
+	mutex         sync.Mutex
+	contacts      []*Contact
+	notifications []*Notification
+}
+
+// GetContact returns the stored contact of the given user within the given workspace, returning an error along with a 'NotFound' status should there be none.
+func (nm *MockNotificationModel) GetContact(workspace string, user string) (c *Contact, status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	for _, contact := range nm.contacts {
+		if contact.Workspace == workspace && contact.User == user {
+			return contact, vote.Ok, nil
+		}
+	}
+	return nil, vote.NotFound, fmt.Errorf("%s has not registered a contact", user)
+}
+
+// GetContacts returns all stored contacts within the given workspace.
+func (nm *MockNotificationModel) GetContacts(workspace string) (contacts []*Contact, status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	contacts = make([]*Contact, 0)
+	for _, c := range nm.contacts {
+		if c.Workspace == workspace {
+			contacts = append(contacts, c)
+		}
+	}
+	return
+}
+
+// SetContact stores the given contact, replacing any stored contact of the same user within the same workspace.
+func (nm *MockNotificationModel) SetContact(c *Contact) (status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	for i, contact := range nm.contacts {
+		if contact.Workspace == c.Workspace && contact.User == c.User {
+			nm.contacts[i] = c
+			return
+		}
+	}
+	nm.contacts = append(nm.contacts, c)
+	return
+}
+
+// DeleteContact removes the stored contact of the given user within the given workspace, returning an error along with a 'NotFound' status should there be none.
+func (nm *MockNotificationModel) DeleteContact(workspace string, user string) (status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	for i, c := range nm.contacts {
+		if c.Workspace == workspace && c.User == user {
+			nm.contacts = append(nm.contacts[:i], nm.contacts[i+1:]...)
+			return
+		}
+	}
+	return vote.NotFound, fmt.Errorf("%s has not registered a contact", user)
+}
+
+// AddNotification stores the given notification, returning an error along with a 'Conflict' status should a notification of the same kind have been stored for the same user
+// and poll.
+func (nm *MockNotificationModel) AddNotification(n *Notification) (status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	for _, sent := range nm.notifications {
+		if sent.Workspace == n.Workspace && sent.PollID == n.PollID && sent.User == n.User && sent.Kind == n.Kind {
+			return vote.Conflict, fmt.Errorf("a %s for poll %s has already been sent to %s", n.Kind, n.PollID, n.User)
+		}
+	}
+	nm.notifications = append(nm.notifications, n)
+	return
+}
+
+// GetNotifications returns the stored notifications sent to the given user within the given workspace, most recent first.
+func (nm *MockNotificationModel) GetNotifications(workspace string, user string) (notifications []*Notification, status vote.Status, err error) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	notifications = make([]*Notification, 0)
+	for i := len(nm.notifications) - 1; i >= 0; i-- {
+		if n := nm.notifications[i]; n.Workspace == workspace && n.User == user {
+			notifications = append(notifications, n)
+		}
+	}
+	return
+}
+
+// Close has been added to ensure the mock meets the NotificationModel interface, it does not need to actually complete anything.
+func (nm *MockNotificationModel) Close() (err error) {
+	return
+}
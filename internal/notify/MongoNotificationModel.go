@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoNotificationModel provides a mongo based implementation to the NotificationModel interface, storing contacts within the contacts collection and sent notifications within
+// the notifications collection.
+type MongoNotificationModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// GetContact gets the contact of the given user within the given workspace from the mongo database.
+func (nm *MongoNotificationModel) GetContact(workspace string, user string) (c *Contact, status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := Contact{}
+	err = nm.session.DB(nm.DBName).C("contacts").Find(db.Scope(workspace, bson.M{"user": user})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	c = &data
+	return
+}
+
+// GetContacts returns all contacts stored within the mongo database for the given workspace.
+func (nm *MongoNotificationModel) GetContacts(workspace string) (contacts []*Contact, status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	contacts = make([]*Contact, 0)
+	err = nm.session.DB(nm.DBName).C("contacts").Find(db.Scope(workspace, nil)).All(&contacts)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// SetContact upserts the given contact within the mongo database.
+func (nm *MongoNotificationModel) SetContact(c *Contact) (status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	_, err = nm.session.DB(nm.DBName).C("contacts").Upsert(db.Scope(c.Workspace, bson.M{"user": c.User}), c)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// DeleteContact removes the contact of the given user within the given workspace from the mongo database.
+func (nm *MongoNotificationModel) DeleteContact(workspace string, user string) (status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	err = nm.session.DB(nm.DBName).C("contacts").Remove(db.Scope(workspace, bson.M{"user": user}))
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// AddNotification stores the given notification within the mongo database, a unique index ensuring a single notification of each kind is stored for each user and poll.
+func (nm *MongoNotificationModel) AddNotification(n *Notification) (status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := nm.session.DB(nm.DBName).C("notifications")
+	err = c.EnsureIndex(mgo.Index{Key: []string{"workspace", "pollId", "user", "kind"}, Unique: true})
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	err = c.Insert(n)
+	if mgo.IsDup(err) {
+		status = vote.Conflict
+	} else if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// GetNotifications returns the notifications sent to the given user within the given workspace stored within the mongo database, most recent first.
+func (nm *MongoNotificationModel) GetNotifications(workspace string, user string) (notifications []*Notification, status vote.Status, err error) {
+	err = nm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	notifications = make([]*Notification, 0)
+	err = nm.session.DB(nm.DBName).C("notifications").Find(db.Scope(workspace, bson.M{"user": user})).Sort("-sentAt").All(&notifications)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (nm *MongoNotificationModel) Close() (err error) {
+	if nm.session != nil {
+		nm.session.Close()
+	}
+	return
+}
+
+func (nm *MongoNotificationModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&nm.session, nm.URL, nm.Username, nm.Password)
+}
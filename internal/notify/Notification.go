@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Kind represents why a notification was sent.
+type Kind string
+
+const (
+	// Reminder notifications are sent to users who have not voted shortly before a poll closes.
+	Reminder Kind = "reminder"
+	// Result notifications are sent to every user once a poll has closed, stating the poll's winner.
+	Result Kind = "result"
+)
+
+// The channels notifications may be sent through, the address of an endpoint being an email address for Email, a push subscription endpoint for Push, a URL for Webhook and a
+// label for Log.
+const (
+	Email   = "email"
+	Push    = "push"
+	Webhook = "webhook"
+	Log     = "log"
+)
+
+// Notification represents a message sent to a user about a poll. Only a single notification of each kind is sent to each user for each poll.
+type Notification struct {
+	Workspace string    `json:"workspace" bson:"workspace"`
+	PollID    string    `json:"pollId" bson:"pollId"`
+	User      string    `json:"user" bson:"user"`
+	Kind      Kind      `json:"kind" bson:"kind"`
+	Subject   string    `json:"subject" bson:"subject"`
+	Text      string    `json:"text" bson:"text"`
+	SentAt    time.Time `json:"sentAt" bson:"sentAt"`
+}
+
+// Endpoint represents somewhere a user receives notifications, Channel being one of Email, Push, Webhook or Log.
+type Endpoint struct {
+	Channel string `json:"channel" bson:"channel"`
+	Address string `json:"address" bson:"address"`
+}
+
+// Contact records the endpoints a user within a workspace receives notifications through. Users only receive notifications once they have registered a contact.
+type Contact struct {
+	Workspace string      `json:"workspace" bson:"workspace"`
+	User      string      `json:"user" bson:"user"`
+	Endpoints []*Endpoint `json:"endpoints" bson:"endpoints"`
+}
+
+// Validate checks each of the contact's endpoints uses a known channel with an address suited to it: a valid email address for Email, and an absolute http or https URL for Push
+// and Webhook.
+func (c *Contact) Validate() error {
+	if len(c.Endpoints) == 0 {
+		return fmt.Errorf("at least one endpoint must be given")
+	}
+
+	for i, e := range c.Endpoints {
+		if e == nil {
+			return fmt.Errorf("endpoints[%d] must not be null", i)
+		}
+
+		switch e.Channel {
+		case Email:
+			if _, err := mail.ParseAddress(e.Address); err != nil {
+				return fmt.Errorf("endpoints[%d].address must be an email address, got %q", i, e.Address)
+			}
+		case Push, Webhook:
+			u, err := url.Parse(e.Address)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("endpoints[%d].address must be an absolute http or https URL, got %q", i, e.Address)
+			}
+		case Log:
+		default:
+			return fmt.Errorf("endpoints[%d].channel must be one of %s, %s, %s or %s, got %q", i, Email, Push, Webhook, Log, e.Channel)
+		}
+	}
+	return nil
+}
+
+// NewReminder returns a reminder for the given user to vote within the given poll, which closes shortly after the given time.
+func NewReminder(p *vote.Poll, user string, now time.Time) *Notification {
+	names := make([]string, 0, len(p.Options))
+	for _, opt := range p.Options {
+		if p.IsAvailable(opt.ID, now) {
+			names = append(names, opt.Name)
+		}
+	}
+
+	minutes := int(p.ClosesAt.Sub(now).Round(time.Minute).Minutes())
+	return &Notification{
+		Workspace: p.Workspace,
+		PollID:    p.ID,
+		User:      user,
+		Kind:      Reminder,
+		Subject:   fmt.Sprintf("Lunch poll %s closes in %d minutes", p.ID, minutes),
+		Text: fmt.Sprintf("You have not voted in lunch poll %s yet, which closes at %s. The options are: %s.",
+			p.ID, p.ClosesAt.UTC().Format("15:04 MST"), strings.Join(names, ", ")),
+		SentAt: now,
+	}
+}
+
+// NewResult returns a notification of the result of the given closed poll for the given user. The result follows the poll's visibility, as seen by a user who has not voted.
+func NewResult(p *vote.Poll, user string, now time.Time) *Notification {
+	n := &Notification{
+		Workspace: p.Workspace,
+		PollID:    p.ID,
+		User:      user,
+		Kind:      Result,
+		Subject:   fmt.Sprintf("Lunch poll %s has closed", p.ID),
+		SentAt:    now,
+	}
+
+	view := p.ViewFor("", now)
+	winner := view.Winner()
+	switch {
+	case p.Outcome == vote.Failed:
+		n.Text = fmt.Sprintf("Lunch poll %s closed without meeting its rules, so has no winner.", p.ID)
+	case winner == nil:
+		n.Text = fmt.Sprintf("Lunch poll %s closed without a winner.", p.ID)
+	default:
+		votes := 0
+		for _, t := range view.Results() {
+			if t.OptionID == winner.ID {
+				votes = t.Votes
+			}
+		}
+		n.Text = fmt.Sprintf("Lunch poll %s has closed and the winner is %s with %d votes.", p.ID, winner.Name, votes)
+	}
+	return n
+}
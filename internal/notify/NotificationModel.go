@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var instance *Container
+
+// NotificationModel defines a contract for how the system should interact with the database for accessing the contacts of users and the notifications sent to them. As with
+// polls, every contact and notification belongs to a workspace, the default workspace being represented by an empty string.
+type NotificationModel interface {
+	// GetContact returns the contact of the given user within the given workspace, returning an error along with a 'NotFound' status should the user not have registered one.
+	GetContact(workspace string, user string) (*Contact, vote.Status, error)
+	// GetContacts returns every contact within the given workspace.
+	GetContacts(workspace string) ([]*Contact, vote.Status, error)
+	// SetContact stores the given contact, replacing any contact of the same user within the same workspace.
+	SetContact(c *Contact) (vote.Status, error)
+	// DeleteContact removes the contact of the given user within the given workspace.
+	DeleteContact(workspace string, user string) (vote.Status, error)
+	// AddNotification records the given notification as sent, returning an error along with a 'Conflict' status should a notification of the same kind have already been sent to
+	// the same user for the same poll.
+	AddNotification(n *Notification) (vote.Status, error)
+	// GetNotifications returns the notifications sent to the given user within the given workspace, most recent first.
+	GetNotifications(workspace string, user string) ([]*Notification, vote.Status, error)
+	// Close allows for a NotificationModel connection to be closed.
+	Close() error
+}
+
+// Container provides access to injected implementation of NotificationModel for the application, along with the Notifier sending notifications.
+type Container struct {
+	Model    NotificationModel `inject:""`
+	Notifier *Notifier
+}
+
+// Init allows the notify package to be initialised with the Container c.
+func Init(c *Container) {
+	instance = c
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"log"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Notifier periodically reminds users who have not voted within a poll that the poll is about to close, and notifies every user of a poll's result once it has closed. Only users
+// who have registered a contact within a poll's workspace are notified, each through every endpoint of their contact whose channel is configured within Channels. Polls naming
+// their participants, through the rights given to them or the users their rules invite, are only notified to those participants and the users who voted within them.
+type Notifier struct {
+	Polls    vote.PollModel
+	Model    NotificationModel
+	Channels map[string]Channel
+	// RemindBefore states how long before a poll closes reminders are sent, defaulting to 15 minutes.
+	RemindBefore time.Duration
+	// Lookback states how long after a poll closes its result may still be sent, covering polls settled against their rules after closing, defaulting to an hour.
+	Lookback time.Duration
+	// Interval states how often the notifier checks for polls to notify users of, defaulting to once a minute.
+	Interval time.Duration
+}
+
+// Run starts the notifier's loop, sending notifications until the stop channel is closed. Note this method will block so should be ran as a separate goroutine.
+func (nt *Notifier) Run(stop <-chan struct{}) {
+	interval := nt.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			nt.Notify(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Notify sends reminders for every poll closing within RemindBefore of now to the users who have not voted within it, and the results of every poll which has closed within
+// Lookback of now. Polls with rules are only notified of once they have been settled against their rules.
+func (nt *Notifier) Notify(now time.Time) {
+	remindBefore := nt.RemindBefore
+	if remindBefore == 0 {
+		remindBefore = 15 * time.Minute
+	}
+	lookback := nt.Lookback
+	if lookback == 0 {
+		lookback = time.Hour
+	}
+
+	closing, _, err := nt.Polls.GetClosingPolls(now, now.Add(remindBefore))
+	if err != nil {
+		log.Printf("Notifier: could not retrieve closing polls due to: %s\n", err)
+	} else {
+		for _, p := range closing {
+			nt.notifyPoll(p, now, func(user string) *Notification {
+				if voted(p, user) {
+					return nil
+				}
+				return NewReminder(p, user, now)
+			})
+		}
+	}
+
+	closed, _, err := nt.Polls.GetClosingPolls(now.Add(-lookback), now)
+	if err != nil {
+		log.Printf("Notifier: could not retrieve closed polls due to: %s\n", err)
+		return
+	}
+	for _, p := range closed {
+		if p.Rules != nil && p.Outcome == "" {
+			continue
+		}
+		nt.notifyPoll(p, now, func(user string) *Notification {
+			return NewResult(p, user, now)
+		})
+	}
+}
+
+// notifyPoll sends the notification returned by the given function for each participant of the poll with a contact within the poll's workspace, skipping users the function
+// returns nil for. Each notification is recorded before being sent, ensuring it is only sent once however many times the poll is checked.
+func (nt *Notifier) notifyPoll(p *vote.Poll, now time.Time, notification func(user string) *Notification) {
+	contacts, _, err := nt.Model.GetContacts(p.Workspace)
+	if err != nil {
+		log.Printf("Notifier: could not retrieve the contacts of workspace %q due to: %s\n", p.Workspace, err)
+		return
+	}
+
+	named := participants(p)
+	for _, c := range contacts {
+		if named != nil && !named[c.User] {
+			continue
+		}
+
+		n := notification(c.User)
+		if n == nil {
+			continue
+		}
+
+		if status, err := nt.Model.AddNotification(n); err != nil {
+			if status != vote.Conflict {
+				log.Printf("Notifier: could not record %s for %s due to: %s\n", n.Kind, c.User, err)
+			}
+			continue
+		}
+		nt.Send(c, n)
+	}
+}
+
+// Send sends the given notification through every endpoint of the given contact whose channel is configured, logging any endpoint the notification could not be sent to.
+func (nt *Notifier) Send(c *Contact, n *Notification) {
+	for _, e := range c.Endpoints {
+		ch, ok := nt.Channels[e.Channel]
+		if !ok {
+			log.Printf("Notifier: the %s channel of %s is not configured\n", e.Channel, c.User)
+			continue
+		}
+
+		if err := ch.Send(e.Address, n); err != nil {
+			log.Printf("Notifier: could not send %s for poll %s to %s through %s due to: %s\n", n.Kind, n.PollID, c.User, e.Channel, err)
+		}
+	}
+}
+
+// participants returns the users taking part in the given poll, being the users given rights within the poll, the users its rules invite and the users who have voted. Polls
+// naming neither rights nor invited users are open to every user of their workspace, so nil is returned for them.
+func participants(p *vote.Poll) map[string]bool {
+	if len(p.Rights) == 0 && (p.Rules == nil || len(p.Rules.Invited) == 0) {
+		return nil
+	}
+
+	users := make(map[string]bool)
+	for u := range p.Rights {
+		users[u] = true
+	}
+	if p.Rules != nil {
+		for _, u := range p.Rules.Invited {
+			users[u] = true
+		}
+	}
+	for _, voters := range p.Votes {
+		for _, u := range voters {
+			users[u] = true
+		}
+	}
+	return users
+}
+
+// voted returns whether the given user has a vote within the given poll.
+func voted(p *vote.Poll, user string) bool {
+	for _, users := range p.Votes {
+		for _, u := range users {
+			if u == user {
+				return true
+			}
+		}
+	}
+	return false
+}
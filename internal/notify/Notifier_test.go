@@ -0,0 +1,173 @@
+package notify
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// recorder records the notifications sent through it, keyed by address.
+type recorder struct {
+	mutex sync.Mutex
+	sent  map[string][]*Notification
+}
+
+func (rc *recorder) Send(address string, n *Notification) error {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	if rc.sent == nil {
+		rc.sent = make(map[string][]*Notification)
+	}
+	rc.sent[address] = append(rc.sent[address], n)
+	return nil
+}
+
+func newNotifier(t *testing.T) (*Notifier, *vote.Poll, *recorder) {
+	polls := &vote.MockPollModel{}
+	poll, _, err := polls.NewPoll("", []*restaurant.Building{{ID: "r1", Name: "Restaurant 1"}, {ID: "r2", Name: "Restaurant 2"}})
+	if err != nil {
+		t.Fatalf("Could not create poll: %s", err)
+	}
+	poll.Votes = map[string][]string{"r1": {"Jack"}}
+	polls.UpdatePoll(poll)
+
+	model := &MockNotificationModel{}
+	model.SetContact(&Contact{User: "Jack", Endpoints: []*Endpoint{{Channel: Log, Address: "jack"}}})
+	model.SetContact(&Contact{User: "Tom", Endpoints: []*Endpoint{{Channel: Log, Address: "tom"}, {Channel: Email, Address: "tom@example.com"}}})
+	model.SetContact(&Contact{Workspace: "other", User: "Sam", Endpoints: []*Endpoint{{Channel: Log, Address: "sam"}}})
+
+	rc := &recorder{}
+	return &Notifier{Polls: polls, Model: model, Channels: map[string]Channel{Log: rc}, RemindBefore: 15 * time.Minute}, poll, rc
+}
+
+func TestNotifyReminders(t *testing.T) {
+	nt, poll, rc := newNotifier(t)
+	now := time.Now()
+
+	poll.ClosesAt = now.Add(time.Hour)
+	nt.Polls.UpdatePoll(poll)
+	nt.Notify(now)
+	if len(rc.sent) != 0 {
+		t.Fatalf("Expected no reminders to be sent before the poll is within RemindBefore of closing, got %v", rc.sent)
+	}
+
+	poll.ClosesAt = now.Add(10 * time.Minute)
+	nt.Polls.UpdatePoll(poll)
+	nt.Notify(now)
+	nt.Notify(now.Add(time.Minute))
+
+	if len(rc.sent["jack"]) != 0 || len(rc.sent["sam"]) != 0 {
+		t.Logf("Expected only users of the poll's workspace who have not voted to be reminded, got %v", rc.sent)
+		t.Fail()
+	}
+	if len(rc.sent["tom"]) != 1 || rc.sent["tom"][0].Kind != Reminder || rc.sent["tom"][0].PollID != poll.ID {
+		t.Fatalf("Expected Tom to be reminded once, got %v", rc.sent["tom"])
+	}
+
+	sent, _, _ := nt.Model.GetNotifications("", "Tom")
+	if len(sent) != 1 {
+		t.Logf("Expected the reminder to be recorded, got %v notifications", len(sent))
+		t.Fail()
+	}
+}
+
+func TestNotifyResults(t *testing.T) {
+	nt, poll, rc := newNotifier(t)
+	now := time.Now()
+	poll.ClosesAt = now.Add(-time.Minute)
+	nt.Polls.UpdatePoll(poll)
+
+	nt.Notify(now)
+	nt.Notify(now.Add(time.Minute))
+
+	for _, address := range []string{"jack", "tom"} {
+		if len(rc.sent[address]) != 1 || rc.sent[address][0].Kind != Result {
+			t.Logf("Expected %s to be sent the result once, got %v", address, rc.sent[address])
+			t.Fail()
+		}
+	}
+	if n := rc.sent["tom"]; len(n) == 1 && n[0].Text != "Lunch poll new poll has closed and the winner is Restaurant 1 with 1 votes." {
+		t.Logf("Expected the result to name the winner, got %q", n[0].Text)
+		t.Fail()
+	}
+
+	poll.ClosesAt = now.Add(-2 * time.Hour)
+	poll.ID = "old poll"
+	nt.Polls.UpdatePoll(poll)
+	nt.Notify(now)
+	if len(rc.sent["tom"]) != 1 {
+		t.Logf("Expected polls closed before Lookback not to be notified, got %v", rc.sent["tom"])
+		t.Fail()
+	}
+}
+
+func TestNotifyParticipants(t *testing.T) {
+	nt, poll, rc := newNotifier(t)
+	nt.Model.SetContact(&Contact{User: "Will", Endpoints: []*Endpoint{{Channel: Log, Address: "will"}}})
+	now := time.Now()
+	poll.ClosesAt = now.Add(10 * time.Minute)
+	poll.Rights = map[string]vote.Rights{"Tom": {Weight: 2}}
+	nt.Polls.UpdatePoll(poll)
+
+	nt.Notify(now)
+	if len(rc.sent["tom"]) != 1 || len(rc.sent["will"]) != 0 {
+		t.Logf("Expected only the poll's participants to be reminded, got %v", rc.sent)
+		t.Fail()
+	}
+
+	poll.ClosesAt = now.Add(-time.Minute)
+	nt.Polls.UpdatePoll(poll)
+	nt.Notify(now)
+	if len(rc.sent["jack"]) != 1 || len(rc.sent["tom"]) != 2 || len(rc.sent["will"]) != 0 {
+		t.Logf("Expected the result to be sent to the poll's participants and voters only, got %v", rc.sent)
+		t.Fail()
+	}
+}
+
+func TestNotifyUnsettled(t *testing.T) {
+	nt, poll, rc := newNotifier(t)
+	now := time.Now()
+	poll.ClosesAt = now.Add(-time.Minute)
+	poll.Rules = &vote.Rules{}
+	nt.Polls.UpdatePoll(poll)
+
+	nt.Notify(now)
+	if len(rc.sent) != 0 {
+		t.Fatalf("Expected no results to be sent before the poll is settled against its rules, got %v", rc.sent)
+	}
+
+	poll.Outcome = vote.Failed
+	nt.Polls.UpdatePoll(poll)
+	nt.Notify(now)
+	if n := rc.sent["tom"]; len(n) != 1 || n[0].Text != "Lunch poll new poll closed without meeting its rules, so has no winner." {
+		t.Logf("Expected the failed poll to be notified without a winner, got %v", n)
+		t.Fail()
+	}
+}
+
+func TestValidateContact(t *testing.T) {
+	cases := []struct {
+		endpoints []*Endpoint
+		valid     bool
+	}{
+		{[]*Endpoint{{Channel: Email, Address: "jack@example.com"}, {Channel: Log, Address: "jack"}}, true},
+		{[]*Endpoint{{Channel: Push, Address: "https://push.example.com/abc"}, {Channel: Webhook, Address: "http://bots.example.com"}}, true},
+		{nil, false},
+		{[]*Endpoint{nil}, false},
+		{[]*Endpoint{{Channel: Email, Address: "jack"}}, false},
+		{[]*Endpoint{{Channel: Push, Address: "push.example.com"}}, false},
+		{[]*Endpoint{{Channel: "sms", Address: "07000000000"}}, false},
+	}
+
+	for i, c := range cases {
+		err := (&Contact{Endpoints: c.endpoints}).Validate()
+		if (err == nil) != c.valid {
+			t.Logf("Expected case %v to be valid: %v, got error %v", i, c.valid, err)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetContact provides a http handler for accessing the caller's contact within their workspace.
+func GetContact(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	c, status, err := instance.Model.GetContact(caller.Workspace(r.Context()), user)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("%s has not registered a contact, returning not found status.\n", user)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find contact due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// SetContact provides a http handler for registering the endpoints the caller receives notifications through within their workspace, replacing any endpoints registered
+// before. Each endpoint's channel must be configured on the server.
+func SetContact(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	// if request body could not be parsed, return an internal server error to the client.
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	c := &Contact{}
+	if err = json.Unmarshal(b, c); err != nil {
+		log.Printf("Could not parse %s into a contact", b)
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	if err = c.Validate(); err != nil {
+		log.Printf("Supplied contact invalid: %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, e := range c.Endpoints {
+		if _, ok := instance.Notifier.Channels[e.Channel]; !ok {
+			log.Printf("The %s channel is not configured\n", e.Channel)
+			http.Error(w, "The "+e.Channel+" channel is not configured on this server", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// contacts can only be registered for the caller within the caller's workspace.
+	c.Workspace = caller.Workspace(r.Context())
+	c.User = user
+	if _, err = instance.Model.SetContact(c); err != nil {
+		log.Printf("Could not register contact of %s due to: %s\n", user, err.Error())
+		http.Error(w, "Contact could not be registered", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Registered %v notification endpoints for %s\n", len(c.Endpoints), user)
+	writeJSON(w, http.StatusOK, c)
+}
+
+// DeleteContact provides a http handler for removing the caller's contact within their workspace, stopping them from receiving notifications.
+func DeleteContact(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := instance.Model.DeleteContact(caller.Workspace(r.Context()), user)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("%s has not registered a contact\n", user)
+			http.Error(w, "No contact registered", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+// GetNotifications provides a http handler for listing the notifications sent to the caller within their workspace, most recent first. Web push messages are sent without a
+// payload, so the caller's service worker uses this handler to fetch the notification it was woken for.
+func GetNotifications(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	notifications, _, err := instance.Model.GetNotifications(caller.Workspace(r.Context()), user)
+	if err != nil {
+		log.Printf("Could not retrieve notifications of %s due to: %s\n", user, err.Error())
+		http.Error(w, "Could not retrieve notifications", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notifications)
+}
+
+// requireUser returns the user making the given request, writing a bad request status and returning false should the request not specify one.
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	user := caller.User(r.Context())
+	if user == "" {
+		log.Println("No user specified. Returning bad request status.")
+		http.Error(w, "A user must be specified", http.StatusBadRequest)
+		return "", false
+	}
+	return user, true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(data)
+}
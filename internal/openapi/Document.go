@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Document represents an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       Info                   `json:"info"`
+	Paths      map[string]PathItem    `json:"paths"`
+	Components map[string]interface{} `json:"components"`
+
+	schemas *Schemas
+}
+
+// Info provides the metadata of a Document.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps the lower case http methods supported by a path to their operations.
+type PathItem map[string]*Operation
+
+// Operation describes a single method of a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *Body                `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a query, path or header parameter of an Operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Body describes the JSON body of a request.
+type Body struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an Operation, with a nil content stating the response has no body.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// NewDocument creates an empty document with the given title and version.
+func NewDocument(title string, version string) *Document {
+	schemas := NewSchemas()
+	return &Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: title, Version: version},
+		Paths:      make(map[string]PathItem),
+		Components: map[string]interface{}{"schemas": schemas.Components},
+		schemas:    schemas,
+	}
+}
+
+// Add adds an operation for the given method and path to the document, returning the operation to allow it to be described further.
+func (d *Document) Add(method string, path string, id string, summary string) *Operation {
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(PathItem)
+	}
+
+	op := &Operation{OperationID: id, Summary: summary, Responses: make(map[string]*Response)}
+	d.Paths[path][strings.ToLower(method)] = op
+
+	// path parameters are implied by the path template.
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			op.Parameters = append(op.Parameters, &Parameter{Name: strings.Trim(part, "{}"), In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	return op
+}
+
+// Operation returns the operation for the given method and path, or nil should the document not describe it.
+func (d *Document) Operation(method string, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+// Schemas returns the schemas the document's components were generated by, allowing responses to be validated against the document.
+func (d *Document) Schemas() *Schemas {
+	return d.schemas
+}
+
+// Query adds a query parameter to the operation.
+func (op *Operation) Query(name string, description string, required bool) *Operation {
+	op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "query", Description: description, Required: required, Schema: &Schema{Type: "string"}})
+	return op
+}
+
+// Header adds a header parameter to the operation.
+func (op *Operation) Header(name string, description string) *Operation {
+	op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "header", Description: description, Schema: &Schema{Type: "string"}})
+	return op
+}
+
+// Body states the operation accepts a JSON body matching the given schema.
+func (op *Operation) Body(schema *Schema, required bool) *Operation {
+	op.RequestBody = &Body{Required: required, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+	return op
+}
+
+// Form states the operation accepts a url encoded form body matching the given schema.
+func (op *Operation) Form(schema *Schema, required bool) *Operation {
+	op.RequestBody = &Body{Required: required, Content: map[string]MediaType{"application/x-www-form-urlencoded": {Schema: schema}}}
+	return op
+}
+
+// Upload adds a required request body to the operation accepting a file of any of the given media types, or a multipart form holding the file within its 'file' field.
+func (op *Operation) Upload(mediaTypes ...string) *Operation {
+	file := &Schema{Type: "string", Format: "binary"}
+	content := map[string]MediaType{
+		"multipart/form-data": {Schema: &Schema{Type: "object", Properties: map[string]*Schema{"file": file}, Required: []string{"file"}}},
+	}
+	for _, t := range mediaTypes {
+		content[t] = MediaType{Schema: file}
+	}
+	op.RequestBody = &Body{Required: true, Content: content}
+	return op
+}
+
+// Returns adds a response with the given status code to the operation, a nil schema stating the response has no body.
+func (op *Operation) Returns(code int, schema *Schema) *Operation {
+	r := &Response{Description: http.StatusText(code)}
+	if schema != nil {
+		r.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	op.Responses[strconv.Itoa(code)] = r
+	return op
+}
+
+// ReturnsFile adds a response with the given status code to the operation whose body is a file of any of the given media types, in addition to any JSON body already added.
+func (op *Operation) ReturnsFile(code int, mediaTypes ...string) *Operation {
+	r := op.Responses[strconv.Itoa(code)]
+	if r == nil {
+		r = &Response{Description: http.StatusText(code)}
+		op.Responses[strconv.Itoa(code)] = r
+	}
+	if r.Content == nil {
+		r.Content = make(map[string]MediaType)
+	}
+	for _, t := range mediaTypes {
+		r.Content[t] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	return op
+}
+
+// Fails adds a response with each of the given status codes to the operation, each response having a body matching the given schema.
+func (op *Operation) Fails(schema *Schema, codes ...int) *Operation {
+	for _, code := range codes {
+		op.Returns(code, schema)
+	}
+	return op
+}
@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schema represents an OpenAPI 3 schema object, covering the subset of the specification needed to describe the JSON produced by the server's Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// Schemas generates schemas from Go types, recording the schema of each named struct type it encounters as a component referenced by the generated schemas.
+type Schemas struct {
+	Components map[string]*Schema
+}
+
+// NewSchemas creates an empty set of schemas.
+func NewSchemas() *Schemas {
+	return &Schemas{Components: make(map[string]*Schema)}
+}
+
+// For returns the schema describing the JSON encoding of the given value's type, with named struct types being referenced as components.
+func (s *Schemas) For(v interface{}) *Schema {
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *Schemas) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		// raw JSON is written as is, so may hold any value.
+		return &Schema{}
+	case t.Kind() == reflect.Ptr:
+		elem := s.schema(t.Elem())
+		if elem.Ref != "" {
+			// siblings of a reference are ignored, so the reference is wrapped to allow it to be nullable.
+			return &Schema{AllOf: []*Schema{elem}, Nullable: true}
+		}
+		elem.Nullable = true
+		return elem
+	case t.Kind() == reflect.Struct:
+		return s.component(t)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &Schema{Type: "array", Items: s.schema(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem()), Nullable: true}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+
+	// interfaces may hold any value.
+	return &Schema{}
+}
+
+// component records the schema of the given struct type as a component, returning a reference to said component.
+func (s *Schemas) component(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+	if _, ok := s.Components[t.Name()]; ok {
+		return ref
+	}
+
+	c := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	// the component is recorded before its fields are generated, allowing recursive types to reference themselves.
+	s.Components[t.Name()] = c
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, omitempty := f.Name, false
+		if tag := f.Tag.Get("json"); tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, p := range parts[1:] {
+				omitempty = omitempty || p == "omitempty"
+			}
+		}
+
+		c.Properties[name] = s.schema(f.Type)
+		// fields without omitempty are always present within the encoded JSON.
+		if !omitempty {
+			c.Required = append(c.Required, name)
+		}
+	}
+
+	sort.Strings(c.Required)
+	return ref
+}
+
+// Validate checks that the given value, as decoded by encoding/json into an interface{}, matches the given schema, resolving references using the recorded components.
+func (s *Schemas) Validate(schema *Schema, v interface{}) error {
+	return s.validate(schema, v, "$")
+}
+
+func (s *Schemas) validate(schema *Schema, v interface{}, path string) error {
+	if schema.Ref != "" {
+		c, ok := s.Components[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			return fmt.Errorf("%s: unknown reference %s", path, schema.Ref)
+		}
+		return s.validate(c, v, path)
+	}
+
+	if v == nil {
+		if schema.Nullable || schema.Type == "" && len(schema.AllOf) == 0 {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+
+	for _, sub := range schema.AllOf {
+		if err := s.validate(sub, v, path); err != nil {
+			return err
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %T", path, v)
+		}
+
+		for _, name := range schema.Required {
+			if _, ok := o[name]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, name)
+			}
+		}
+
+		for name, value := range o {
+			p, ok := schema.Properties[name]
+			if !ok {
+				p = schema.AdditionalProperties
+			}
+			// objects without any properties described may hold any properties.
+			if p == nil && schema.Properties == nil {
+				continue
+			}
+			if p == nil {
+				return fmt.Errorf("%s: unexpected property %s", path, name)
+			}
+			if err := s.validate(p, value, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %T", path, v)
+		}
+
+		for i, item := range a {
+			if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string, got %T", path, v)
+		}
+
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return fmt.Errorf("%s: %s is not a date-time", path, str)
+			}
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected an integer, got %v", path, v)
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: expected a number, got %T", path, v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean, got %T", path, v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type child struct {
+	Name string `json:"name"`
+}
+
+type parent struct {
+	ID       string            `json:"id"`
+	Created  time.Time         `json:"created"`
+	Count    int               `json:"count,omitempty"`
+	Child    *child            `json:"child"`
+	Children []*child          `json:"children"`
+	Tags     map[string]string `json:"tags"`
+	Ignored  string            `json:"-"`
+}
+
+func validate(s *Schemas, schema *Schema, data string) error {
+	var v interface{}
+	json.Unmarshal([]byte(data), &v)
+	return s.Validate(schema, v)
+}
+
+func TestSchemaMatchesEncoding(t *testing.T) {
+	s := NewSchemas()
+	schema := s.For(parent{})
+
+	data, _ := json.Marshal(parent{ID: "p", Children: []*child{{Name: "c"}}})
+	if err := validate(s, schema, string(data)); err != nil {
+		t.Logf("Expected the encoding of parent to match its schema, got %s", err.Error())
+		t.Fail()
+	}
+
+	if len(s.Components["parent"].Required) != 5 {
+		t.Logf("Expected every field without omitempty to be required, got %v", s.Components["parent"].Required)
+		t.Fail()
+	}
+}
+
+func TestSchemaDetectsDrift(t *testing.T) {
+	s := NewSchemas()
+	schema := s.For(parent{})
+
+	for _, data := range []string{
+		`{"id": "p", "created": "2020-01-01T00:00:00Z", "child": null, "children": null}`,
+		`{"id": 1, "created": "2020-01-01T00:00:00Z", "child": null, "children": null, "tags": null}`,
+		`{"id": "p", "created": "yesterday", "child": null, "children": null, "tags": null}`,
+		`{"id": "p", "created": "2020-01-01T00:00:00Z", "child": {"name": "c", "age": 3}, "children": null, "tags": null}`,
+		`{"id": "p", "created": "2020-01-01T00:00:00Z", "child": null, "children": [{}], "tags": null}`,
+	} {
+		if err := validate(s, schema, data); err == nil {
+			t.Logf("Expected %s not to match the schema of parent", data)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/chatops"
+	"takeaway/takeaway-server/internal/notify"
+	"takeaway/takeaway-server/internal/recommend"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/schedule"
+	"takeaway/takeaway-server/internal/stats"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/webhook"
+	"takeaway/takeaway-server/internal/workspace"
+)
+
+var (
+	spec     *Document
+	specOnce sync.Once
+)
+
+// Spec returns the OpenAPI document describing every route served by the server. The schemas of request and response bodies are generated from the Go types the handlers
+// read and write, ensuring the document cannot drift from the types themselves.
+func Spec() *Document {
+	specOnce.Do(func() {
+		spec = build()
+	})
+	return spec
+}
+
+func build() *Document {
+	d := NewDocument("Takeaway Server", "2.0.0")
+	s := d.Schemas()
+
+	poll := s.For(vote.Poll{})
+	polls := s.For(vote.PollPage{})
+	building := s.For(restaurant.Building{})
+	buildings := s.For([]*restaurant.Building{})
+	template := s.For(schedule.Template{})
+	ws := s.For(workspace.Workspace{})
+	envelope := s.For(vote.ErrorEnvelope{})
+	id := "the ID of the resource"
+
+	pollQuery := func(op *Operation) *Operation {
+		return op.
+			Query("creator", "only list polls created by the given user", false).
+			Query("participant", "only list polls the given user has voted within", false).
+			Query("state", "only list polls that are open or closed", false).
+			Query("restaurant", "only list polls with the given restaurant as an option", false).
+			Query("createdAfter", "only list polls created at or after the given RFC 3339 time", false).
+			Query("createdBefore", "only list polls created before the given RFC 3339 time", false).
+			Query("sort", "createdAt or closesAt, prefixed by - for descending order", false).
+			Query("cursor", "the cursor returned with the previous page", false).
+			Query("limit", "the maximum number of polls to return", false)
+	}
+	ruleQuery := func(op *Operation) *Operation {
+		return op.
+			Query("quorum", "the minimum number of users who must vote for the poll to be decided", false).
+			Query("quorumPercent", "the minimum percentage of the invited users who must vote for the poll to be decided", false).
+			Query("invited", "comma separated users invited to vote, no other users being allowed to vote", false).
+			Query("margin", "the number of weighted votes the winner must lead every other option by", false).
+			Query("onFailure", "fail, or extend to reopen the poll should it close without meeting its rules", false).
+			Query("extendBy", "the number of minutes the poll is extended by", false).
+			Query("maxExtensions", "the number of times the poll may be extended", false)
+	}
+	rules := s.For(vote.Rules{})
+	review := s.For(vote.Review{})
+	reviews := s.For([]*vote.Review{})
+
+	// v1 API, reporting errors using plain text bodies.
+	d.Add(http.MethodGet, "/poll", "getPoll", "Get a poll").Query("id", id, true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	ruleQuery(d.Add(http.MethodPut, "/poll", "newPoll", "Create a poll")).
+		Query("suggest", "the number of suggested restaurants to add as options", false).
+		Query("users", "comma separated users suggestions are made for", false).
+		Query("allowSuggestions", "set to true to allow voters to suggest options for the creator to approve", false).
+		Query("visibility", "public, anonymous to only show the number of votes for each option, or secret to also hide them until the poll closes", false).
+		Query("office", "the location the order is delivered to, given as lat,lng or as an address", false).
+		Body(buildings, false).
+		Returns(http.StatusCreated, poll).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/poll", "updatePoll", "Update a poll").Body(poll, true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/poll", "deletePoll", "Delete a poll").Query("id", id, true).
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	pollQuery(d.Add(http.MethodGet, "/polls", "listPolls", "List polls")).
+		Returns(http.StatusOK, polls).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/poll/history", "getHistory", "Get the event log of a poll").Query("id", id, true).
+		Returns(http.StatusOK, s.For([]*vote.Event{})).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/poll/export", "exportPoll", "Export the results of a poll for spreadsheets or calendars").Query("id", id, true).
+		Query("format", "csv, json, or ics for a calendar event at the winning restaurant", true).
+		Returns(http.StatusOK, s.For(vote.PollExport{})).ReturnsFile(http.StatusOK, "text/csv", "text/calendar").
+		Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/poll/rollback", "rollbackPoll", "Restore a poll to an earlier point in its history").Query("id", id, true).
+		Query("to", "the sequence number of the last event to keep", false).
+		Query("at", "the RFC 3339 time to restore the poll to", false).
+		Query("undo", "the number of most recent events to undo", false).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/poll/options", "addOption", "Add an option to a poll, or suggest one for the poll's creator to approve").Query("id", id, true).Body(building, true).
+		Returns(http.StatusCreated, poll).Returns(http.StatusAccepted, poll).
+		Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/poll/options", "removeOption", "Remove an option from a poll, or reject a suggested option").Query("id", id, true).
+		Query("option", "the ID of the option to remove", true).
+		Query("moveVotesTo", "the ID of the option to move the removed option's votes to", false).
+		Query("discardVotes", "set to true to discard the removed option's votes", false).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/poll/options/approve", "approveOption", "Approve a suggested option").Query("id", id, true).
+		Query("option", "the ID of the suggested option", true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/poll/rights", "setRights", "Set the weight and vetoes of a poll's participant").Query("id", id, true).
+		Query("user", "the participant whose rights are set", true).Body(s.For(vote.Rights{}), true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/poll/rules", "setRules", "Set the quorum and margin a poll must meet to be decided").Query("id", id, true).Body(rules, true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/poll/reviews", "getReviews", "List the reviews of a poll's winner").Query("id", id, true).
+		Returns(http.StatusOK, reviews).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPost, "/poll/reviews", "addReview", "Review the winner of a closed poll").Query("id", id, true).Body(review, true).
+		Returns(http.StatusCreated, review).
+		Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodPost, "/poll/veto", "addVeto", "Veto an option, preventing it from winning").Query("id", id, true).
+		Query("option", "the ID of the option to veto", true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/poll/veto", "removeVeto", "Withdraw a veto").Query("id", id, true).
+		Query("option", "the ID of the vetoed option", true).
+		Returns(http.StatusOK, poll).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/vote", "addVote", "Cast a vote").Query("id", id, true).Body(s.For(vote.Vote{}), true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/vote", "removeUser", "Remove a user's votes").Query("id", id, true).Query("user", "the user to remove", true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+
+	d.Add(http.MethodGet, "/template", "getTemplate", "Get a poll template").Query("id", id, true).
+		Returns(http.StatusOK, template).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/template", "newTemplate", "Create a poll template").Body(template, true).
+		Returns(http.StatusCreated, template).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/template", "updateTemplate", "Update a poll template").Body(template, true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/template", "deleteTemplate", "Delete a poll template").Query("id", id, true).
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/templates", "getTemplates", "List poll templates").
+		Returns(http.StatusOK, s.For([]*schedule.Template{})).Fails(nil, http.StatusInternalServerError)
+
+	contact := s.For(notify.Contact{})
+	d.Add(http.MethodGet, "/notifications/contact", "getContact", "Get the endpoints the caller receives notifications through").
+		Returns(http.StatusOK, contact).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/notifications/contact", "setContact", "Register the endpoints the caller receives poll reminders and results through").Body(contact, true).
+		Returns(http.StatusOK, contact).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/notifications/contact", "deleteContact", "Stop the caller receiving notifications").
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/notifications", "getNotifications", "List the notifications sent to the caller, most recent first").
+		Returns(http.StatusOK, s.For([]*notify.Notification{})).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+
+	subscription := s.For(webhook.Subscription{})
+	delivery := s.For(webhook.Delivery{})
+	d.Add(http.MethodGet, "/webhook", "getWebhook", "Get a webhook subscription, without its secret").Query("id", id, true).
+		Returns(http.StatusOK, subscription).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/webhook", "newWebhook", "Subscribe a URL to poll events, returning the secret deliveries are signed with").Body(subscription, true).
+		Returns(http.StatusCreated, subscription).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/webhook", "deleteWebhook", "Delete a webhook subscription and its deliveries").Query("id", id, true).
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/webhooks", "getWebhooks", "List webhook subscriptions, without their secrets").
+		Returns(http.StatusOK, s.For([]*webhook.Subscription{})).Fails(nil, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/webhook/deliveries", "getDeliveries", "List the deliveries to a webhook subscription, most recent first").Query("id", id, true).
+		Query("state", "pending, delivered or failed, failed listing the dead letters", false).
+		Returns(http.StatusOK, s.For([]*webhook.Delivery{})).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/webhook/deliveries/retry", "retryDelivery", "Retry a failed delivery").Query("id", "the ID of the delivery", true).
+		Returns(http.StatusAccepted, delivery).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+
+	d.Add(http.MethodGet, "/workspace", "getWorkspace", "Get a workspace").Query("id", id, true).
+		Returns(http.StatusOK, ws).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/workspace", "newWorkspace", "Create a workspace").Body(ws, true).
+		Returns(http.StatusCreated, ws).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/workspace", "deleteWorkspace", "Delete a workspace holding no data").Query("id", id, true).
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/workspaces", "getWorkspaces", "List the caller's workspaces").
+		Returns(http.StatusOK, s.For([]*workspace.Workspace{})).Fails(nil, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/workspace/members", "addMember", "Add a member to a workspace").Query("id", id, true).Query("user", "the user to add", true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/workspace/members", "removeMember", "Remove a member from a workspace").Query("id", id, true).Query("user", "the user to remove", true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	d.Add(http.MethodGet, "/restaurant", "getRestaurant", "Get a restaurant").Query("id", id, true).
+		Returns(http.StatusOK, building).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodPut, "/restaurant", "newRestaurant", "Add a restaurant to the catalogue").Body(building, true).
+		Returns(http.StatusCreated, building).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/restaurant", "updateRestaurant", "Update a restaurant").Body(building, true).
+		Returns(http.StatusAccepted, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodDelete, "/restaurant", "deleteRestaurant", "Remove a restaurant from the catalogue").Query("id", id, true).
+		Returns(http.StatusOK, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/restaurants", "getRestaurants", "List the restaurants within the catalogue").
+		Returns(http.StatusOK, buildings).Fails(nil, http.StatusInternalServerError)
+	d.Add(http.MethodPost, "/restaurants/import", "importRestaurants", "Import restaurants into the catalogue from a CSV, JSON, GeoJSON or vCard file").
+		Query("format", "csv, json, geojson or vcard, detected from the uploaded file should it be omitted", false).
+		Query("dryRun", "set to true to report what would be imported without changing the catalogue", false).
+		Upload("text/csv", "application/json", "application/geo+json", "text/vcard").
+		Returns(http.StatusOK, s.For(catalogue.ImportReport{})).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/restaurants/suggest", "getSuggestions", "Suggest restaurants").
+		Query("users", "comma separated users suggestions are made for", false).
+		Query("n", "the number of suggestions to return", false).
+		Returns(http.StatusOK, s.For([]*recommend.Suggestion{})).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+	d.Add(http.MethodGet, "/stats", "getReport", "Get poll statistics").
+		Query("since", "the RFC 3339 time statistics are gathered from", false).
+		Query("users", "comma separated users to include within the participation statistics", false).
+		Returns(http.StatusOK, s.For(stats.Report{})).Fails(nil, http.StatusBadRequest, http.StatusInternalServerError)
+
+	command := &Schema{Type: "object", Properties: map[string]*Schema{
+		"text":         {Type: "string"},
+		"channel_id":   {Type: "string"},
+		"user_id":      {Type: "string"},
+		"user_name":    {Type: "string"},
+		"response_url": {Type: "string"},
+		"token":        {Type: "string"},
+	}, Required: []string{"channel_id", "user_id"}}
+	action := &Schema{Type: "object", Properties: map[string]*Schema{"payload": {Type: "string"}}, Required: []string{"payload"}}
+	signature := "the signature of the request, required should the server be configured with a signing secret"
+	timestamp := "the unix time the request was signed at"
+	d.Add(http.MethodPost, "/chat/commands", "runChatCommand", "Run a /lunch slash command sent by a chat platform").
+		Header(chatops.SignatureHeader, signature).Header(chatops.TimestampHeader, timestamp).Form(command, true).
+		Returns(http.StatusOK, s.For(chatops.Message{})).Fails(nil, http.StatusBadRequest, http.StatusUnauthorized)
+	d.Add(http.MethodPost, "/chat/actions", "runChatAction", "Vote using a button of a poll message sent to a chat platform").
+		Header(chatops.SignatureHeader, signature).Header(chatops.TimestampHeader, timestamp).Form(action, true).
+		Returns(http.StatusOK, s.For(chatops.Message{})).Fails(nil, http.StatusBadRequest, http.StatusUnauthorized)
+
+	d.Add(http.MethodGet, "/ws", "watchPoll", "Watch a poll for changes over a websocket").Query("id", id, true).
+		Returns(http.StatusSwitchingProtocols, nil).Fails(nil, http.StatusBadRequest, http.StatusNotFound)
+	graphRequest := &Schema{Type: "object", Properties: map[string]*Schema{
+		"query":         {Type: "string"},
+		"variables":     {Type: "object", AdditionalProperties: &Schema{}, Nullable: true},
+		"operationName": {Type: "string"},
+	}, Required: []string{"query"}}
+	graphResult := &Schema{Type: "object", Properties: map[string]*Schema{
+		"data":   {Nullable: true},
+		"errors": {Type: "array", Items: &Schema{Type: "object"}},
+	}}
+	d.Add(http.MethodGet, "/graphql", "queryGraphQL", "Run a GraphQL query, or open a graphql-transport-ws websocket for subscriptions").
+		Query("query", "the GraphQL query", true).
+		Query("variables", "the JSON encoded variables of the query", false).
+		Query("operationName", "the operation to run", false).
+		Returns(http.StatusOK, graphResult).Returns(http.StatusSwitchingProtocols, nil).Fails(nil, http.StatusBadRequest, http.StatusMethodNotAllowed)
+	d.Add(http.MethodPost, "/graphql", "postGraphQL", "Run a GraphQL query or mutation").Body(graphRequest, true).
+		Returns(http.StatusOK, graphResult).Fails(nil, http.StatusBadRequest)
+	d.Add(http.MethodGet, "/openapi.json", "getSpec", "Get this document").
+		Returns(http.StatusOK, &Schema{Type: "object"})
+
+	// v2 API, reporting every error using an error envelope.
+	pollQuery(d.Add(http.MethodGet, "/v2/polls", "listPollsV2", "List polls")).
+		Returns(http.StatusOK, polls).Fails(envelope, http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	ruleQuery(d.Add(http.MethodPost, "/v2/polls", "createPollV2", "Create a poll")).Body(buildings, true).
+		Query("allowSuggestions", "set to true to allow voters to suggest options for the creator to approve", false).
+		Query("visibility", "public, anonymous to only show the number of votes for each option, or secret to also hide them until the poll closes", false).
+		Query("office", "the location the order is delivered to, given as lat,lng or as an address", false).
+		Returns(http.StatusCreated, poll).Fails(envelope, http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	d.Add(http.MethodGet, "/v2/polls/{id}", "getPollV2", "Get a poll").
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}", "updatePollV2", "Replace a poll").Body(poll, true).
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodDelete, "/v2/polls/{id}", "deletePollV2", "Delete a poll").
+		Returns(http.StatusNoContent, nil).Fails(envelope, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodGet, "/v2/polls/{id}/votes/{user}", "getVoteV2", "Get a user's vote").
+		Returns(http.StatusOK, s.For(vote.Ballot{})).Fails(envelope, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}/votes/{user}", "castVoteV2", "Cast a user's vote").Body(s.For(vote.Ballot{}), true).
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodDelete, "/v2/polls/{id}/votes/{user}", "deleteVoteV2", "Remove a user's votes").
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodPost, "/v2/polls/{id}/options", "addOptionV2", "Add an option to a poll, or suggest one for the poll's creator to approve").Body(building, true).
+		Returns(http.StatusCreated, poll).Returns(http.StatusAccepted, poll).
+		Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodGet, "/v2/polls/{id}/options/{optionId}", "getOptionV2", "Get an option of a poll").
+		Returns(http.StatusOK, building).Fails(envelope, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPatch, "/v2/polls/{id}/options/{optionId}", "renameOptionV2", "Rename an option of a poll, keeping its votes").Body(s.For(vote.OptionPatch{}), true).
+		Returns(http.StatusOK, building).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodDelete, "/v2/polls/{id}/options/{optionId}", "deleteOptionV2", "Remove an option from a poll, or reject a suggested option").
+		Query("moveVotesTo", "the ID of the option to move the removed option's votes to", false).
+		Query("discardVotes", "set to true to discard the removed option's votes", false).
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodPost, "/v2/polls/{id}/options/{optionId}/approve", "approveOptionV2", "Approve a suggested option").
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}/rights/{user}", "setRightsV2", "Set the weight and vetoes of a poll's participant").Body(s.For(vote.Rights{}), true).
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}/rules", "setRulesV2", "Set the quorum and margin a poll must meet to be decided").Body(rules, true).
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodGet, "/v2/polls/{id}/reviews", "getReviewsV2", "List the reviews of a poll's winner").
+		Returns(http.StatusOK, reviews).Fails(envelope, http.StatusNotFound, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}/reviews/{user}", "reviewPollV2", "Review the winner of a closed poll as the calling user").Body(review, true).
+		Returns(http.StatusOK, review).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodPut, "/v2/polls/{id}/vetoes/{optionId}", "vetoOptionV2", "Veto an option, preventing it from winning").
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+	d.Add(http.MethodDelete, "/v2/polls/{id}/vetoes/{optionId}", "withdrawVetoV2", "Withdraw the caller's veto of an option").
+		Returns(http.StatusOK, poll).Fails(envelope, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable)
+
+	// every route is served through the workspace middleware, which may reject a request before it reaches its handler.
+	for path, item := range d.Paths {
+		var rejected *Schema
+		if strings.HasPrefix(path, "/v2/") {
+			rejected = envelope
+		}
+
+		for _, op := range item {
+			op.Header(workspace.WorkspaceHeader, "the workspace the request is made within, defaulting to the default workspace").
+				Header(workspace.UserHeader, "the user making the request, required when a workspace is specified")
+			for _, code := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError} {
+				if op.Responses[strconv.Itoa(code)] == nil {
+					op.Returns(code, rejected)
+				}
+			}
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,20 @@
+package openapi
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// GetSpec provides a http handler returning the OpenAPI document describing the server's routes.
+func GetSpec(w http.ResponseWriter, r *http.Request) {
+	data, err := json.Marshal(Spec())
+	if err != nil {
+		log.Printf("The OpenAPI document could not be serialised to JSON due to: %s\n", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
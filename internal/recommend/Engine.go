@@ -0,0 +1,174 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Engine scores restaurants using the outcomes of past polls. A restaurant's score combines how much the given users have preferred it when it was an option, how recently it
+// last won a poll and how often it has been offered, allowing suggestions to favour popular restaurants while avoiding repeating yesterday's lunch.
+type Engine struct {
+	// HalfLife is the age at which a past poll counts half as much towards a user's preferences as a poll held now.
+	HalfLife time.Duration
+	// Cooldown controls how quickly a restaurant recovers from winning a poll, a restaurant that won Cooldown ago having its score reduced by roughly a third.
+	Cooldown time.Duration
+	// Variety is the weight given to restaurants that have rarely been offered, between 0 and 1.
+	Variety float64
+}
+
+// DefaultEngine provides an engine with sensible defaults for a team ordering lunch most days.
+var DefaultEngine = &Engine{
+	HalfLife: 60 * 24 * time.Hour,
+	Cooldown: 7 * 24 * time.Hour,
+	Variety:  0.2,
+}
+
+// Suggestion represents a restaurant suggested by the engine, along with the components of its score.
+type Suggestion struct {
+	Restaurant *restaurant.Building `json:"restaurant"`
+	Score      float64              `json:"score"`
+	// Preference is the smoothed proportion of votes the restaurant received when offered to the users, 0.5 representing no known preference.
+	Preference float64 `json:"preference"`
+	// Freshness is the factor the score is reduced by due to the restaurant winning recently, 1 representing a restaurant that has not won recently.
+	Freshness float64   `json:"freshness"`
+	Offered   int       `json:"offered"`
+	Wins      int       `json:"wins"`
+	LastWon   time.Time `json:"lastWon"`
+}
+
+// tally holds the weighted statistics gathered for a singular restaurant.
+type tally struct {
+	restaurant *restaurant.Building
+	votesFor   float64
+	votesSeen  float64
+	offered    int
+	wins       int
+	lastWon    time.Time
+}
+
+// Suggest scores every restaurant offered within the given polls for the given users, returning the n best suggestions in descending order of score. Should no users be given,
+// the votes of every user are considered. The voters of private polls are never considered, though the number of votes cast for each of their options is when no users are given.
+func (e *Engine) Suggest(polls []*vote.Poll, users []string, n int, now time.Time) []*Suggestion {
+	tallies := e.tally(polls, users, now)
+
+	suggestions := make([]*Suggestion, 0, len(tallies))
+	for _, t := range tallies {
+		suggestions = append(suggestions, e.score(t, now))
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+
+	if n >= 0 && len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+func (e *Engine) tally(polls []*vote.Poll, users []string, now time.Time) []*tally {
+	considered := make(map[string]bool)
+	for _, u := range users {
+		considered[u] = true
+	}
+
+	tallies := make([]*tally, 0)
+	byID := make(map[string]*tally)
+
+	for _, p := range polls {
+		weight := e.weight(p, now)
+		// polls are seen as by a user who has not voted, so the voters of private polls are never revealed.
+		view := p.ViewFor("", now)
+
+		// votes counts the votes of the considered users for each option, along with the votes counted by private polls should every user be considered.
+		votes := make(map[string]int)
+		total := 0
+		for opt, voters := range view.Votes {
+			for _, u := range voters {
+				if len(considered) == 0 || considered[u] {
+					votes[opt]++
+					total++
+				}
+			}
+		}
+		if len(considered) == 0 {
+			for opt, n := range view.Counts {
+				votes[opt] += n
+				total += n
+			}
+		}
+
+		winner := view.Winner()
+		for _, opt := range p.Options {
+			t, found := byID[opt.ID]
+			if !found {
+				t = &tally{restaurant: opt}
+				byID[opt.ID] = t
+				tallies = append(tallies, t)
+			}
+
+			t.offered++
+			t.votesSeen += weight * float64(total)
+			t.votesFor += weight * float64(votes[opt.ID])
+
+			if winner != nil && winner.ID == opt.ID {
+				t.wins++
+				if pollTime(p).After(t.lastWon) {
+					t.lastWon = pollTime(p)
+				}
+			}
+		}
+	}
+
+	return tallies
+}
+
+func (e *Engine) score(t *tally, now time.Time) *Suggestion {
+	s := &Suggestion{
+		Restaurant: t.restaurant,
+		Offered:    t.offered,
+		Wins:       t.wins,
+		LastWon:    t.lastWon,
+		// Laplace smoothing ensures restaurants with few votes tend towards a neutral preference.
+		Preference: (t.votesFor + 1) / (t.votesSeen + 2),
+		Freshness:  1,
+	}
+
+	if !t.lastWon.IsZero() && e.Cooldown > 0 {
+		since := now.Sub(t.lastWon)
+		if since < 0 {
+			since = 0
+		}
+		s.Freshness = 1 - math.Exp(-float64(since)/float64(e.Cooldown))
+	}
+
+	variety := 1 / float64(1+t.offered)
+	s.Score = ((1-e.Variety)*s.Preference + e.Variety*variety) * s.Freshness
+	return s
+}
+
+// weight returns how much the given poll counts towards user preferences, decaying with the poll's age. Polls without a creation time are given a full weight.
+func (e *Engine) weight(p *vote.Poll, now time.Time) float64 {
+	t := pollTime(p)
+	if t.IsZero() || e.HalfLife <= 0 {
+		return 1
+	}
+
+	age := now.Sub(t)
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, float64(age)/float64(e.HalfLife))
+}
+
+// pollTime returns the time the outcome of the given poll was decided, being its closing time should it have one.
+func pollTime(p *vote.Poll) time.Time {
+	if !p.ClosesAt.IsZero() {
+		return p.ClosesAt
+	}
+	return p.CreatedAt
+}
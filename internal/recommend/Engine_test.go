@@ -0,0 +1,86 @@
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var (
+	now   = time.Date(2018, time.November, 2, 12, 0, 0, 0, time.UTC)
+	pizza = &restaurant.Building{ID: "pizza", Name: "Pizza"}
+	curry = &restaurant.Building{ID: "curry", Name: "Curry"}
+	sushi = &restaurant.Building{ID: "sushi", Name: "Sushi"}
+)
+
+func poll(daysAgo int, votes map[string][]string) *vote.Poll {
+	return &vote.Poll{
+		Options:   []*restaurant.Building{pizza, curry, sushi},
+		Votes:     votes,
+		CreatedAt: now.AddDate(0, 0, -daysAgo),
+	}
+}
+
+func TestSuggestPreference(t *testing.T) {
+	polls := []*vote.Poll{
+		poll(30, map[string][]string{"curry": {"Jack", "Tom"}}),
+		poll(20, map[string][]string{"curry": {"Jack"}, "pizza": {"Will"}}),
+	}
+
+	s := DefaultEngine.Suggest(polls, []string{"Jack"}, 3, now)
+	if len(s) != 3 || s[0].Restaurant.ID != "curry" {
+		t.Fail()
+	}
+}
+
+func TestSuggestRecentWinnerPenalised(t *testing.T) {
+	polls := []*vote.Poll{
+		poll(30, map[string][]string{"curry": {"Jack"}, "pizza": {"Tom"}}),
+		poll(1, map[string][]string{"curry": {"Jack", "Tom"}}),
+	}
+
+	s := DefaultEngine.Suggest(polls, nil, 1, now)
+	if len(s) != 1 || s[0].Restaurant.ID == "curry" {
+		t.Log("Restaurant that won yesterday was suggested first")
+		t.Fail()
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	s := DefaultEngine.Suggest([]*vote.Poll{poll(1, nil)}, nil, 2, now)
+	if len(s) != 2 {
+		t.Fail()
+	}
+}
+
+func TestSuggestNoHistory(t *testing.T) {
+	if s := DefaultEngine.Suggest(nil, nil, 5, now); len(s) != 0 {
+		t.Fail()
+	}
+}
+
+func TestSuggestPrivatePoll(t *testing.T) {
+	private := poll(10, map[string][]string{"sushi": {"Jack", "Tom"}, "pizza": {"Will"}})
+	private.Visibility = vote.Anonymous
+	polls := []*vote.Poll{poll(30, map[string][]string{"curry": {"Jack"}}), private}
+
+	preference := func(s []*Suggestion, id string) float64 {
+		for _, sg := range s {
+			if sg.Restaurant.ID == id {
+				return sg.Preference
+			}
+		}
+		return -1
+	}
+
+	if s := DefaultEngine.Suggest(polls, []string{"Jack"}, 3, now); preference(s, "sushi") != preference(s, "pizza") {
+		t.Logf("Expected Jack's vote within the anonymous poll to be hidden, got %v for sushi and %v for pizza", preference(s, "sushi"), preference(s, "pizza"))
+		t.Fail()
+	}
+	if s := DefaultEngine.Suggest(polls, nil, 3, now); preference(s, "sushi") <= preference(s, "pizza") {
+		t.Logf("Expected the anonymous poll's counts to be considered for every user, got %v for sushi and %v for pizza", preference(s, "sushi"), preference(s, "pizza"))
+		t.Fail()
+	}
+}
@@ -0,0 +1,61 @@
+package recommend
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var instance *Recommender
+
+// Recommender suggests restaurants using the polls stored within the given PollModel.
+type Recommender struct {
+	Model  vote.PollModel
+	Engine *Engine
+	// History states how far back polls are read from, defaulting to all polls within the system.
+	History time.Duration
+}
+
+// Init allows the recommend package to be initialised with the Recommender r.
+func Init(r *Recommender) {
+	instance = r
+}
+
+// Suggest returns the n best suggestions for the given users of the given workspace using the polls stored within the workspace.
+func (r *Recommender) Suggest(workspace string, users []string, n int) (suggestions []*Suggestion, status vote.Status, err error) {
+	now := time.Now()
+
+	since := time.Time{}
+	if r.History > 0 {
+		since = now.Add(-r.History)
+	}
+
+	polls, status, err := r.Model.GetPolls(workspace, since)
+	if err != nil {
+		return
+	}
+
+	engine := r.Engine
+	if engine == nil {
+		engine = DefaultEngine
+	}
+
+	suggestions = engine.Suggest(polls, users, n, now)
+	return
+}
+
+// SuggestOptions returns the restaurants of the n best suggestions for the given users of the given workspace, allowing the Recommender to be used as a vote.Suggester.
+func (r *Recommender) SuggestOptions(workspace string, users []string, n int) (options []*restaurant.Building, err error) {
+	suggestions, _, err := r.Suggest(workspace, users, n)
+	if err != nil {
+		return
+	}
+
+	options = make([]*restaurant.Building, 0, len(suggestions))
+	for _, s := range suggestions {
+		o := *s.Restaurant
+		options = append(options, &o)
+	}
+	return
+}
@@ -0,0 +1,45 @@
+package recommend
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// defaultSuggestions is the number of suggestions returned should a request not specify how many it requires.
+const defaultSuggestions = 5
+
+// GetSuggestions provides a http handler for suggesting restaurants within the caller's workspace. The comma separated 'users' query parameter specifies who the suggestions are
+// for, defaulting to the whole workspace, while the 'n' query parameter specifies the number of suggestions to return.
+func GetSuggestions(w http.ResponseWriter, r *http.Request) {
+	n := defaultSuggestions
+	if param := r.URL.Query().Get("n"); param != "" {
+		var err error
+		n, err = strconv.Atoi(param)
+		if err != nil || n < 1 {
+			log.Printf("Invalid number of suggestions %s requested\n", param)
+			http.Error(w, "Could not parse number of suggestions", http.StatusBadRequest)
+			return
+		}
+	}
+
+	suggestions, _, err := instance.Suggest(caller.Workspace(r.Context()), vote.SplitList(r.URL.Query().Get("users")), n)
+	if err != nil {
+		log.Printf("Could not suggest restaurants due to: %s\n", err.Error())
+		http.Error(w, "Could not suggest restaurants", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		log.Printf("The suggestions %v could not be serialised to JSON.\n", suggestions)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
+}
@@ -1,8 +1,81 @@
 package restaurant
 
+import (
+	"time"
+)
+
+// Service represents how a restaurant serves its orders.
+type Service string
+
+const (
+	// Delivery states that a restaurant only delivers its orders.
+	Delivery Service = "delivery"
+	// Collection states that a restaurant's orders must be collected.
+	Collection Service = "collection"
+	// DeliveryAndCollection states that a restaurant's orders may either be delivered or collected.
+	DeliveryAndCollection Service = "both"
+)
+
+// Delivers returns whether a restaurant offering the service delivers its orders.
+func (s Service) Delivers() bool {
+	return s == Delivery || s == DeliveryAndCollection
+}
+
 // Building represents a singular restaurant building within the system.
 type Building struct {
 	ID      string `json:"id" bson:"id"`
 	Name    string `json:"name" bson:"name"`
 	Address string `json:"address" bson:"address"`
+	// Workspace is the workspace the building belongs to within the restaurant catalogue, being empty for the default workspace.
+	Workspace string `json:"workspace,omitempty" bson:"workspace,omitempty"`
+	// Location gives the coordinates of the building, nil should they be unknown.
+	Location *Location `json:"location,omitempty" bson:"location,omitempty"`
+	// DeliveryRadius is the distance in metres from the building the restaurant delivers within, 0 stating the radius is unknown.
+	DeliveryRadius float64 `json:"deliveryRadius,omitempty" bson:"deliveryRadius,omitempty"`
+	// Service states whether the restaurant delivers its orders or has them collected, an empty service stating it is unknown.
+	Service Service `json:"service,omitempty" bson:"service,omitempty"`
+	// Hours lists the periods the restaurant is open each week, within the time zone given by TimeZone, no hours stating the restaurant's opening hours are unknown. Exceptions
+	// lists the dates the weekly hours do not apply, such as public holidays.
+	Hours      []*OpeningHours   `json:"hours,omitempty" bson:"hours,omitempty"`
+	Exceptions []*HoursException `json:"exceptions,omitempty" bson:"exceptions,omitempty"`
+	TimeZone   string            `json:"timeZone,omitempty" bson:"timeZone,omitempty"`
+}
+
+// Zone returns the time zone of the restaurant's opening hours, being UTC should the restaurant not give a time zone or give one which is not known.
+func (b *Building) Zone() *time.Location {
+	if b.TimeZone == "" {
+		return time.UTC
+	}
+	if loc, err := time.LoadLocation(b.TimeZone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Copy creates a deep copy of the building, ensuring changes made to the returned building do not affect the original.
+func (b *Building) Copy() *Building {
+	c := *b
+
+	if b.Location != nil {
+		l := *b.Location
+		c.Location = &l
+	}
+
+	if b.Hours != nil {
+		c.Hours = make([]*OpeningHours, 0, len(b.Hours))
+		for _, h := range b.Hours {
+			hc := *h
+			c.Hours = append(c.Hours, &hc)
+		}
+	}
+
+	if b.Exceptions != nil {
+		c.Exceptions = make([]*HoursException, 0, len(b.Exceptions))
+		for _, ex := range b.Exceptions {
+			exc := *ex
+			c.Exceptions = append(c.Exceptions, &exc)
+		}
+	}
+
+	return &c
 }
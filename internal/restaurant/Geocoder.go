@@ -0,0 +1,30 @@
+package restaurant
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Geocoder defines a contract for components able to find the location of an address.
+type Geocoder interface {
+	// Geocode returns the location of the given address, returning an error should the address not be found.
+	Geocode(address string) (*Location, error)
+}
+
+// StubGeocoder provides an offline implementation of the Geocoder interface, looking addresses up within a fixed table rather than calling a geocoding service. Addresses are
+// matched ignoring case and surrounding whitespace.
+type StubGeocoder struct {
+	Locations map[string]Location
+}
+
+// Geocode returns the location the given address is mapped to within the stub's table, returning an error should the address not be within the table.
+func (g *StubGeocoder) Geocode(address string) (*Location, error) {
+	key := strings.ToLower(strings.TrimSpace(address))
+	for a, l := range g.Locations {
+		if strings.ToLower(strings.TrimSpace(a)) == key {
+			loc := l
+			return &loc, nil
+		}
+	}
+	return nil, fmt.Errorf("the address %s could not be found", address)
+}
@@ -0,0 +1,101 @@
+package restaurant
+
+import (
+	"strings"
+	"time"
+)
+
+// OpeningHours represents a single period a restaurant is open on a day of the week, the day being given by its lowercase English name and the times as 'hh:mm' within the
+// restaurant's time zone. Periods closing at or before they open run past midnight into the following day, so '00:00' to '00:00' is open all day.
+type OpeningHours struct {
+	Day    string `json:"day" bson:"day"`
+	Opens  string `json:"opens" bson:"opens"`
+	Closes string `json:"closes" bson:"closes"`
+}
+
+// HoursException represents a date on which a restaurant's weekly opening hours do not apply, such as a public holiday, the date being given as 'yyyy-mm-dd'. The restaurant is
+// open between Opens and Closes on the date, given as for OpeningHours, or is closed all day should neither be given.
+type HoursException struct {
+	Date   string `json:"date" bson:"date"`
+	Opens  string `json:"opens,omitempty" bson:"opens,omitempty"`
+	Closes string `json:"closes,omitempty" bson:"closes,omitempty"`
+}
+
+// ParseDay parses the lowercase English name of a day of the week, returning false should the name not be a day of the week.
+func ParseDay(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.ToLower(d.String()) == name {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// ParseClock parses a time of day given as 'hh:mm', returning the number of minutes since midnight or false should the time not be valid.
+func ParseClock(clock string) (int, bool) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// ParseDate parses a date given as 'yyyy-mm-dd', returning false should the date not be valid.
+func ParseDate(date string) (time.Time, bool) {
+	t, err := time.Parse("2006-01-02", date)
+	return t, err == nil
+}
+
+// IsOpen returns whether the restaurant is open at the given time, with known being false should the restaurant's opening hours be unknown. Exceptions replace the weekly periods
+// opening on their date, while periods opening the day before and running past midnight still apply.
+func (b *Building) IsOpen(t time.Time) (open bool, known bool) {
+	t = t.In(b.Zone())
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if len(b.Hours) == 0 && b.exceptionOn(today) == nil {
+		return false, false
+	}
+
+	// periods opening either today or yesterday may include the given time.
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		if ex := b.exceptionOn(day); ex != nil {
+			if includes(day, ex.Opens, ex.Closes, t) {
+				return true, true
+			}
+			continue
+		}
+
+		for _, h := range b.Hours {
+			if d, ok := ParseDay(h.Day); ok && d == day.Weekday() && includes(day, h.Opens, h.Closes, t) {
+				return true, true
+			}
+		}
+	}
+	return false, true
+}
+
+// exceptionOn returns the exception to the restaurant's weekly opening hours on the date of the given time, nil should there be no exception on the date.
+func (b *Building) exceptionOn(day time.Time) *HoursException {
+	date := day.Format("2006-01-02")
+	for _, ex := range b.Exceptions {
+		if ex.Date == date {
+			return ex
+		}
+	}
+	return nil
+}
+
+// includes returns whether the period opening and closing at the given times of the given day includes the time t, periods which cannot be parsed never including any time.
+func includes(day time.Time, opens string, closes string, t time.Time) bool {
+	o, ok1 := ParseClock(opens)
+	c, ok2 := ParseClock(closes)
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	start := day.Add(time.Duration(o) * time.Minute)
+	end := day.Add(time.Duration(c) * time.Minute)
+	if c <= o {
+		end = end.Add(24 * time.Hour)
+	}
+	return !t.Before(start) && t.Before(end)
+}
@@ -0,0 +1,27 @@
+package restaurant
+
+import (
+	"math"
+)
+
+// EarthRadius is the mean radius of the earth in metres, used when calculating the distance between locations.
+const EarthRadius = 6371000.0
+
+// Location represents a point on the earth's surface, given by its latitude and longitude in degrees.
+type Location struct {
+	Lat float64 `json:"lat" bson:"lat"`
+	Lng float64 `json:"lng" bson:"lng"`
+}
+
+// DistanceTo returns the distance in metres between the location and the given location along the earth's surface, calculated using the haversine formula.
+func (l Location) DistanceTo(o Location) float64 {
+	lat1, lat2 := radians(l.Lat), radians(o.Lat)
+	dLat, dLng := lat2-lat1, radians(o.Lng-l.Lng)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(a))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,270 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/rpc/pollpb"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/websocket"
+	"takeaway/takeaway-server/internal/workspace"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// WorkspaceKey is the metadata key a call specifies the workspace it is made within by.
+	WorkspaceKey = "x-workspace"
+	// UserKey is the metadata key a call specifies the user making it by.
+	UserKey = "x-user"
+)
+
+// Server implements the PollService, applying changes through the vote package's shared operations so calls are treated the same as requests to the HTTP API.
+type Server struct {
+	pollpb.UnimplementedPollServiceServer
+	// Hub is the hub whose broadcasts are streamed to WatchPoll calls.
+	Hub *websocket.Hub
+}
+
+// NewServer creates a gRPC server serving the PollService, with updates for WatchPoll calls taken from the given hub.
+func NewServer(hub *websocket.Hub) *grpc.Server {
+	s := grpc.NewServer(grpc.UnaryInterceptor(unaryCaller), grpc.StreamInterceptor(streamCaller))
+	pollpb.RegisterPollServiceServer(s, &Server{Hub: hub})
+	return s
+}
+
+// Serve serves the PollService on the given listener, blocking until the listener fails.
+func Serve(lis net.Listener, hub *websocket.Hub) error {
+	log.Printf("Serving gRPC requests on %s\n", lis.Addr())
+	return NewServer(hub).Serve(lis)
+}
+
+// GetPoll returns the poll with the requested ID.
+func (s *Server) GetPoll(ctx context.Context, req *pollpb.GetPollRequest) (*pollpb.Poll, error) {
+	p, st, err := vote.FindPoll(caller.Workspace(ctx), req.Id)
+	if err != nil {
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// NewPoll creates a poll with the requested options, recording the caller as the poll's creator.
+func (s *Server) NewPoll(ctx context.Context, req *pollpb.NewPollRequest) (*pollpb.Poll, error) {
+	if len(req.Options) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a poll must have at least one option")
+	}
+
+	options := make([]*restaurant.Building, 0, len(req.Options))
+	for _, opt := range req.Options {
+		options = append(options, fromRestaurant(opt))
+	}
+
+	settings := &vote.PollSettings{AllowSuggestions: req.AllowSuggestions, Visibility: vote.Visibility(req.Visibility), Rules: fromRules(req.Rules)}
+	p, st, err := vote.CreatePoll(caller.Workspace(ctx), caller.User(ctx), options, settings)
+	if err != nil {
+		log.Printf("Could not create poll due to: %s\n", err.Error())
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// UpdatePoll replaces the stored poll with the requested poll, which must already exist within the caller's workspace.
+func (s *Server) UpdatePoll(ctx context.Context, req *pollpb.UpdatePollRequest) (*pollpb.Poll, error) {
+	if req.Poll == nil || req.Poll.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "a poll with an ID must be given")
+	}
+
+	p := fromPoll(req.Poll)
+	// polls can only be updated within the caller's workspace, preventing polls from being moved between workspaces.
+	p.Workspace = caller.Workspace(ctx)
+
+	st, err := vote.SavePoll(p, caller.User(ctx))
+	if err != nil {
+		log.Printf("Could not update poll with id %s due to: %s\n", p.ID, err.Error())
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// DeletePoll deletes the poll with the requested ID.
+func (s *Server) DeletePoll(ctx context.Context, req *pollpb.DeletePollRequest) (*pollpb.DeletePollResponse, error) {
+	st, err := vote.RemovePoll(caller.Workspace(ctx), req.Id)
+	if err != nil {
+		return nil, toError(st, err)
+	}
+	return &pollpb.DeletePollResponse{}, nil
+}
+
+// CastVote records a vote for the requested option, made by the requested user or the caller should no user be given.
+func (s *Server) CastVote(ctx context.Context, req *pollpb.CastVoteRequest) (*pollpb.Poll, error) {
+	user := req.User
+	if user == "" {
+		user = caller.User(ctx)
+	}
+	if user == "" || req.OptionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "a user and option must be given")
+	}
+
+	if st, err := vote.CheckVoter(caller.Workspace(ctx), req.PollId, caller.User(ctx), user); err != nil {
+		return nil, toError(st, err)
+	}
+
+	p, st, err := vote.CastVote(caller.Workspace(ctx), req.PollId, user, req.OptionId)
+	if err != nil {
+		log.Printf("Could not add vote to poll %s due to: %s\n", req.PollId, err.Error())
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// SetRights sets the rights of the requested participant, the caller being required to be the poll's organiser.
+func (s *Server) SetRights(ctx context.Context, req *pollpb.SetRightsRequest) (*pollpb.Poll, error) {
+	if req.User == "" || req.Rights == nil {
+		return nil, status.Error(codes.InvalidArgument, "a user and their rights must be given")
+	}
+
+	p, st, err := vote.SetParticipantRights(caller.Workspace(ctx), req.PollId, caller.User(ctx), req.User, fromRights(req.Rights))
+	if err != nil {
+		log.Printf("Could not set the rights of %s within poll %s due to: %s\n", req.User, req.PollId, err.Error())
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// VetoOption records the caller vetoing the requested option, or withdraws the caller's veto should withdraw be set.
+func (s *Server) VetoOption(ctx context.Context, req *pollpb.VetoOptionRequest) (*pollpb.Poll, error) {
+	change := vote.VetoOption
+	if req.Withdraw {
+		change = vote.WithdrawVeto
+	}
+
+	p, st, err := change(caller.Workspace(ctx), req.PollId, caller.User(ctx), req.OptionId)
+	if err != nil {
+		log.Printf("Could not change the veto of %s within poll %s due to: %s\n", req.OptionId, req.PollId, err.Error())
+		return nil, toError(st, err)
+	}
+	return view(ctx, p), nil
+}
+
+// WatchPoll streams the requested poll each time the hub broadcasts a change to it, until the call is cancelled.
+func (s *Server) WatchPoll(req *pollpb.WatchPollRequest, stream pollpb.PollService_WatchPollServer) error {
+	ctx := stream.Context()
+	// only allow clients to watch polls within their own workspace.
+	if _, st, err := vote.FindPoll(caller.Workspace(ctx), req.Id); err != nil {
+		return toError(st, err)
+	}
+
+	messages, stop := s.Hub.Listen(req.Id)
+	defer stop()
+
+	// the header is sent once the listener is registered, letting clients know no updates will be missed from this point.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			// the hub also broadcasts events for a poll, which are not streamed.
+			p, isPoll := msg.Data.(*vote.Poll)
+			if !isPoll {
+				continue
+			}
+			if err := stream.Send(view(ctx, p)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// view converts the view of the given poll for the caller into its protobuf message, private polls only showing the caller their own vote.
+func view(ctx context.Context, p *vote.Poll) *pollpb.Poll {
+	return toPoll(p.ViewFor(caller.User(ctx), time.Now()))
+}
+
+// toError converts an error returned by the vote package into a gRPC status error. Validation errors include the invalid fields as BadRequest details.
+func toError(st vote.Status, err error) error {
+	switch st {
+	case vote.NotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case vote.Invalid:
+		s := status.New(codes.InvalidArgument, err.Error())
+		var verr *vote.ValidationError
+		if errors.As(err, &verr) {
+			br := &errdetails.BadRequest{}
+			for _, f := range verr.Fields {
+				br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+			}
+			if ds, derr := s.WithDetails(br); derr == nil {
+				s = ds
+			}
+		}
+		return s.Err()
+	case vote.NoConnection:
+		return status.Error(codes.Unavailable, err.Error())
+	case vote.Conflict:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case vote.Forbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// authorise records the workspace and user specified by the metadata of a call within the returned context, rejecting calls to workspaces the user cannot access.
+func authorise(ctx context.Context) (context.Context, error) {
+	var id, user string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(WorkspaceKey); len(v) > 0 {
+			id = v[0]
+		}
+		if v := md.Get(UserKey); len(v) > 0 {
+			user = v[0]
+		}
+	}
+
+	if code, err := workspace.Authorise(id, user); err != nil {
+		log.Printf("Rejecting call by %s to workspace %s due to: %s\n", user, id, err.Error())
+		return nil, status.Error(authCodes[code], err.Error())
+	}
+	return caller.NewContext(ctx, id, user), nil
+}
+
+func unaryCaller(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authorise(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamCaller(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authorise(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+}
+
+// callerStream overrides the context of a stream with one recording the caller.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
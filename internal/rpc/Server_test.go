@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/rpc/pollpb"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/websocket"
+	"takeaway/takeaway-server/internal/workspace"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// hub ensures the websocket hub is running, as changes to polls block while being broadcast.
+var hub sync.Once
+
+// client serves the PollService over an in-process listener, returning a client connected to it.
+func client(t *testing.T) pollpb.PollServiceClient {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}})
+	workspace.Init(&workspace.Container{Model: &workspace.MockWorkspaceModel{}})
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := NewServer(websocket.HubInstance)
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Could not connect to the in-process server: %s", err.Error())
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+	return pollpb.NewPollServiceClient(conn)
+}
+
+func TestGetPoll(t *testing.T) {
+	c := client(t)
+
+	p, err := c.GetPoll(context.Background(), &pollpb.GetPollRequest{Id: "test"})
+	if err != nil {
+		t.Logf("Expected poll test to be returned, got %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(p.Options) != 2 || len(p.Votes["r1"].GetUsers()) != 2 {
+		t.Logf("Expected poll with 2 options and 2 votes for r1, got %v", p)
+		t.Fail()
+	}
+
+	_, err = c.GetPoll(context.Background(), &pollpb.GetPollRequest{Id: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Logf("Expected unknown poll to not be found, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestNewPollAndVote(t *testing.T) {
+	c := client(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), UserKey, "Jack")
+
+	p, err := c.NewPoll(ctx, &pollpb.NewPollRequest{Options: []*pollpb.Restaurant{{Id: "r1", Name: "Restaurant 1"}}})
+	if err != nil || p.Creator != "Jack" {
+		t.Logf("Expected poll created by Jack, got %v, %v", p, err)
+		t.FailNow()
+	}
+
+	p, err = c.CastVote(ctx, &pollpb.CastVoteRequest{PollId: p.Id, OptionId: "r1"})
+	if err != nil || len(p.Votes["r1"].GetUsers()) != 1 || p.Votes["r1"].Users[0] != "Jack" {
+		t.Logf("Expected Jack's vote to be cast, got %v, %v", p, err)
+		t.Fail()
+	}
+
+	_, err = c.NewPoll(ctx, &pollpb.NewPollRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Logf("Expected poll without options to be invalid, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestWorkspaceRejected(t *testing.T) {
+	c := client(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), WorkspaceKey, "team")
+
+	_, err := c.GetPoll(ctx, &pollpb.GetPollRequest{Id: "test"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Logf("Expected call to a workspace without a user to be unauthenticated, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestWatchPoll(t *testing.T) {
+	c := client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := c.WatchPoll(ctx, &pollpb.WatchPollRequest{Id: "test"})
+	if err != nil {
+		t.Logf("Expected to watch poll test, got %s", err.Error())
+		t.FailNow()
+	}
+
+	// the header is received once the server is listening for updates.
+	if _, err = stream.Header(); err != nil {
+		t.Logf("Expected the stream's header, got %s", err.Error())
+		t.FailNow()
+	}
+
+	if _, _, err = vote.CastVote("", "test", "Kate", "r2"); err != nil {
+		t.Logf("Expected the vote to be cast, got %s", err.Error())
+		t.FailNow()
+	}
+
+	p, err := stream.Recv()
+	if err != nil {
+		t.Logf("Expected an update to poll test, got %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(p.Votes["r2"].GetUsers()) != 3 {
+		t.Logf("Expected Kate's vote within the update, got %v", p.Votes)
+		t.Fail()
+	}
+}
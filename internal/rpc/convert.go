@@ -0,0 +1,230 @@
+package rpc
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/rpc/pollpb"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/workspace"
+
+	"google.golang.org/grpc/codes"
+)
+
+// authCodes maps the error codes returned by workspace.Authorise to the gRPC code of the rejected call.
+var authCodes = map[string]codes.Code{
+	workspace.Unauthorised:   codes.Unauthenticated,
+	workspace.Forbidden:      codes.PermissionDenied,
+	vote.NotFound.Code():     codes.NotFound,
+	vote.NoConnection.Code(): codes.Unavailable,
+}
+
+func toRestaurant(b *restaurant.Building) *pollpb.Restaurant {
+	res := &pollpb.Restaurant{
+		Id:             b.ID,
+		Name:           b.Name,
+		Address:        b.Address,
+		Location:       toLocation(b.Location),
+		DeliveryRadius: b.DeliveryRadius,
+		Service:        string(b.Service),
+		TimeZone:       b.TimeZone,
+	}
+	for _, h := range b.Hours {
+		res.Hours = append(res.Hours, &pollpb.OpeningHours{Day: h.Day, Opens: h.Opens, Closes: h.Closes})
+	}
+	for _, ex := range b.Exceptions {
+		res.Exceptions = append(res.Exceptions, &pollpb.HoursException{Date: ex.Date, Opens: ex.Opens, Closes: ex.Closes})
+	}
+	return res
+}
+
+func fromRestaurant(r *pollpb.Restaurant) *restaurant.Building {
+	res := &restaurant.Building{
+		ID:             r.Id,
+		Name:           r.Name,
+		Address:        r.Address,
+		Location:       fromLocation(r.Location),
+		DeliveryRadius: r.DeliveryRadius,
+		Service:        restaurant.Service(r.Service),
+		TimeZone:       r.TimeZone,
+	}
+	for _, h := range r.GetHours() {
+		res.Hours = append(res.Hours, &restaurant.OpeningHours{Day: h.Day, Opens: h.Opens, Closes: h.Closes})
+	}
+	for _, ex := range r.GetExceptions() {
+		res.Exceptions = append(res.Exceptions, &restaurant.HoursException{Date: ex.Date, Opens: ex.Opens, Closes: ex.Closes})
+	}
+	return res
+}
+
+func toLocation(l *restaurant.Location) *pollpb.Location {
+	if l == nil {
+		return nil
+	}
+	return &pollpb.Location{Lat: l.Lat, Lng: l.Lng}
+}
+
+func fromLocation(l *pollpb.Location) *restaurant.Location {
+	if l == nil {
+		return nil
+	}
+	return &restaurant.Location{Lat: l.Lat, Lng: l.Lng}
+}
+
+// toPoll converts the given poll into its protobuf message, zero times being represented by a timestamp of 0.
+func toPoll(p *vote.Poll) *pollpb.Poll {
+	res := &pollpb.Poll{
+		Id:               p.ID,
+		Workspace:        p.Workspace,
+		Creator:          p.Creator,
+		Votes:            make(map[string]*pollpb.Voters),
+		CreatedAt:        unix(p.CreatedAt),
+		ClosesAt:         unix(p.ClosesAt),
+		Participants:     p.Participants,
+		AllowSuggestions: p.AllowSuggestions,
+		Visibility:       string(p.Visibility),
+		ResultsHidden:    p.ResultsHidden,
+		Rules:            toRules(p.Rules),
+		Outcome:          string(p.Outcome),
+		Extensions:       int32(p.Extensions),
+		Office:           toLocation(p.Office),
+		Unavailable:      p.Unavailable,
+	}
+
+	for _, opt := range p.Options {
+		res.Options = append(res.Options, toRestaurant(opt))
+	}
+	if p.Counts != nil {
+		res.Counts = make(map[string]int32)
+		for id, n := range p.Counts {
+			res.Counts[id] = int32(n)
+		}
+	}
+	if p.Rights != nil {
+		res.Rights = make(map[string]*pollpb.Rights)
+		for user, r := range p.Rights {
+			res.Rights[user] = &pollpb.Rights{Weight: int32(r.Weight), Vetoes: int32(r.Vetoes)}
+		}
+	}
+	if p.Vetoed != nil {
+		res.Vetoed = make(map[string]*pollpb.Voters)
+		for id, users := range p.Vetoed {
+			res.Vetoed[id] = &pollpb.Voters{Users: users}
+		}
+	}
+	for _, t := range p.Tally {
+		res.Tally = append(res.Tally, &pollpb.OptionTally{OptionId: t.OptionID, Votes: int32(t.Votes), Weighted: int32(t.Weighted), Vetoed: t.Vetoed, VetoedBy: t.VetoedBy})
+	}
+	for _, d := range p.Delivery {
+		pd := &pollpb.OptionDelivery{OptionId: d.OptionID, CanDeliver: d.CanDeliver, Reason: d.Reason}
+		if d.Distance != nil {
+			pd.HasDistance, pd.Distance = true, *d.Distance
+		}
+		if d.Open != nil {
+			pd.HasOpen, pd.Open = true, *d.Open
+		}
+		res.Delivery = append(res.Delivery, pd)
+	}
+	for _, s := range p.Pending {
+		res.Pending = append(res.Pending, &pollpb.Suggestion{Option: toRestaurant(s.Option), SuggestedBy: s.SuggestedBy, SuggestedAt: unix(s.SuggestedAt)})
+	}
+	for id, users := range p.Votes {
+		res.Votes[id] = &pollpb.Voters{Users: users}
+	}
+	return res
+}
+
+// fromPoll converts the given protobuf message into a poll.
+func fromPoll(p *pollpb.Poll) *vote.Poll {
+	res := &vote.Poll{
+		ID:               p.Id,
+		Workspace:        p.Workspace,
+		Creator:          p.Creator,
+		Votes:            make(map[string][]string),
+		Options:          make([]*restaurant.Building, 0, len(p.Options)),
+		CreatedAt:        fromUnix(p.CreatedAt),
+		ClosesAt:         fromUnix(p.ClosesAt),
+		AllowSuggestions: p.AllowSuggestions,
+		Visibility:       vote.Visibility(p.Visibility),
+		Rules:            fromRules(p.Rules),
+		Office:           fromLocation(p.Office),
+	}
+
+	for _, opt := range p.Options {
+		res.Options = append(res.Options, fromRestaurant(opt))
+	}
+	for _, s := range p.GetPending() {
+		res.Pending = append(res.Pending, &vote.PendingOption{Option: fromRestaurant(s.GetOption()), SuggestedBy: s.SuggestedBy, SuggestedAt: fromUnix(s.SuggestedAt)})
+	}
+	for id, voters := range p.Votes {
+		res.Votes[id] = append([]string{}, voters.GetUsers()...)
+	}
+	for user, r := range p.GetRights() {
+		if res.Rights == nil {
+			res.Rights = make(map[string]vote.Rights)
+		}
+		res.Rights[user] = fromRights(r)
+	}
+	for id, voters := range p.GetVetoed() {
+		if res.Vetoed == nil {
+			res.Vetoed = make(map[string][]string)
+		}
+		res.Vetoed[id] = append([]string{}, voters.GetUsers()...)
+	}
+	return res
+}
+
+// fromRights converts the given protobuf message into rights, a missing weight defaulting to 1.
+func fromRights(r *pollpb.Rights) vote.Rights {
+	res := vote.Rights{Weight: int(r.GetWeight()), Vetoes: int(r.GetVetoes())}
+	if res.Weight == 0 {
+		res.Weight = vote.MinWeight
+	}
+	return res
+}
+
+// toRules converts the given rules into their protobuf message, nil rules being left out.
+func toRules(r *vote.Rules) *pollpb.Rules {
+	if r == nil {
+		return nil
+	}
+	return &pollpb.Rules{
+		Quorum:        int32(r.Quorum),
+		QuorumPercent: int32(r.QuorumPercent),
+		Invited:       r.Invited,
+		Margin:        int32(r.Margin),
+		OnFailure:     string(r.OnFailure),
+		ExtendBy:      int32(r.ExtendBy),
+		MaxExtensions: int32(r.MaxExtensions),
+	}
+}
+
+// fromRules converts the given protobuf message into rules, a missing message leaving the poll without rules.
+func fromRules(r *pollpb.Rules) *vote.Rules {
+	if r == nil {
+		return nil
+	}
+	return &vote.Rules{
+		Quorum:        int(r.Quorum),
+		QuorumPercent: int(r.QuorumPercent),
+		Invited:       append([]string(nil), r.Invited...),
+		Margin:        int(r.Margin),
+		OnFailure:     vote.FailureAction(r.OnFailure),
+		ExtendBy:      int(r.ExtendBy),
+		MaxExtensions: int(r.MaxExtensions),
+	}
+}
+
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnix(s int64) time.Time {
+	if s == 0 {
+		return time.Time{}
+	}
+	return time.Unix(s, 0).UTC()
+}
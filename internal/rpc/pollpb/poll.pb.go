@@ -0,0 +1,2081 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: poll.proto
+
+// The poll service mirrors vote.PollModel for services preferring gRPC over the HTTP API. The workspace and user a call is made by are read from the 'x-workspace' and 'x-user'
+// metadata, matching the X-Workspace and X-User headers of the HTTP API.
+//
+// Regenerate the Go code within this directory using:
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative poll.proto
+
+package pollpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Restaurant struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address  string    `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Location *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// delivery_radius is given in metres, 0 stating it is unknown, while service is one of delivery, collection or both.
+	DeliveryRadius float64 `protobuf:"fixed64,5,opt,name=delivery_radius,json=deliveryRadius,proto3" json:"delivery_radius,omitempty"`
+	Service        string  `protobuf:"bytes,6,opt,name=service,proto3" json:"service,omitempty"`
+	// hours lists the periods the restaurant is open each week within time_zone, defaulting to UTC.
+	Hours    []*OpeningHours `protobuf:"bytes,7,rep,name=hours,proto3" json:"hours,omitempty"`
+	TimeZone string          `protobuf:"bytes,8,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// exceptions replace the weekly hours on the given dates, such as public holidays.
+	Exceptions []*HoursException `protobuf:"bytes,9,rep,name=exceptions,proto3" json:"exceptions,omitempty"`
+}
+
+func (x *Restaurant) Reset() {
+	*x = Restaurant{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Restaurant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restaurant) ProtoMessage() {}
+
+func (x *Restaurant) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restaurant.ProtoReflect.Descriptor instead.
+func (*Restaurant) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Restaurant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Restaurant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Restaurant) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Restaurant) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Restaurant) GetDeliveryRadius() float64 {
+	if x != nil {
+		return x.DeliveryRadius
+	}
+	return 0
+}
+
+func (x *Restaurant) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Restaurant) GetHours() []*OpeningHours {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+func (x *Restaurant) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Restaurant) GetExceptions() []*HoursException {
+	if x != nil {
+		return x.Exceptions
+	}
+	return nil
+}
+
+// Location gives a latitude and longitude in degrees.
+type Location struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng float64 `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Location) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Location) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+// OpeningHours gives a period a restaurant is open, day being the lowercase name of a day of the week and opens and closes being times given as hh:mm.
+type OpeningHours struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day    string `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Opens  string `protobuf:"bytes,2,opt,name=opens,proto3" json:"opens,omitempty"`
+	Closes string `protobuf:"bytes,3,opt,name=closes,proto3" json:"closes,omitempty"`
+}
+
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpeningHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpeningHours) ProtoMessage() {}
+
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OpeningHours) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetOpens() string {
+	if x != nil {
+		return x.Opens
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetCloses() string {
+	if x != nil {
+		return x.Closes
+	}
+	return ""
+}
+
+// HoursException gives the hours a restaurant is open on a date given as yyyy-mm-dd, the restaurant being closed all day should neither opens nor closes be given.
+type HoursException struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date   string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Opens  string `protobuf:"bytes,2,opt,name=opens,proto3" json:"opens,omitempty"`
+	Closes string `protobuf:"bytes,3,opt,name=closes,proto3" json:"closes,omitempty"`
+}
+
+func (x *HoursException) Reset() {
+	*x = HoursException{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HoursException) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoursException) ProtoMessage() {}
+
+func (x *HoursException) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoursException.ProtoReflect.Descriptor instead.
+func (*HoursException) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HoursException) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *HoursException) GetOpens() string {
+	if x != nil {
+		return x.Opens
+	}
+	return ""
+}
+
+func (x *HoursException) GetCloses() string {
+	if x != nil {
+		return x.Closes
+	}
+	return ""
+}
+
+// Voters lists the users who voted for a single option.
+type Voters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users []string `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+}
+
+func (x *Voters) Reset() {
+	*x = Voters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Voters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Voters) ProtoMessage() {}
+
+func (x *Voters) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Voters.ProtoReflect.Descriptor instead.
+func (*Voters) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Voters) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type Poll struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Workspace string        `protobuf:"bytes,2,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Creator   string        `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Options   []*Restaurant `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	// votes maps the ID of each option to the users who voted for it.
+	Votes map[string]*Voters `protobuf:"bytes,5,rep,name=votes,proto3" json:"votes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// created_at and closes_at are unix timestamps in seconds, a closes_at of 0 stating the poll has no set closing time.
+	CreatedAt    int64    `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ClosesAt     int64    `protobuf:"varint,7,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	Participants []string `protobuf:"bytes,8,rep,name=participants,proto3" json:"participants,omitempty"`
+	// allow_suggestions states whether users other than the creator may suggest options, which are pending until approved by the creator.
+	AllowSuggestions bool          `protobuf:"varint,9,opt,name=allow_suggestions,json=allowSuggestions,proto3" json:"allow_suggestions,omitempty"`
+	Pending          []*Suggestion `protobuf:"bytes,10,rep,name=pending,proto3" json:"pending,omitempty"`
+	// visibility is one of public, anonymous or secret. The votes of anonymous and secret polls only include the caller's own vote, with counts giving the number of votes for
+	// each option unless results_hidden states they are hidden until the poll closes.
+	Visibility    string           `protobuf:"bytes,11,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Counts        map[string]int32 `protobuf:"bytes,12,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ResultsHidden bool             `protobuf:"varint,13,opt,name=results_hidden,json=resultsHidden,proto3" json:"results_hidden,omitempty"`
+	// rights maps each participant given rights by the creator to their rights, while vetoed maps the ID of each vetoed option to the users who vetoed it.
+	Rights map[string]*Rights `protobuf:"bytes,14,rep,name=rights,proto3" json:"rights,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Vetoed map[string]*Voters `protobuf:"bytes,15,rep,name=vetoed,proto3" json:"vetoed,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// tally gives the raw and weighted votes for each option, in the order the options are listed, being empty while results_hidden is set.
+	Tally []*OptionTally `protobuf:"bytes,16,rep,name=tally,proto3" json:"tally,omitempty"`
+	// rules gives the requirements the poll must meet to be decided, with outcome being decided or failed once the poll has been settled against them and extensions the number
+	// of times the poll has been extended for not meeting them.
+	Rules      *Rules `protobuf:"bytes,17,opt,name=rules,proto3" json:"rules,omitempty"`
+	Outcome    string `protobuf:"bytes,18,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Extensions int32  `protobuf:"varint,19,opt,name=extensions,proto3" json:"extensions,omitempty"`
+	// office gives the location the order is delivered to, with delivery reporting whether each option can deliver to it at the poll's closing time.
+	Office   *Location         `protobuf:"bytes,20,opt,name=office,proto3" json:"office,omitempty"`
+	Delivery []*OptionDelivery `protobuf:"bytes,21,rep,name=delivery,proto3" json:"delivery,omitempty"`
+	// unavailable lists the options whose restaurants are closed at the poll's closing time, which cannot be voted for.
+	Unavailable []string `protobuf:"bytes,22,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Poll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Poll) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *Poll) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *Poll) GetOptions() []*Restaurant {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetVotes() map[string]*Voters {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *Poll) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Poll) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+func (x *Poll) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *Poll) GetAllowSuggestions() bool {
+	if x != nil {
+		return x.AllowSuggestions
+	}
+	return false
+}
+
+func (x *Poll) GetPending() []*Suggestion {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *Poll) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Poll) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *Poll) GetResultsHidden() bool {
+	if x != nil {
+		return x.ResultsHidden
+	}
+	return false
+}
+
+func (x *Poll) GetRights() map[string]*Rights {
+	if x != nil {
+		return x.Rights
+	}
+	return nil
+}
+
+func (x *Poll) GetVetoed() map[string]*Voters {
+	if x != nil {
+		return x.Vetoed
+	}
+	return nil
+}
+
+func (x *Poll) GetTally() []*OptionTally {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+func (x *Poll) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Poll) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Poll) GetExtensions() int32 {
+	if x != nil {
+		return x.Extensions
+	}
+	return 0
+}
+
+func (x *Poll) GetOffice() *Location {
+	if x != nil {
+		return x.Office
+	}
+	return nil
+}
+
+func (x *Poll) GetDelivery() []*OptionDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+func (x *Poll) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+// OptionDelivery reports whether an option can deliver to the office of a poll, distance being given in metres. has_distance and has_open state whether distance and open are
+// known.
+type OptionDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OptionId    string  `protobuf:"bytes,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	HasDistance bool    `protobuf:"varint,2,opt,name=has_distance,json=hasDistance,proto3" json:"has_distance,omitempty"`
+	Distance    float64 `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	HasOpen     bool    `protobuf:"varint,4,opt,name=has_open,json=hasOpen,proto3" json:"has_open,omitempty"`
+	Open        bool    `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	CanDeliver  bool    `protobuf:"varint,6,opt,name=can_deliver,json=canDeliver,proto3" json:"can_deliver,omitempty"`
+	Reason      string  `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *OptionDelivery) Reset() {
+	*x = OptionDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptionDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionDelivery) ProtoMessage() {}
+
+func (x *OptionDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionDelivery.ProtoReflect.Descriptor instead.
+func (*OptionDelivery) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OptionDelivery) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *OptionDelivery) GetHasDistance() bool {
+	if x != nil {
+		return x.HasDistance
+	}
+	return false
+}
+
+func (x *OptionDelivery) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *OptionDelivery) GetHasOpen() bool {
+	if x != nil {
+		return x.HasOpen
+	}
+	return false
+}
+
+func (x *OptionDelivery) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *OptionDelivery) GetCanDeliver() bool {
+	if x != nil {
+		return x.CanDeliver
+	}
+	return false
+}
+
+func (x *OptionDelivery) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Rules gives the quorum and margin a poll must meet to be decided, along with whether the poll fails or is extended should it close without meeting them.
+type Rules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quorum        int32    `protobuf:"varint,1,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	QuorumPercent int32    `protobuf:"varint,2,opt,name=quorum_percent,json=quorumPercent,proto3" json:"quorum_percent,omitempty"`
+	Invited       []string `protobuf:"bytes,3,rep,name=invited,proto3" json:"invited,omitempty"`
+	Margin        int32    `protobuf:"varint,4,opt,name=margin,proto3" json:"margin,omitempty"`
+	// on_failure is either fail or extend, polls being extended by extend_by minutes up to max_extensions times.
+	OnFailure     string `protobuf:"bytes,5,opt,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	ExtendBy      int32  `protobuf:"varint,6,opt,name=extend_by,json=extendBy,proto3" json:"extend_by,omitempty"`
+	MaxExtensions int32  `protobuf:"varint,7,opt,name=max_extensions,json=maxExtensions,proto3" json:"max_extensions,omitempty"`
+}
+
+func (x *Rules) Reset() {
+	*x = Rules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rules) ProtoMessage() {}
+
+func (x *Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rules.ProtoReflect.Descriptor instead.
+func (*Rules) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Rules) GetQuorum() int32 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
+}
+
+func (x *Rules) GetQuorumPercent() int32 {
+	if x != nil {
+		return x.QuorumPercent
+	}
+	return 0
+}
+
+func (x *Rules) GetInvited() []string {
+	if x != nil {
+		return x.Invited
+	}
+	return nil
+}
+
+func (x *Rules) GetMargin() int32 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *Rules) GetOnFailure() string {
+	if x != nil {
+		return x.OnFailure
+	}
+	return ""
+}
+
+func (x *Rules) GetExtendBy() int32 {
+	if x != nil {
+		return x.ExtendBy
+	}
+	return 0
+}
+
+func (x *Rules) GetMaxExtensions() int32 {
+	if x != nil {
+		return x.MaxExtensions
+	}
+	return 0
+}
+
+// Rights gives the number of votes a participant's vote counts as, along with the number of options they may veto.
+type Rights struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weight int32 `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Vetoes int32 `protobuf:"varint,2,opt,name=vetoes,proto3" json:"vetoes,omitempty"`
+}
+
+func (x *Rights) Reset() {
+	*x = Rights{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rights) ProtoMessage() {}
+
+func (x *Rights) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rights.ProtoReflect.Descriptor instead.
+func (*Rights) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Rights) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Rights) GetVetoes() int32 {
+	if x != nil {
+		return x.Vetoes
+	}
+	return 0
+}
+
+type OptionTally struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OptionId string   `protobuf:"bytes,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Votes    int32    `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	Weighted int32    `protobuf:"varint,3,opt,name=weighted,proto3" json:"weighted,omitempty"`
+	Vetoed   bool     `protobuf:"varint,4,opt,name=vetoed,proto3" json:"vetoed,omitempty"`
+	VetoedBy []string `protobuf:"bytes,5,rep,name=vetoed_by,json=vetoedBy,proto3" json:"vetoed_by,omitempty"`
+}
+
+func (x *OptionTally) Reset() {
+	*x = OptionTally{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptionTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionTally) ProtoMessage() {}
+
+func (x *OptionTally) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionTally.ProtoReflect.Descriptor instead.
+func (*OptionTally) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OptionTally) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *OptionTally) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *OptionTally) GetWeighted() int32 {
+	if x != nil {
+		return x.Weighted
+	}
+	return 0
+}
+
+func (x *OptionTally) GetVetoed() bool {
+	if x != nil {
+		return x.Vetoed
+	}
+	return false
+}
+
+func (x *OptionTally) GetVetoedBy() []string {
+	if x != nil {
+		return x.VetoedBy
+	}
+	return nil
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Option      *Restaurant `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+	SuggestedBy string      `protobuf:"bytes,2,opt,name=suggested_by,json=suggestedBy,proto3" json:"suggested_by,omitempty"`
+	SuggestedAt int64       `protobuf:"varint,3,opt,name=suggested_at,json=suggestedAt,proto3" json:"suggested_at,omitempty"`
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Suggestion) GetOption() *Restaurant {
+	if x != nil {
+		return x.Option
+	}
+	return nil
+}
+
+func (x *Suggestion) GetSuggestedBy() string {
+	if x != nil {
+		return x.SuggestedBy
+	}
+	return ""
+}
+
+func (x *Suggestion) GetSuggestedAt() int64 {
+	if x != nil {
+		return x.SuggestedAt
+	}
+	return 0
+}
+
+type GetPollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetPollRequest) Reset() {
+	*x = GetPollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPollRequest) ProtoMessage() {}
+
+func (x *GetPollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPollRequest.ProtoReflect.Descriptor instead.
+func (*GetPollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPollRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type NewPollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options          []*Restaurant `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	AllowSuggestions bool          `protobuf:"varint,2,opt,name=allow_suggestions,json=allowSuggestions,proto3" json:"allow_suggestions,omitempty"`
+	Visibility       string        `protobuf:"bytes,3,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Rules            *Rules        `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *NewPollRequest) Reset() {
+	*x = NewPollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewPollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewPollRequest) ProtoMessage() {}
+
+func (x *NewPollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewPollRequest.ProtoReflect.Descriptor instead.
+func (*NewPollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NewPollRequest) GetOptions() []*Restaurant {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *NewPollRequest) GetAllowSuggestions() bool {
+	if x != nil {
+		return x.AllowSuggestions
+	}
+	return false
+}
+
+func (x *NewPollRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *NewPollRequest) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type UpdatePollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Poll *Poll `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+}
+
+func (x *UpdatePollRequest) Reset() {
+	*x = UpdatePollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePollRequest) ProtoMessage() {}
+
+func (x *UpdatePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePollRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdatePollRequest) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type DeletePollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeletePollRequest) Reset() {
+	*x = DeletePollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePollRequest) ProtoMessage() {}
+
+func (x *DeletePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePollRequest.ProtoReflect.Descriptor instead.
+func (*DeletePollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeletePollRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePollResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeletePollResponse) Reset() {
+	*x = DeletePollResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePollResponse) ProtoMessage() {}
+
+func (x *DeletePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePollResponse.ProtoReflect.Descriptor instead.
+func (*DeletePollResponse) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{15}
+}
+
+type CastVoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PollId   string `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OptionId string `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	// user defaults to the caller should it be empty.
+	User string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *CastVoteRequest) Reset() {
+	*x = CastVoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CastVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CastVoteRequest) ProtoMessage() {}
+
+func (x *CastVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CastVoteRequest.ProtoReflect.Descriptor instead.
+func (*CastVoteRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CastVoteRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *CastVoteRequest) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *CastVoteRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type WatchPollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WatchPollRequest) Reset() {
+	*x = WatchPollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchPollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPollRequest) ProtoMessage() {}
+
+func (x *WatchPollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPollRequest.ProtoReflect.Descriptor instead.
+func (*WatchPollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchPollRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetRightsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PollId string  `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	User   string  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Rights *Rights `protobuf:"bytes,3,opt,name=rights,proto3" json:"rights,omitempty"`
+}
+
+func (x *SetRightsRequest) Reset() {
+	*x = SetRightsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetRightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRightsRequest) ProtoMessage() {}
+
+func (x *SetRightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRightsRequest.ProtoReflect.Descriptor instead.
+func (*SetRightsRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetRightsRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *SetRightsRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SetRightsRequest) GetRights() *Rights {
+	if x != nil {
+		return x.Rights
+	}
+	return nil
+}
+
+type VetoOptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PollId   string `protobuf:"bytes,1,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OptionId string `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	// withdraw withdraws the caller's veto of the option rather than vetoing it.
+	Withdraw bool `protobuf:"varint,3,opt,name=withdraw,proto3" json:"withdraw,omitempty"`
+}
+
+func (x *VetoOptionRequest) Reset() {
+	*x = VetoOptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_poll_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VetoOptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VetoOptionRequest) ProtoMessage() {}
+
+func (x *VetoOptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VetoOptionRequest.ProtoReflect.Descriptor instead.
+func (*VetoOptionRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VetoOptionRequest) GetPollId() string {
+	if x != nil {
+		return x.PollId
+	}
+	return ""
+}
+
+func (x *VetoOptionRequest) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *VetoOptionRequest) GetWithdraw() bool {
+	if x != nil {
+		return x.Withdraw
+	}
+	return false
+}
+
+var File_poll_proto protoreflect.FileDescriptor
+
+var file_poll_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x61,
+	0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x22, 0xd1, 0x02, 0x0a, 0x0a,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
+	0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x31, 0x0a, 0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x52, 0x05, 0x68, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65,
+	0x12, 0x3d, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x2e, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6e, 0x67, 0x22,
+	0x4e, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x61,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x22,
+	0x52, 0x0a, 0x0e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6c, 0x6f, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x06, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x22, 0xc3, 0x09, 0x0a, 0x04, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x6f, 0x74,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61,
+	0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x2e, 0x56, 0x6f,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x07,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x37, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c,
+	0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x12, 0x37, 0x0a, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c,
+	0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x76, 0x65,
+	0x74, 0x6f, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x2e,
+	0x56, 0x65, 0x74, 0x6f, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x65, 0x74,
+	0x6f, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x52, 0x05,
+	0x74, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x69, 0x63, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x61,
+	0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x08, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x6e,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x1a, 0x4f, 0x0a, 0x0a, 0x56, 0x6f, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61,
+	0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50, 0x0a, 0x0b, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50, 0x0a, 0x0b, 0x56, 0x65, 0x74, 0x6f, 0x65,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77,
+	0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd4, 0x01, 0x0a, 0x0e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x73,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x68, 0x61, 0x73, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f,
+	0x6f, 0x70, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4f,
+	0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x5f, 0x64,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61,
+	0x6e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0xdb, 0x01, 0x0a, 0x05, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
+	0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x38,
+	0x0a, 0x06, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x74, 0x6f, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x76, 0x65, 0x74, 0x6f, 0x65, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x0b, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x74, 0x6f, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x76, 0x65, 0x74, 0x6f, 0x65, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x76, 0x65, 0x74, 0x6f, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x76, 0x65, 0x74, 0x6f, 0x65, 0x64, 0x42, 0x79, 0x22, 0x85, 0x01, 0x0a,
+	0x0a, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x61,
+	0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x52, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xbe, 0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x50, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61,
+	0x75, 0x72, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b,
+	0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x76,
+	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x04,
+	0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52,
+	0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x22, 0x23, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50,
+	0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5b, 0x0a, 0x0f, 0x43, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x22, 0x0a,
+	0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x6e, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x22, 0x65, 0x0a, 0x11, 0x56, 0x65, 0x74, 0x6f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x6c, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x32, 0xb1, 0x04, 0x0a, 0x0b, 0x50, 0x6f, 0x6c,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50,
+	0x6f, 0x6c, 0x6c, 0x12, 0x1d, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70,
+	0x6f, 0x6c, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x3d, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x50, 0x6f,
+	0x6c, 0x6c, 0x12, 0x1d, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x4e, 0x65, 0x77, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c,
+	0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x43, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61,
+	0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x51, 0x0a, 0x0a, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x74, 0x61, 0x6b, 0x65,
+	0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x61,
+	0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f,
+	0x0a, 0x08, 0x43, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x43, 0x61, 0x73, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b,
+	0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x12,
+	0x43, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x1f, 0x2e, 0x74,
+	0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f,
+	0x6c, 0x6c, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x12, 0x1f, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c,
+	0x6c, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x43, 0x0a, 0x0a, 0x56, 0x65, 0x74, 0x6f, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x56, 0x65, 0x74, 0x6f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77,
+	0x61, 0x79, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x42, 0x2e, 0x5a, 0x2c,
+	0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61, 0x79, 0x2f, 0x74, 0x61, 0x6b, 0x65, 0x61, 0x77, 0x61,
+	0x79, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x6f, 0x6c, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_poll_proto_rawDescOnce sync.Once
+	file_poll_proto_rawDescData = file_poll_proto_rawDesc
+)
+
+func file_poll_proto_rawDescGZIP() []byte {
+	file_poll_proto_rawDescOnce.Do(func() {
+		file_poll_proto_rawDescData = protoimpl.X.CompressGZIP(file_poll_proto_rawDescData)
+	})
+	return file_poll_proto_rawDescData
+}
+
+var file_poll_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_poll_proto_goTypes = []any{
+	(*Restaurant)(nil),         // 0: takeaway.poll.Restaurant
+	(*Location)(nil),           // 1: takeaway.poll.Location
+	(*OpeningHours)(nil),       // 2: takeaway.poll.OpeningHours
+	(*HoursException)(nil),     // 3: takeaway.poll.HoursException
+	(*Voters)(nil),             // 4: takeaway.poll.Voters
+	(*Poll)(nil),               // 5: takeaway.poll.Poll
+	(*OptionDelivery)(nil),     // 6: takeaway.poll.OptionDelivery
+	(*Rules)(nil),              // 7: takeaway.poll.Rules
+	(*Rights)(nil),             // 8: takeaway.poll.Rights
+	(*OptionTally)(nil),        // 9: takeaway.poll.OptionTally
+	(*Suggestion)(nil),         // 10: takeaway.poll.Suggestion
+	(*GetPollRequest)(nil),     // 11: takeaway.poll.GetPollRequest
+	(*NewPollRequest)(nil),     // 12: takeaway.poll.NewPollRequest
+	(*UpdatePollRequest)(nil),  // 13: takeaway.poll.UpdatePollRequest
+	(*DeletePollRequest)(nil),  // 14: takeaway.poll.DeletePollRequest
+	(*DeletePollResponse)(nil), // 15: takeaway.poll.DeletePollResponse
+	(*CastVoteRequest)(nil),    // 16: takeaway.poll.CastVoteRequest
+	(*WatchPollRequest)(nil),   // 17: takeaway.poll.WatchPollRequest
+	(*SetRightsRequest)(nil),   // 18: takeaway.poll.SetRightsRequest
+	(*VetoOptionRequest)(nil),  // 19: takeaway.poll.VetoOptionRequest
+	nil,                        // 20: takeaway.poll.Poll.VotesEntry
+	nil,                        // 21: takeaway.poll.Poll.CountsEntry
+	nil,                        // 22: takeaway.poll.Poll.RightsEntry
+	nil,                        // 23: takeaway.poll.Poll.VetoedEntry
+}
+var file_poll_proto_depIdxs = []int32{
+	1,  // 0: takeaway.poll.Restaurant.location:type_name -> takeaway.poll.Location
+	2,  // 1: takeaway.poll.Restaurant.hours:type_name -> takeaway.poll.OpeningHours
+	3,  // 2: takeaway.poll.Restaurant.exceptions:type_name -> takeaway.poll.HoursException
+	0,  // 3: takeaway.poll.Poll.options:type_name -> takeaway.poll.Restaurant
+	20, // 4: takeaway.poll.Poll.votes:type_name -> takeaway.poll.Poll.VotesEntry
+	10, // 5: takeaway.poll.Poll.pending:type_name -> takeaway.poll.Suggestion
+	21, // 6: takeaway.poll.Poll.counts:type_name -> takeaway.poll.Poll.CountsEntry
+	22, // 7: takeaway.poll.Poll.rights:type_name -> takeaway.poll.Poll.RightsEntry
+	23, // 8: takeaway.poll.Poll.vetoed:type_name -> takeaway.poll.Poll.VetoedEntry
+	9,  // 9: takeaway.poll.Poll.tally:type_name -> takeaway.poll.OptionTally
+	7,  // 10: takeaway.poll.Poll.rules:type_name -> takeaway.poll.Rules
+	1,  // 11: takeaway.poll.Poll.office:type_name -> takeaway.poll.Location
+	6,  // 12: takeaway.poll.Poll.delivery:type_name -> takeaway.poll.OptionDelivery
+	0,  // 13: takeaway.poll.Suggestion.option:type_name -> takeaway.poll.Restaurant
+	0,  // 14: takeaway.poll.NewPollRequest.options:type_name -> takeaway.poll.Restaurant
+	7,  // 15: takeaway.poll.NewPollRequest.rules:type_name -> takeaway.poll.Rules
+	5,  // 16: takeaway.poll.UpdatePollRequest.poll:type_name -> takeaway.poll.Poll
+	8,  // 17: takeaway.poll.SetRightsRequest.rights:type_name -> takeaway.poll.Rights
+	4,  // 18: takeaway.poll.Poll.VotesEntry.value:type_name -> takeaway.poll.Voters
+	8,  // 19: takeaway.poll.Poll.RightsEntry.value:type_name -> takeaway.poll.Rights
+	4,  // 20: takeaway.poll.Poll.VetoedEntry.value:type_name -> takeaway.poll.Voters
+	11, // 21: takeaway.poll.PollService.GetPoll:input_type -> takeaway.poll.GetPollRequest
+	12, // 22: takeaway.poll.PollService.NewPoll:input_type -> takeaway.poll.NewPollRequest
+	13, // 23: takeaway.poll.PollService.UpdatePoll:input_type -> takeaway.poll.UpdatePollRequest
+	14, // 24: takeaway.poll.PollService.DeletePoll:input_type -> takeaway.poll.DeletePollRequest
+	16, // 25: takeaway.poll.PollService.CastVote:input_type -> takeaway.poll.CastVoteRequest
+	17, // 26: takeaway.poll.PollService.WatchPoll:input_type -> takeaway.poll.WatchPollRequest
+	18, // 27: takeaway.poll.PollService.SetRights:input_type -> takeaway.poll.SetRightsRequest
+	19, // 28: takeaway.poll.PollService.VetoOption:input_type -> takeaway.poll.VetoOptionRequest
+	5,  // 29: takeaway.poll.PollService.GetPoll:output_type -> takeaway.poll.Poll
+	5,  // 30: takeaway.poll.PollService.NewPoll:output_type -> takeaway.poll.Poll
+	5,  // 31: takeaway.poll.PollService.UpdatePoll:output_type -> takeaway.poll.Poll
+	15, // 32: takeaway.poll.PollService.DeletePoll:output_type -> takeaway.poll.DeletePollResponse
+	5,  // 33: takeaway.poll.PollService.CastVote:output_type -> takeaway.poll.Poll
+	5,  // 34: takeaway.poll.PollService.WatchPoll:output_type -> takeaway.poll.Poll
+	5,  // 35: takeaway.poll.PollService.SetRights:output_type -> takeaway.poll.Poll
+	5,  // 36: takeaway.poll.PollService.VetoOption:output_type -> takeaway.poll.Poll
+	29, // [29:37] is the sub-list for method output_type
+	21, // [21:29] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_poll_proto_init() }
+func file_poll_proto_init() {
+	if File_poll_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_poll_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Restaurant); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*OpeningHours); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*HoursException); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Voters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Poll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*OptionDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Rules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Rights); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*OptionTally); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Suggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*NewPollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdatePollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*DeletePollResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*CastVoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchPollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SetRightsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_poll_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*VetoOptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_poll_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_poll_proto_goTypes,
+		DependencyIndexes: file_poll_proto_depIdxs,
+		MessageInfos:      file_poll_proto_msgTypes,
+	}.Build()
+	File_poll_proto = out.File
+	file_poll_proto_rawDesc = nil
+	file_poll_proto_goTypes = nil
+	file_poll_proto_depIdxs = nil
+}
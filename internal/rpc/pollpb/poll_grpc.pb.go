@@ -0,0 +1,413 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: poll.proto
+
+// The poll service mirrors vote.PollModel for services preferring gRPC over the HTTP API. The workspace and user a call is made by are read from the 'x-workspace' and 'x-user'
+// metadata, matching the X-Workspace and X-User headers of the HTTP API.
+//
+// Regenerate the Go code within this directory using:
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative poll.proto
+
+package pollpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PollService_GetPoll_FullMethodName    = "/takeaway.poll.PollService/GetPoll"
+	PollService_NewPoll_FullMethodName    = "/takeaway.poll.PollService/NewPoll"
+	PollService_UpdatePoll_FullMethodName = "/takeaway.poll.PollService/UpdatePoll"
+	PollService_DeletePoll_FullMethodName = "/takeaway.poll.PollService/DeletePoll"
+	PollService_CastVote_FullMethodName   = "/takeaway.poll.PollService/CastVote"
+	PollService_WatchPoll_FullMethodName  = "/takeaway.poll.PollService/WatchPoll"
+	PollService_SetRights_FullMethodName  = "/takeaway.poll.PollService/SetRights"
+	PollService_VetoOption_FullMethodName = "/takeaway.poll.PollService/VetoOption"
+)
+
+// PollServiceClient is the client API for PollService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PollServiceClient interface {
+	// GetPoll returns the poll with the given ID.
+	GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*Poll, error)
+	// NewPoll creates a poll with the given options, made by the caller.
+	NewPoll(ctx context.Context, in *NewPollRequest, opts ...grpc.CallOption) (*Poll, error)
+	// UpdatePoll replaces the options, votes and closing time of an existing poll.
+	UpdatePoll(ctx context.Context, in *UpdatePollRequest, opts ...grpc.CallOption) (*Poll, error)
+	// DeletePoll deletes the poll with the given ID.
+	DeletePoll(ctx context.Context, in *DeletePollRequest, opts ...grpc.CallOption) (*DeletePollResponse, error)
+	// CastVote records a vote for an option, replacing any earlier vote made by the user.
+	CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*Poll, error)
+	// WatchPoll streams the poll with the given ID each time it changes, until the client cancels the call.
+	WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Poll], error)
+	// SetRights sets the weight and vetoes of a participant, only being allowed for the poll's creator.
+	SetRights(ctx context.Context, in *SetRightsRequest, opts ...grpc.CallOption) (*Poll, error)
+	// VetoOption records the caller vetoing an option, or withdraws the caller's veto.
+	VetoOption(ctx context.Context, in *VetoOptionRequest, opts ...grpc.CallOption) (*Poll, error)
+}
+
+type pollServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPollServiceClient(cc grpc.ClientConnInterface) PollServiceClient {
+	return &pollServiceClient{cc}
+}
+
+func (c *pollServiceClient) GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_GetPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) NewPoll(ctx context.Context, in *NewPollRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_NewPoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) UpdatePoll(ctx context.Context, in *UpdatePollRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_UpdatePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) DeletePoll(ctx context.Context, in *DeletePollRequest, opts ...grpc.CallOption) (*DeletePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePollResponse)
+	err := c.cc.Invoke(ctx, PollService_DeletePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) CastVote(ctx context.Context, in *CastVoteRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_CastVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Poll], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PollService_ServiceDesc.Streams[0], PollService_WatchPoll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPollRequest, Poll]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PollService_WatchPollClient = grpc.ServerStreamingClient[Poll]
+
+func (c *pollServiceClient) SetRights(ctx context.Context, in *SetRightsRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_SetRights_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) VetoOption(ctx context.Context, in *VetoOptionRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_VetoOption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PollServiceServer is the server API for PollService service.
+// All implementations must embed UnimplementedPollServiceServer
+// for forward compatibility.
+type PollServiceServer interface {
+	// GetPoll returns the poll with the given ID.
+	GetPoll(context.Context, *GetPollRequest) (*Poll, error)
+	// NewPoll creates a poll with the given options, made by the caller.
+	NewPoll(context.Context, *NewPollRequest) (*Poll, error)
+	// UpdatePoll replaces the options, votes and closing time of an existing poll.
+	UpdatePoll(context.Context, *UpdatePollRequest) (*Poll, error)
+	// DeletePoll deletes the poll with the given ID.
+	DeletePoll(context.Context, *DeletePollRequest) (*DeletePollResponse, error)
+	// CastVote records a vote for an option, replacing any earlier vote made by the user.
+	CastVote(context.Context, *CastVoteRequest) (*Poll, error)
+	// WatchPoll streams the poll with the given ID each time it changes, until the client cancels the call.
+	WatchPoll(*WatchPollRequest, grpc.ServerStreamingServer[Poll]) error
+	// SetRights sets the weight and vetoes of a participant, only being allowed for the poll's creator.
+	SetRights(context.Context, *SetRightsRequest) (*Poll, error)
+	// VetoOption records the caller vetoing an option, or withdraws the caller's veto.
+	VetoOption(context.Context, *VetoOptionRequest) (*Poll, error)
+	mustEmbedUnimplementedPollServiceServer()
+}
+
+// UnimplementedPollServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPollServiceServer struct{}
+
+func (UnimplementedPollServiceServer) GetPoll(context.Context, *GetPollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoll not implemented")
+}
+func (UnimplementedPollServiceServer) NewPoll(context.Context, *NewPollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewPoll not implemented")
+}
+func (UnimplementedPollServiceServer) UpdatePoll(context.Context, *UpdatePollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePoll not implemented")
+}
+func (UnimplementedPollServiceServer) DeletePoll(context.Context, *DeletePollRequest) (*DeletePollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePoll not implemented")
+}
+func (UnimplementedPollServiceServer) CastVote(context.Context, *CastVoteRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CastVote not implemented")
+}
+func (UnimplementedPollServiceServer) WatchPoll(*WatchPollRequest, grpc.ServerStreamingServer[Poll]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPoll not implemented")
+}
+func (UnimplementedPollServiceServer) SetRights(context.Context, *SetRightsRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRights not implemented")
+}
+func (UnimplementedPollServiceServer) VetoOption(context.Context, *VetoOptionRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VetoOption not implemented")
+}
+func (UnimplementedPollServiceServer) mustEmbedUnimplementedPollServiceServer() {}
+func (UnimplementedPollServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafePollServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PollServiceServer will
+// result in compilation errors.
+type UnsafePollServiceServer interface {
+	mustEmbedUnimplementedPollServiceServer()
+}
+
+func RegisterPollServiceServer(s grpc.ServiceRegistrar, srv PollServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPollServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PollService_ServiceDesc, srv)
+}
+
+func _PollService_GetPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).GetPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_GetPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).GetPoll(ctx, req.(*GetPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_NewPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).NewPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_NewPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).NewPoll(ctx, req.(*NewPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_UpdatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).UpdatePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_UpdatePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).UpdatePoll(ctx, req.(*UpdatePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_DeletePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).DeletePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_DeletePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).DeletePoll(ctx, req.(*DeletePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_CastVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CastVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).CastVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_CastVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).CastVote(ctx, req.(*CastVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_WatchPoll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPollRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PollServiceServer).WatchPoll(m, &grpc.GenericServerStream[WatchPollRequest, Poll]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PollService_WatchPollServer = grpc.ServerStreamingServer[Poll]
+
+func _PollService_SetRights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).SetRights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_SetRights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).SetRights(ctx, req.(*SetRightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_VetoOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VetoOptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).VetoOption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_VetoOption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).VetoOption(ctx, req.(*VetoOptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PollService_ServiceDesc is the grpc.ServiceDesc for PollService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PollService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "takeaway.poll.PollService",
+	HandlerType: (*PollServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPoll",
+			Handler:    _PollService_GetPoll_Handler,
+		},
+		{
+			MethodName: "NewPoll",
+			Handler:    _PollService_NewPoll_Handler,
+		},
+		{
+			MethodName: "UpdatePoll",
+			Handler:    _PollService_UpdatePoll_Handler,
+		},
+		{
+			MethodName: "DeletePoll",
+			Handler:    _PollService_DeletePoll_Handler,
+		},
+		{
+			MethodName: "CastVote",
+			Handler:    _PollService_CastVote_Handler,
+		},
+		{
+			MethodName: "SetRights",
+			Handler:    _PollService_SetRights_Handler,
+		},
+		{
+			MethodName: "VetoOption",
+			Handler:    _PollService_VetoOption_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPoll",
+			Handler:       _PollService_WatchPoll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "poll.proto",
+}
@@ -0,0 +1,144 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayNames maps the abbreviated day names accepted within the day of week field of a cron expression to their numeric value.
+var dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+// Cron represents a parsed cron expression of the form "minute hour day-of-month month day-of-week", e.g. "0 11 * * fri" for every Friday at 11:00. Each field accepts '*', single
+// values, ranges ("1-5"), lists ("mon,wed,fri") and steps ("*/15").
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// ParseCron parses the given cron expression, returning an error should the expression not be valid.
+func ParseCron(expr string) (c *Cron, err error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		err = fmt.Errorf("the cron expression %q must have 5 fields, found %v", expr, len(fields))
+		return
+	}
+
+	c = &Cron{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+
+	if c.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if c.month, err = parseField(fields[3], 1, 12, nil); err != nil {
+		return nil, err
+	}
+	if c.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, err
+	}
+
+	// both 0 and 7 represent Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return
+}
+
+// Next returns the first time after t matching the cron expression, within t's location. A zero time is returned should no matching time exist within the next five years,
+// e.g. for the 31st of February.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows the standard cron behaviour where, should both the day of month and day of week be restricted, a day matching either field is accepted.
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseField(field string, min int, max int, names map[string]int) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			if lo, err = parseValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseValue(bounds[1], names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("cron field %q is outside of the range %v-%v", field, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return
+}
+
+func parseValue(s string, names map[string]int) (v int, err error) {
+	if n, found := names[strings.ToLower(s)]; found {
+		return n, nil
+	}
+
+	v, err = strconv.Atoi(s)
+	if err != nil {
+		err = fmt.Errorf("invalid value %q within cron expression", s)
+	}
+	return
+}
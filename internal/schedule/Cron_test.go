@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// friday is a Friday at 09:30.
+var friday = time.Date(2018, time.November, 2, 9, 30, 0, 0, time.UTC)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{"", "0 11 * *", "60 11 * * *", "0 24 * * *", "0 11 * * funday", "5-1 * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Logf("%q was parsed without error", expr)
+			t.Fail()
+		}
+	}
+}
+
+func TestNextSameDay(t *testing.T) {
+	c, _ := ParseCron("0 11 * * fri")
+
+	if next := c.Next(friday); !next.Equal(time.Date(2018, time.November, 2, 11, 0, 0, 0, time.UTC)) {
+		t.Logf("Next run: %s", next)
+		t.Fail()
+	}
+}
+
+func TestNextFollowingWeek(t *testing.T) {
+	c, _ := ParseCron("0 11 * * 5")
+
+	if next := c.Next(friday.Add(2 * time.Hour)); !next.Equal(time.Date(2018, time.November, 9, 11, 0, 0, 0, time.UTC)) {
+		t.Logf("Next run: %s", next)
+		t.Fail()
+	}
+}
+
+func TestNextStep(t *testing.T) {
+	c, _ := ParseCron("*/15 9-17 * * mon-fri")
+
+	if next := c.Next(friday); !next.Equal(time.Date(2018, time.November, 2, 9, 45, 0, 0, time.UTC)) {
+		t.Logf("Next run: %s", next)
+		t.Fail()
+	}
+}
+
+func TestNextSunday(t *testing.T) {
+	c, _ := ParseCron("0 12 * * 7")
+
+	if next := c.Next(friday); next.Weekday() != time.Sunday {
+		t.Logf("Next run: %s", next)
+		t.Fail()
+	}
+}
+
+func TestNextImpossible(t *testing.T) {
+	c, _ := ParseCron("0 12 31 2 *")
+
+	if next := c.Next(friday); !next.IsZero() {
+		t.Logf("Next run: %s", next)
+		t.Fail()
+	}
+}
@@ -0,0 +1,118 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockTemplateModel provides an in memory implementation of the TemplateModel interface.
+type MockTemplateModel struct {
+	mutex     sync.Mutex
+	templates []*Template
+	nextID    int
+}
+
+// GetTemplate returns the stored template with the given ID within the given workspace, returning an error along with a 'NotFound' status should no such template exist.
+func (tm *MockTemplateModel) GetTemplate(workspace string, id string) (t *Template, status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	i := tm.indexOf(workspace, id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	t = tm.templates[i]
+	return
+}
+
+// GetTemplates returns all stored templates within the given workspace.
+func (tm *MockTemplateModel) GetTemplates(workspace string) (templates []*Template, status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	templates = make([]*Template, 0)
+	for _, t := range tm.templates {
+		if t.Workspace == workspace {
+			templates = append(templates, t)
+		}
+	}
+	return
+}
+
+// GetDueTemplates returns all stored unpaused templates whose next run is at or before the given time.
+func (tm *MockTemplateModel) GetDueTemplates(now time.Time) (templates []*Template, status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	templates = make([]*Template, 0)
+	for _, t := range tm.templates {
+		if !t.Paused && !t.NextRun.IsZero() && !t.NextRun.After(now) {
+			templates = append(templates, t)
+		}
+	}
+	return
+}
+
+// NewTemplate stores the given template, assigning it a sequential ID.
+func (tm *MockTemplateModel) NewTemplate(t *Template) (status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	tm.nextID++
+	t.ID = "template" + strconv.Itoa(tm.nextID)
+	tm.templates = append(tm.templates, t)
+	return
+}
+
+// UpdateTemplate replaces the stored template with the same ID as the given template, returning an error along with a 'NotFound' status should no such template exist.
+func (tm *MockTemplateModel) UpdateTemplate(t *Template) (status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	i := tm.indexOf(t.Workspace, t.ID)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", t.ID)
+		status = vote.NotFound
+		return
+	}
+
+	tm.templates[i] = t
+	return
+}
+
+// DeleteTemplate removes the stored template with the given ID from the given workspace, returning an error along with a 'NotFound' status should no such template exist.
+func (tm *MockTemplateModel) DeleteTemplate(workspace string, id string) (status vote.Status, err error) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	i := tm.indexOf(workspace, id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	tm.templates = append(tm.templates[:i], tm.templates[i+1:]...)
+	return
+}
+
+// Close has been added to ensure the mock meets the TemplateModel interface, it does not need to actually complete anything.
+func (tm *MockTemplateModel) Close() (err error) {
+	return
+}
+
+func (tm *MockTemplateModel) indexOf(workspace string, id string) int {
+	for i, t := range tm.templates {
+		if t.ID == id && t.Workspace == workspace {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package schedule
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoTemplateModel provides a mongo based implementation to the TemplateModel interface.
+type MongoTemplateModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// GetTemplate gets the template with the specified id within the given workspace from the mongo database.
+func (tm *MongoTemplateModel) GetTemplate(workspace string, id string) (t *Template, status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := Template{}
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Find(db.Scope(workspace, bson.M{"id": id})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	t = &data
+	return
+}
+
+// GetTemplates returns all templates stored within the mongo database for the given workspace.
+func (tm *MongoTemplateModel) GetTemplates(workspace string) (templates []*Template, status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	templates = make([]*Template, 0)
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Find(db.Scope(workspace, nil)).All(&templates)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// GetDueTemplates returns all unpaused templates stored within the mongo database whose next run is at or before the given time.
+func (tm *MongoTemplateModel) GetDueTemplates(now time.Time) (templates []*Template, status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	templates = make([]*Template, 0)
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Find(bson.M{"paused": false, "nextRun": bson.M{"$gt": time.Time{}, "$lte": now}}).All(&templates)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// NewTemplate stores the given template within the mongo database, assigning it a new ID.
+func (tm *MongoTemplateModel) NewTemplate(t *Template) (status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	t.ID = bson.NewObjectId().Hex()
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Insert(t)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// UpdateTemplate replaces the template stored within the mongo database with the same ID and workspace as the given template.
+func (tm *MongoTemplateModel) UpdateTemplate(t *Template) (status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Update(db.Scope(t.Workspace, bson.M{"id": t.ID}), t)
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// DeleteTemplate removes the template with the given ID within the given workspace from the mongo database.
+func (tm *MongoTemplateModel) DeleteTemplate(workspace string, id string) (status vote.Status, err error) {
+	err = tm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := tm.session.DB(tm.DBName).C("templates")
+	err = c.Remove(db.Scope(workspace, bson.M{"id": id}))
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (tm *MongoTemplateModel) Close() (err error) {
+	if tm.session != nil {
+		tm.session.Close()
+	}
+	return
+}
+
+func (tm *MongoTemplateModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&tm.session, tm.URL, tm.Username, tm.Password)
+}
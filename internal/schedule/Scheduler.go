@@ -0,0 +1,81 @@
+package schedule
+
+import (
+	"log"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Scheduler periodically creates polls from all templates that are due, using the given models to access templates and the polls previously created from them. Polls are
+// created through the vote package, which must have been initialised, so are recorded and published as any other new poll.
+type Scheduler struct {
+	Templates TemplateModel
+	Polls     vote.PollModel
+	// Interval states how often the scheduler checks for due templates, defaulting to once a minute.
+	Interval time.Duration
+}
+
+// Run starts the scheduler's loop, creating polls for due templates until the stop channel is closed. Note this method will block so should be ran as a separate goroutine.
+func (s *Scheduler) Run(stop <-chan struct{}) {
+	interval := s.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.RunDue(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// RunDue creates a poll for every unpaused template whose next run is at or before now, scheduling each template's following run afterwards. Should the server have been stopped
+// over several scheduled runs, only a single poll is created for the template.
+func (s *Scheduler) RunDue(now time.Time) {
+	templates, _, err := s.Templates.GetDueTemplates(now)
+	if err != nil {
+		log.Printf("Scheduler: could not retrieve templates due to: %s\n", err)
+		return
+	}
+
+	for _, t := range templates {
+		poll, err := s.CreatePoll(t)
+		if err != nil {
+			log.Printf("Scheduler: could not create poll for template %s due to: %s\n", t.ID, err)
+		} else {
+			log.Printf("Scheduler: created poll %s from template %s\n", poll.ID, t.ID)
+			t.AddPoll(poll.ID)
+		}
+
+		t.NextRun, err = t.Next(now)
+		if err != nil {
+			log.Printf("Scheduler: could not schedule template %s due to: %s\n", t.ID, err)
+		}
+
+		_, err = s.Templates.UpdateTemplate(t)
+		if err != nil {
+			log.Printf("Scheduler: could not update template %s due to: %s\n", t.ID, err)
+		}
+	}
+}
+
+// CreatePoll creates a new poll from the given template, setting the poll's closing time should the template specify one.
+func (s *Scheduler) CreatePoll(t *Template) (poll *vote.Poll, err error) {
+	recent := make([]*vote.Poll, 0, len(t.PollIDs))
+	for _, id := range t.PollIDs {
+		p, _, err := s.Polls.GetPoll(t.Workspace, id)
+		if err == nil {
+			recent = append(recent, p)
+		}
+	}
+
+	poll, _, err = vote.CreatePoll(t.Workspace, "", t.PollOptions(recent), &vote.PollSettings{CloseAfter: time.Duration(t.CloseAfter) * time.Minute})
+	return
+}
@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+func TestCreatePoll(t *testing.T) {
+	polls := &vote.MockPollModel{}
+	events := &vote.MockEventModel{}
+	vote.Init(&vote.Container{Model: polls, Events: events})
+
+	s := &Scheduler{Templates: &MockTemplateModel{}, Polls: polls}
+	template := &Template{ID: "t1", CloseAfter: 30, Options: []*restaurant.Building{{ID: "r1", Name: "Restaurant 1"}}}
+
+	poll, err := s.CreatePoll(template)
+	if err != nil {
+		t.Fatalf("Could not create poll: %s", err)
+	}
+	if !poll.ClosesAt.Equal(poll.CreatedAt.Add(30 * time.Minute)) {
+		t.Logf("Expected the poll to close 30 minutes after its creation, got %s", poll.ClosesAt)
+		t.Fail()
+	}
+
+	history, _, _ := events.GetEvents(poll.ID)
+	if len(history) != 1 || history[0].Type != vote.PollCreated || history[0].Snapshot == nil {
+		t.Logf("Expected the poll's creation to be recorded, got %v", history)
+		t.Fail()
+	}
+}
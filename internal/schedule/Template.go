@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// recentPolls is the number of polls created from a template that are remembered, both for choosing rotated options and for listing the template's history.
+const recentPolls = 5
+
+// Template represents a poll that should be created automatically on a recurring schedule.
+type Template struct {
+	ID        string `json:"id" bson:"id"`
+	Workspace string `json:"workspace" bson:"workspace"`
+	Name      string `json:"name" bson:"name"`
+	// Schedule is a cron expression stating when polls should be created, e.g. "0 11 * * fri" for every Friday at 11:00.
+	Schedule string `json:"schedule" bson:"schedule"`
+	// CloseAfter is the number of minutes after creation that a poll created from the template closes, 0 stating created polls have no closing time.
+	CloseAfter int `json:"closeAfter" bson:"closeAfter"`
+	// Options are the restaurants included within every poll created from the template.
+	Options []*restaurant.Building `json:"options" bson:"options"`
+	// Rotation are restaurants that are rotated into created polls, RotateCount of which are added to each poll in favour of those that have not won recently.
+	Rotation    []*restaurant.Building `json:"rotation" bson:"rotation"`
+	RotateCount int                    `json:"rotateCount" bson:"rotateCount"`
+	Paused      bool                   `json:"paused" bson:"paused"`
+	NextRun     time.Time              `json:"nextRun" bson:"nextRun"`
+	// PollIDs are the IDs of the most recent polls created from the template, oldest first.
+	PollIDs []string `json:"pollIds" bson:"pollIds"`
+}
+
+// Next returns the first time after the given time the template is due to create a poll, returning an error should the template's schedule not be valid.
+func (t *Template) Next(after time.Time) (next time.Time, err error) {
+	c, err := ParseCron(t.Schedule)
+	if err != nil {
+		return
+	}
+
+	next = c.Next(after)
+	return
+}
+
+// PollOptions returns the options a poll created from the template should have, given the most recent polls created from the template. Rotated options are picked in favour of
+// restaurants that have gone the longest without winning, with ties being resolved by the order of the template's rotation.
+func (t *Template) PollOptions(recent []*vote.Poll) (options []*restaurant.Building) {
+	options = make([]*restaurant.Building, 0, len(t.Options)+t.RotateCount)
+	included := make(map[string]bool)
+	for _, opt := range t.Options {
+		o := *opt
+		options = append(options, &o)
+		included[opt.ID] = true
+	}
+
+	// lastWon records the index of the most recent poll each restaurant won, restaurants that have not won recently being absent.
+	lastWon := make(map[string]int)
+	for i, p := range recent {
+		if w := p.Winner(); w != nil {
+			lastWon[w.ID] = i + 1
+		}
+	}
+
+	candidates := make([]*restaurant.Building, 0, len(t.Rotation))
+	for _, opt := range t.Rotation {
+		if !included[opt.ID] {
+			candidates = append(candidates, opt)
+		}
+	}
+
+	for n := 0; n < t.RotateCount && len(candidates) > 0; n++ {
+		best := 0
+		for i, c := range candidates {
+			if lastWon[c.ID] < lastWon[candidates[best].ID] {
+				best = i
+			}
+		}
+
+		o := *candidates[best]
+		options = append(options, &o)
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+
+	return
+}
+
+// AddPoll records the given poll ID as the most recent poll created from the template, forgetting the oldest poll should more than recentPolls be recorded.
+func (t *Template) AddPoll(id string) {
+	t.PollIDs = append(t.PollIDs, id)
+	if len(t.PollIDs) > recentPolls {
+		t.PollIDs = t.PollIDs[len(t.PollIDs)-recentPolls:]
+	}
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var instance *Container
+
+// TemplateModel defines a contract for how the system should interact with the database for accessing poll templates. As with polls, every template belongs to a workspace, the
+// default workspace being represented by an empty string.
+type TemplateModel interface {
+	// GetTemplate allows for a singular template within the given workspace to be accessed using its ID. Should any issue occur while attempting to access the template an error will
+	// be returned along with a status detailing the issue.
+	GetTemplate(workspace string, id string) (*Template, vote.Status, error)
+	// GetTemplates returns all templates within the given workspace.
+	GetTemplates(workspace string) ([]*Template, vote.Status, error)
+	// GetDueTemplates returns all unpaused templates, across every workspace, whose next run is at or before the given time.
+	GetDueTemplates(now time.Time) ([]*Template, vote.Status, error)
+	// NewTemplate stores the given template within its workspace, assigning it a new ID. Any errors that occur while attempting to store the template will be returned along with a
+	// status.
+	NewTemplate(t *Template) (vote.Status, error)
+	// UpdateTemplate replaces the stored template with the same ID and workspace as the given template. A status is returned detailing the status of the update action.
+	UpdateTemplate(t *Template) (vote.Status, error)
+	// DeleteTemplate attempts to delete the template with the given ID from the given workspace.
+	DeleteTemplate(workspace string, id string) (vote.Status, error)
+	// Close allows for a TemplateModel connection to be closed.
+	Close() error
+}
+
+// Container provides access to injected implementation of TemplateModel for the application.
+type Container struct {
+	Model TemplateModel `inject:""`
+}
+
+// Init allows the schedule package to be initialised with the Container c.
+func Init(c *Container) {
+	instance = c
+}
@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"testing"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var (
+	pizza  = &restaurant.Building{ID: "pizza", Name: "Pizza"}
+	curry  = &restaurant.Building{ID: "curry", Name: "Curry"}
+	sushi  = &restaurant.Building{ID: "sushi", Name: "Sushi"}
+	burger = &restaurant.Building{ID: "burger", Name: "Burger"}
+)
+
+func wonBy(opt *restaurant.Building) *vote.Poll {
+	return &vote.Poll{
+		Options: []*restaurant.Building{opt},
+		Votes:   map[string][]string{opt.ID: {"Jack"}},
+	}
+}
+
+func TestPollOptionsRotation(t *testing.T) {
+	tmpl := &Template{
+		Options:     []*restaurant.Building{pizza},
+		Rotation:    []*restaurant.Building{curry, sushi, burger},
+		RotateCount: 2,
+	}
+
+	opts := tmpl.PollOptions([]*vote.Poll{wonBy(burger), wonBy(curry)})
+
+	if len(opts) != 3 || opts[0].ID != "pizza" || opts[1].ID != "sushi" || opts[2].ID != "burger" {
+		for _, o := range opts {
+			t.Logf("Option: %s", o.ID)
+		}
+		t.Fail()
+	}
+}
+
+func TestPollOptionsSkipsShortlisted(t *testing.T) {
+	tmpl := &Template{
+		Options:     []*restaurant.Building{pizza},
+		Rotation:    []*restaurant.Building{pizza, curry},
+		RotateCount: 2,
+	}
+
+	opts := tmpl.PollOptions(nil)
+
+	if len(opts) != 2 || opts[1].ID != "curry" {
+		t.Fail()
+	}
+}
+
+func TestAddPoll(t *testing.T) {
+	tmpl := &Template{}
+	for _, id := range []string{"1", "2", "3", "4", "5", "6"} {
+		tmpl.AddPoll(id)
+	}
+
+	if len(tmpl.PollIDs) != recentPolls || tmpl.PollIDs[0] != "2" || tmpl.PollIDs[recentPolls-1] != "6" {
+		t.Logf("Poll IDs: %v", tmpl.PollIDs)
+		t.Fail()
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	r1 := &restaurant.Building{ID: "r1", Name: "Restaurant 1"}
+	cases := []struct {
+		template *Template
+		valid    bool
+	}{
+		{&Template{Schedule: "0 11 * * fri", Options: []*restaurant.Building{r1}}, true},
+		{&Template{Schedule: "0 11 * * fri", Rotation: []*restaurant.Building{r1}, RotateCount: 1}, true},
+		{&Template{Schedule: "0 11 * * fri", Options: []*restaurant.Building{r1, {ID: "r1", Name: "Restaurant 2"}}}, false},
+		{&Template{Schedule: "0 11 * * fri", Options: []*restaurant.Building{r1}, Rotation: []*restaurant.Building{{ID: "r2"}}}, false},
+		{&Template{Schedule: "0 11 * * fri"}, false},
+	}
+
+	for i, c := range cases {
+		err := validate(c.template)
+		if (err == nil) != c.valid {
+			t.Logf("Expected case %v to be valid: %v, got error %v", i, c.valid, err)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetTemplate provides a http handler for accessing a specified template.
+func GetTemplate(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	t, status, err := instance.Model.GetTemplate(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find template %s, returning not found status.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find template due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+// GetTemplates provides a http handler for listing all templates within the caller's workspace.
+func GetTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, _, err := instance.Model.GetTemplates(caller.Workspace(r.Context()))
+	if err != nil {
+		log.Printf("Could not retrieve templates due to: %s\n", err.Error())
+		http.Error(w, "Could not retrieve templates", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, templates)
+}
+
+// NewTemplate provides a http handler for creating a new template, scheduling its first run from the current time.
+func NewTemplate(w http.ResponseWriter, r *http.Request) {
+	t, ok := readTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	t.PollIDs = nil
+	status, err := instance.Model.NewTemplate(t)
+	if err != nil {
+		log.Printf("Could not create a new template due to: %s\n", err.Error())
+		if status == vote.Invalid {
+			http.Error(w, "Supplied template invalid", http.StatusBadRequest)
+		} else {
+			http.Error(w, "Template could not be created", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("Created template with id %v, first run at %s\n", t.ID, t.NextRun)
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// UpdateTemplate provides a http handler for updating a template within the system, rescheduling its next run from the current time.
+func UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	t, ok := readTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	// the polls created from a template are recorded by the scheduler so are kept from the stored template.
+	existing, status, err := instance.Model.GetTemplate(t.Workspace, t.ID)
+	if err == nil {
+		t.PollIDs = existing.PollIDs
+		status, err = instance.Model.UpdateTemplate(t)
+	}
+
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find a template with specified ID = %s\n", t.ID)
+			http.Error(w, "Could not find template with specified ID", http.StatusNotFound)
+			return
+		}
+
+		log.Printf("Could not update template with id %s due to internal model error %s\n", t.ID, err.Error())
+		http.Error(w, "Could not update template", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("successfully updated template with id %s\n", t.ID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DeleteTemplate provides a http handler for removing a template from the system. Polls already created from the template are unaffected.
+func DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status, err := instance.Model.DeleteTemplate(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find the ID %s\n", id)
+			http.Error(w, "ID not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+// readTemplate reads and validates a template from the body of the given request, scheduling the template's next run. Should the template not be valid an error response is
+// written and false returned.
+func readTemplate(w http.ResponseWriter, r *http.Request) (t *Template, ok bool) {
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	// if request body could not be parsed, return an internal server error to the client.
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	t = &Template{}
+	err = json.Unmarshal(b, t)
+	if err != nil {
+		log.Printf("Could not parse %s into a template", b)
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	err = validate(t)
+	if err != nil {
+		log.Printf("Supplied template invalid: %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// templates can only be created and updated within the caller's workspace.
+	t.Workspace = caller.Workspace(r.Context())
+	t.NextRun, _ = t.Next(time.Now())
+	ok = true
+	return
+}
+
+// validate checks the given template can create valid polls: its schedule must be a valid cron expression, its options and rotation must each pass vote.ValidateOptions and the
+// polls it creates must have between vote.MinOptions and vote.MaxOptions options.
+func validate(t *Template) error {
+	if _, err := ParseCron(t.Schedule); err != nil {
+		return err
+	}
+	if t.CloseAfter < 0 {
+		return fmt.Errorf("closeAfter must not be negative")
+	}
+	if len(t.Options) > 0 {
+		if err := vote.ValidateOptions(t.Options); err != nil {
+			return err
+		}
+	}
+	if len(t.Rotation) > 0 {
+		if err := vote.ValidateOptions(t.Rotation); err != nil {
+			return fmt.Errorf("rotation: %s", err)
+		}
+	}
+	if t.RotateCount < 0 || t.RotateCount > len(t.Rotation) {
+		return fmt.Errorf("rotateCount must be between 0 and the number of restaurants in the rotation")
+	}
+	if n := len(t.Options) + t.RotateCount; n < vote.MinOptions || n > vote.MaxOptions {
+		return fmt.Errorf("polls created from the template must have between %d and %d options, got %d", vote.MinOptions, vote.MaxOptions, n)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(data)
+}
@@ -0,0 +1,184 @@
+package stats
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var instance *Analyser
+
+// Aggregator is implemented by models able to run mongo aggregation pipelines against the collection they store, allowing statistics to be calculated by the database rather
+// than in memory.
+type Aggregator interface {
+	Aggregate(pipeline interface{}, result interface{}) (vote.Status, error)
+}
+
+// Analyser produces reports using the given models, using aggregation pipelines where the models support them and falling back to calculating the report in memory otherwise.
+// Reports only include the ratings of restaurants should the Analyser be given a ReviewModel.
+type Analyser struct {
+	Polls   vote.PollModel
+	Events  vote.EventModel
+	Reviews vote.ReviewModel
+}
+
+// Init allows the stats package to be initialised with the Analyser a.
+func Init(a *Analyser) {
+	instance = a
+}
+
+// Report returns a report aggregating all polls within the given workspace created since the given time, including the given users within the report's participation along with
+// the ratings of restaurants reviewed since said time. As with Compute, the voters of private polls are left out of the participation and open secret polls out of the votes and
+// wins.
+func (a *Analyser) Report(workspace string, since time.Time, users []string) (r *Report, status vote.Status, err error) {
+	now := time.Now()
+	if agg, ok := a.Polls.(Aggregator); ok {
+		r, status, err = aggregatePolls(agg, workspace, since, now)
+		if err == nil {
+			// winners depend upon the weights and vetoes of each poll, so are decided by vote.Poll.Winner rather than within a pipeline.
+			var polls []*vote.Poll
+			polls, status, err = a.Polls.GetPolls(workspace, since)
+			r.Wins = countWins(polls, now)
+		}
+	} else {
+		var polls []*vote.Poll
+		polls, status, err = a.Polls.GetPolls(workspace, since)
+		if err == nil {
+			r = compute(polls, since, now)
+			r.AverageTimeToDecision, status, err = a.timeToDecision(polls)
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	if agg, ok := a.Events.(Aggregator); ok {
+		r.AverageTimeToDecision, status, err = aggregateTimeToDecision(agg, workspace, since)
+		if err != nil {
+			return
+		}
+	}
+
+	if a.Reviews != nil {
+		var reviews []*vote.Review
+		reviews, status, err = a.Reviews.GetReviews(workspace, since)
+		if err != nil {
+			return
+		}
+		r.Ratings = vote.Ratings(reviews)
+	}
+
+	r.finalise(users)
+	return
+}
+
+// timeToDecision calculates the average time to decision of the given polls in memory using each poll's event log.
+func (a *Analyser) timeToDecision(polls []*vote.Poll) (avg float64, status vote.Status, err error) {
+	if a.Events == nil {
+		return
+	}
+
+	n := 0
+	var total time.Duration
+	for _, p := range polls {
+		var events []*vote.Event
+		events, status, err = a.Events.GetEvents(p.ID)
+		if err != nil {
+			return
+		}
+
+		if d, ok := TimeToDecision(events); ok {
+			total += d
+			n++
+		}
+	}
+
+	if n > 0 {
+		avg = total.Seconds() / float64(n)
+	}
+	return
+}
+
+// aggregatePolls calculates the poll, vote and participation totals of a report at the given time using aggregation pipelines, leaving the report's wins to be counted by the
+// caller.
+func aggregatePolls(agg Aggregator, workspace string, since time.Time, now time.Time) (r *Report, status vote.Status, err error) {
+	r = &Report{Since: since}
+	query := db.Scope(workspace, nil)
+	if !since.IsZero() {
+		query["createdAt"] = bson.M{"$gte": since}
+	}
+	match := bson.M{"$match": query}
+
+	// secret polls hide their votes until they close, a missing or zero closing time leaving the poll open.
+	openSecret := bson.M{"$and": []interface{}{
+		bson.M{"$eq": []interface{}{"$visibility", vote.Secret}},
+		bson.M{"$or": []interface{}{
+			bson.M{"$lte": []interface{}{"$closesAt", time.Time{}}},
+			bson.M{"$gt": []interface{}{"$closesAt", now}},
+		}},
+	}}
+
+	totals := []struct {
+		Polls int `bson:"polls"`
+		Votes int `bson:"votes"`
+	}{}
+	status, err = agg.Aggregate([]bson.M{
+		match,
+		{"$project": bson.M{"hidden": openSecret, "votes": bson.M{"$objectToArray": bson.M{"$ifNull": []interface{}{"$votes", bson.M{}}}}}},
+		{"$project": bson.M{"votes": bson.M{"$cond": []interface{}{"$hidden", 0, bson.M{"$sum": bson.M{"$map": bson.M{
+			"input": "$votes",
+			"as":    "v",
+			"in":    bson.M{"$size": bson.M{"$ifNull": []interface{}{"$$v.v", []interface{}{}}}},
+		}}}}}}},
+		{"$group": bson.M{"_id": nil, "polls": bson.M{"$sum": 1}, "votes": bson.M{"$sum": "$votes"}}},
+	}, &totals)
+	if err != nil {
+		return
+	}
+	if len(totals) > 0 {
+		r.Polls = totals[0].Polls
+		r.Votes = totals[0].Votes
+	}
+
+	r.Participation = make([]*UserParticipation, 0)
+	// the voters of private polls are never revealed.
+	status, err = agg.Aggregate([]bson.M{
+		match,
+		{"$match": bson.M{"visibility": bson.M{"$nin": []vote.Visibility{vote.Anonymous, vote.Secret}}}},
+		{"$project": bson.M{"id": 1, "votes": bson.M{"$objectToArray": bson.M{"$ifNull": []interface{}{"$votes", bson.M{}}}}}},
+		{"$unwind": "$votes"},
+		{"$unwind": "$votes.v"},
+		{"$group": bson.M{"_id": "$votes.v", "polls": bson.M{"$addToSet": "$id"}}},
+		{"$project": bson.M{"polls": bson.M{"$size": "$polls"}}},
+	}, &r.Participation)
+	return
+}
+
+// aggregateTimeToDecision calculates the average time to decision of polls within the given workspace created since the given time using an aggregation pipeline against the event
+// log.
+func aggregateTimeToDecision(agg Aggregator, workspace string, since time.Time) (avg float64, status vote.Status, err error) {
+	result := []struct {
+		Average float64 `bson:"average"`
+	}{}
+
+	status, err = agg.Aggregate([]bson.M{
+		{"$match": db.Scope(workspace, bson.M{"type": bson.M{"$in": []vote.EventType{vote.PollCreated, vote.VoteCast, vote.UserRemoved}}})},
+		{"$group": bson.M{
+			"_id":     "$pollId",
+			"created": bson.M{"$min": bson.M{"$cond": []interface{}{bson.M{"$eq": []interface{}{"$type", vote.PollCreated}}, "$time", nil}}},
+			"decided": bson.M{"$max": bson.M{"$cond": []interface{}{bson.M{"$ne": []interface{}{"$type", vote.PollCreated}}, "$time", nil}}},
+		}},
+		{"$match": bson.M{"created": bson.M{"$gte": since}, "decided": bson.M{"$ne": nil}}},
+		{"$group": bson.M{"_id": nil, "average": bson.M{"$avg": bson.M{"$subtract": []interface{}{"$decided", "$created"}}}}},
+	}, &result)
+
+	// durations are calculated by mongo in milliseconds.
+	if err == nil && len(result) > 0 {
+		avg = result[0].Average / 1000
+	}
+	return
+}
@@ -0,0 +1,163 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Report represents statistics aggregated across all polls created since a given time.
+type Report struct {
+	Since        time.Time `json:"since"`
+	Polls        int       `json:"polls"`
+	Votes        int       `json:"votes"`
+	AverageVotes float64   `json:"averageVotes"`
+	// AverageTimeToDecision is the mean number of seconds between a poll being created and the last vote being cast or removed within it.
+	AverageTimeToDecision float64              `json:"averageTimeToDecision"`
+	Wins                  []*RestaurantWins    `json:"wins"`
+	Participation         []*UserParticipation `json:"participation"`
+	// Ratings aggregates the reviews left for each restaurant since the report's start, ordered from the best rated restaurant.
+	Ratings []*vote.Rating `json:"ratings"`
+}
+
+// RestaurantWins represents the number of polls a restaurant has won.
+type RestaurantWins struct {
+	ID   string `json:"id" bson:"_id"`
+	Name string `json:"name" bson:"name"`
+	Wins int    `json:"wins" bson:"wins"`
+}
+
+// UserParticipation represents the number of polls a user has voted in, along with the proportion of all polls this represents.
+type UserParticipation struct {
+	User  string  `json:"user" bson:"_id"`
+	Polls int     `json:"polls" bson:"polls"`
+	Rate  float64 `json:"rate" bson:"-"`
+}
+
+// Compute aggregates the given polls into a report in memory at the given time, providing a fallback for PollModels that do not support aggregation. Users given are included
+// within the report's participation even should they have never voted. Polls are seen as by a user who has not voted, so the voters of private polls are left out of the
+// participation and secret polls which are still open are left out of the votes and wins.
+func Compute(polls []*vote.Poll, since time.Time, users []string, now time.Time) (r *Report) {
+	r = compute(polls, since, now)
+	r.finalise(users)
+	return
+}
+
+// compute totals the given polls into a report in memory, leaving the report to be finalised by the caller.
+func compute(polls []*vote.Poll, since time.Time, now time.Time) (r *Report) {
+	r = &Report{Since: since, Polls: len(polls)}
+
+	r.Wins = countWins(polls, now)
+	participation := make(map[string]*UserParticipation)
+
+	for _, p := range polls {
+		view := p.ViewFor("", now)
+		// views of private polls hold no voters, only counting the votes cast for each option.
+		voted := make(map[string]bool)
+		for _, voters := range view.Votes {
+			r.Votes += len(voters)
+			for _, u := range voters {
+				voted[u] = true
+			}
+		}
+		for _, n := range view.Counts {
+			r.Votes += n
+		}
+
+		for u := range voted {
+			if participation[u] == nil {
+				participation[u] = &UserParticipation{User: u}
+			}
+			participation[u].Polls++
+		}
+	}
+
+	r.Participation = make([]*UserParticipation, 0, len(participation))
+	for _, p := range participation {
+		r.Participation = append(r.Participation, p)
+	}
+	return
+}
+
+// countWins returns the number of the given polls each restaurant has won, as decided by vote.Poll.Winner. Polls are seen as by a user who has not voted at the given time, so
+// secret polls which are still open have no winner.
+func countWins(polls []*vote.Poll, now time.Time) []*RestaurantWins {
+	wins := make(map[string]*RestaurantWins)
+	for _, p := range polls {
+		if w := p.ViewFor("", now).Winner(); w != nil {
+			if wins[w.ID] == nil {
+				wins[w.ID] = &RestaurantWins{ID: w.ID, Name: w.Name}
+			}
+			wins[w.ID].Wins++
+		}
+	}
+
+	counted := make([]*RestaurantWins, 0, len(wins))
+	for _, w := range wins {
+		counted = append(counted, w)
+	}
+	return counted
+}
+
+// finalise calculates the report's derived values, ensures the report lists its ratings even should there be none, adds any given users who have not voted and sorts the report's restaurants by wins and users by participation rate.
+func (r *Report) finalise(users []string) {
+	if r.Polls > 0 {
+		r.AverageVotes = float64(r.Votes) / float64(r.Polls)
+	}
+	if r.Ratings == nil {
+		r.Ratings = make([]*vote.Rating, 0)
+	}
+
+	known := make(map[string]bool)
+	for _, p := range r.Participation {
+		known[p.User] = true
+	}
+	for _, u := range users {
+		if !known[u] {
+			r.Participation = append(r.Participation, &UserParticipation{User: u})
+			known[u] = true
+		}
+	}
+
+	for _, p := range r.Participation {
+		if r.Polls > 0 {
+			p.Rate = float64(p.Polls) / float64(r.Polls)
+		}
+	}
+
+	sort.Slice(r.Wins, func(i, j int) bool {
+		if r.Wins[i].Wins != r.Wins[j].Wins {
+			return r.Wins[i].Wins > r.Wins[j].Wins
+		}
+		return r.Wins[i].Name < r.Wins[j].Name
+	})
+
+	// users who vote least come first, making it easy to see who never votes.
+	sort.Slice(r.Participation, func(i, j int) bool {
+		if r.Participation[i].Polls != r.Participation[j].Polls {
+			return r.Participation[i].Polls < r.Participation[j].Polls
+		}
+		return r.Participation[i].User < r.Participation[j].User
+	})
+}
+
+// TimeToDecision returns the time between the first and last of the given events that create a poll or change its votes, returning false should the events not include both a
+// creation and a change.
+func TimeToDecision(events []*vote.Event) (d time.Duration, ok bool) {
+	var start, end time.Time
+	for _, e := range events {
+		switch e.Type {
+		case vote.PollCreated:
+			start = e.Time
+		case vote.VoteCast, vote.UserRemoved:
+			end = e.Time
+		}
+	}
+
+	if start.IsZero() || end.IsZero() {
+		return
+	}
+
+	return end.Sub(start), true
+}
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var (
+	pizza = &restaurant.Building{ID: "pizza", Name: "Pizza"}
+	curry = &restaurant.Building{ID: "curry", Name: "Curry"}
+)
+
+func createPolls() []*vote.Poll {
+	return []*vote.Poll{
+		{ID: "1", Options: []*restaurant.Building{pizza, curry}, Votes: map[string][]string{"pizza": {"Jack", "Tom"}, "curry": {"Will"}}},
+		{ID: "2", Options: []*restaurant.Building{pizza, curry}, Votes: map[string][]string{"curry": {"Jack"}}},
+		{ID: "3", Options: []*restaurant.Building{pizza, curry}, Votes: map[string][]string{"pizza": {"Jack"}}},
+	}
+}
+
+func TestComputeWins(t *testing.T) {
+	r := Compute(createPolls(), time.Time{}, nil, time.Now())
+
+	if len(r.Wins) != 2 || r.Wins[0].ID != "pizza" || r.Wins[0].Wins != 2 || r.Wins[1].Wins != 1 {
+		t.Fail()
+	}
+}
+
+func TestComputeWinsWeighted(t *testing.T) {
+	polls := createPolls()
+	polls[0].Rights = map[string]vote.Rights{"Will": {Weight: 3}}
+	polls[2].Vetoed = map[string][]string{"pizza": {"Tom"}}
+
+	wins := countWins(polls, time.Now())
+	if len(wins) != 1 || wins[0].ID != "curry" || wins[0].Wins != 2 {
+		t.Logf("Expected winners to be decided by weighted votes and vetoes, got %+v", wins)
+		t.Fail()
+	}
+}
+
+func TestComputeTotals(t *testing.T) {
+	r := Compute(createPolls(), time.Time{}, nil, time.Now())
+
+	if r.Polls != 3 || r.Votes != 5 {
+		t.Logf("Polls: %v, Votes: %v", r.Polls, r.Votes)
+		t.Fail()
+	} else if r.AverageVotes < 1.66 || r.AverageVotes > 1.67 {
+		t.Logf("Average votes: %v", r.AverageVotes)
+		t.Fail()
+	}
+}
+
+func TestComputeParticipation(t *testing.T) {
+	r := Compute(createPolls(), time.Time{}, []string{"TJ"}, time.Now())
+
+	if len(r.Participation) != 4 || r.Participation[0].User != "TJ" || r.Participation[0].Rate != 0 {
+		t.Log("User who has never voted was not listed first")
+		t.Fail()
+	} else if last := r.Participation[3]; last.User != "Jack" || last.Rate != 1 {
+		t.Fail()
+	}
+}
+
+func TestComputePrivatePolls(t *testing.T) {
+	polls := createPolls()
+	polls[0].Visibility = vote.Anonymous
+	polls[1].Visibility = vote.Secret
+	polls[1].ClosesAt = time.Now().Add(time.Hour)
+
+	r := Compute(polls, time.Time{}, nil, time.Now())
+	if len(r.Participation) != 1 || r.Participation[0].User != "Jack" || r.Participation[0].Polls != 1 {
+		t.Logf("Expected only the voters of the public poll to be listed, got %v", len(r.Participation))
+		t.Fail()
+	}
+	if r.Votes != 4 || len(r.Wins) != 1 || r.Wins[0].ID != "pizza" || r.Wins[0].Wins != 2 {
+		t.Logf("Expected the open secret poll to be left out of the votes and wins, got %v votes and wins %+v", r.Votes, r.Wins)
+		t.Fail()
+	}
+}
+
+func TestTimeToDecision(t *testing.T) {
+	start := time.Date(2018, time.November, 2, 11, 0, 0, 0, time.UTC)
+	events := []*vote.Event{
+		{Type: vote.PollCreated, Time: start},
+		{Type: vote.VoteCast, Time: start.Add(5 * time.Minute)},
+		{Type: vote.UserRemoved, Time: start.Add(20 * time.Minute)},
+	}
+
+	if d, ok := TimeToDecision(events); !ok || d != 20*time.Minute {
+		t.Fail()
+	}
+}
+
+func TestTimeToDecisionNoVotes(t *testing.T) {
+	if _, ok := TimeToDecision([]*vote.Event{{Type: vote.PollCreated, Time: time.Now()}}); ok {
+		t.Fail()
+	}
+}
+
+func TestReportRatings(t *testing.T) {
+	reviews := &vote.MockReviewModel{}
+	reviews.SaveReview(&vote.Review{PollID: "1", RestaurantID: "pizza", RestaurantName: "Pizza", User: "Jack", Rating: 4, CreatedAt: time.Now()})
+	reviews.SaveReview(&vote.Review{PollID: "1", RestaurantID: "pizza", RestaurantName: "Pizza", User: "Tom", Rating: 5, Tags: []string{"great portions"}, CreatedAt: time.Now()})
+
+	a := &Analyser{Polls: &vote.MockPollModel{}, Reviews: reviews}
+	r, _, err := a.Report("", time.Now().Add(-time.Hour), nil)
+	if err != nil || len(r.Ratings) != 1 || r.Ratings[0].Average != 4.5 || r.Ratings[0].Tags["great portions"] != 1 {
+		t.Logf("Expected the report to rate pizza, got %v %v", r, err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetReport provides a http handler for accessing statistics across all polls within the caller's workspace. The optional 'since' query parameter, an RFC 3339 time, restricts the report to polls created
+// after said time, while the comma separated 'users' query parameter lists users that should be included within the report's participation even should they have never voted.
+func GetReport(w http.ResponseWriter, r *http.Request) {
+	since := time.Time{}
+	if param := r.URL.Query().Get("since"); param != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			log.Printf("Could not parse since parameter %s\n", param)
+			http.Error(w, "Could not parse since parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, _, err := instance.Report(caller.Workspace(r.Context()), since, vote.SplitList(r.URL.Query().Get("users")))
+	if err != nil {
+		log.Printf("Could not produce statistics report due to: %s\n", err.Error())
+		http.Error(w, "Could not produce statistics", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("The report %v could not be serialised to JSON.\n", report)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
+}
@@ -0,0 +1,84 @@
+package vote
+
+import (
+	"log"
+	"time"
+)
+
+// Closer periodically settles every poll with rules whose closing time has passed, deciding, extending or failing each poll as its rules state. Polls are also settled whenever
+// they are changed, which decides polls early should their result become certain, so the closer only handles polls reaching their closing time. Polls without rules are decided
+// by closing, so the closer records a decided event for each of them once its closing time passes.
+type Closer struct {
+	// Interval states how often the closer checks for polls which have closed, defaulting to once a minute.
+	Interval time.Duration
+
+	// last holds when the closer last checked for polls which have closed.
+	last time.Time
+}
+
+// Run starts the closer's loop, settling closed polls until the stop channel is closed. Note this method will block so should be ran as a separate goroutine.
+func (c *Closer) Run(stop <-chan struct{}) {
+	interval := c.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			c.SettleDue(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SettleDue settles every poll with rules that closed at or before now without being settled, using SettlePoll to record and broadcast each poll's change of status, then
+// decides every poll without rules that closed since the closer last checked. On the first check, only polls closing within the last interval are decided, so a restart does
+// not decide polls which closed long ago a second time.
+func (c *Closer) SettleDue(now time.Time) {
+	c.decideClosed(now)
+
+	polls, _, err := instance.Model.GetUnsettledPolls(now)
+	if err != nil {
+		log.Printf("Closer: could not retrieve closed polls due to: %s\n", err)
+		return
+	}
+
+	for _, p := range polls {
+		_, e, _, err := SettlePoll(p.Workspace, p.ID)
+		if err != nil {
+			log.Printf("Closer: could not settle poll %s due to: %s\n", p.ID, err)
+		} else if e != nil {
+			log.Printf("Closer: poll %s has been settled with the event %s\n", p.ID, e.Type)
+		}
+	}
+}
+
+// decideClosed records, publishes and broadcasts a decided event for every poll without rules whose closing time passed since the closer last checked.
+func (c *Closer) decideClosed(now time.Time) {
+	from := c.last
+	if from.IsZero() {
+		interval := c.Interval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		from = now.Add(-interval)
+	}
+
+	polls, _, err := instance.Model.GetClosingPolls(from, now)
+	if err != nil {
+		log.Printf("Closer: could not retrieve closed polls due to: %s\n", err)
+		return
+	}
+	c.last = now
+
+	for _, p := range polls {
+		if e := decide(p); e != nil {
+			log.Printf("Closer: poll %s has been decided by closing\n", p.ID)
+		}
+	}
+}
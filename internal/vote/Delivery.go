@@ -0,0 +1,109 @@
+package vote
+
+import (
+	"time"
+)
+
+// MaxDeliveryRadius is the largest delivery radius in metres a restaurant may give.
+const MaxDeliveryRadius = 100000
+
+// OptionDelivery reports whether a single option of a poll can deliver to the poll's office at the poll's order time.
+type OptionDelivery struct {
+	OptionID string `json:"optionId"`
+	// Distance is the distance in metres between the option and the poll's office, nil should the option's location be unknown.
+	Distance *float64 `json:"distance,omitempty"`
+	// Open states whether the option is open at the poll's order time, nil should its opening hours be unknown.
+	Open *bool `json:"open,omitempty"`
+	// CanDeliver states whether the option delivers to the office and is not known to be closed at the order time, with Reason explaining why should it be unable to.
+	CanDeliver bool   `json:"canDeliver"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// OrderTime returns the time the poll's order is placed, being the poll's closing time or the given time should the poll have no closing time.
+func (p *Poll) OrderTime(now time.Time) time.Time {
+	if p.ClosesAt.IsZero() {
+		return now
+	}
+	return p.ClosesAt
+}
+
+// IsAvailable returns whether the option with the given ID can be voted for, being the case unless its restaurant is known to be closed at the poll's order time. Polls without
+// a closing time are ordered straight away, so their options' availability changes over time.
+func (p *Poll) IsAvailable(optionID string, now time.Time) bool {
+	opt := p.Option(optionID)
+	if opt == nil {
+		return false
+	}
+	open, known := opt.IsOpen(p.OrderTime(now))
+	return open || !known
+}
+
+// UpdateAvailability recalculates which of the poll's options are unavailable at the given time, returning the IDs of the options which have become unavailable and of those which
+// have become available again, in the order the options are listed.
+func (p *Poll) UpdateAvailability(now time.Time) (unavailable []string, available []string) {
+	was := p.Unavailable
+	p.Unavailable = nil
+	for _, opt := range p.Options {
+		if !p.IsAvailable(opt.ID, now) {
+			p.Unavailable = append(p.Unavailable, opt.ID)
+			if !contains(was, opt.ID) {
+				unavailable = append(unavailable, opt.ID)
+			}
+		} else if contains(was, opt.ID) {
+			available = append(available, opt.ID)
+		}
+	}
+	return
+}
+
+// setAvailable marks the option with the given ID as available or unavailable.
+func (p *Poll) setAvailable(optionID string, available bool) {
+	for i, id := range p.Unavailable {
+		if id == optionID {
+			p.Unavailable = append(p.Unavailable[:i], p.Unavailable[i+1:]...)
+			break
+		}
+	}
+	if !available {
+		p.Unavailable = append(p.Unavailable, optionID)
+	}
+}
+
+// Deliveries reports whether each option of the poll can deliver to the poll's office at the poll's order time, in the order the options are listed. Options with unknown opening
+// hours are assumed to be open, while options whose service, location or delivery radius is unknown cannot deliver.
+func (p *Poll) Deliveries(now time.Time) []*OptionDelivery {
+	at := p.OrderTime(now)
+
+	deliveries := make([]*OptionDelivery, 0, len(p.Options))
+	for _, opt := range p.Options {
+		d := &OptionDelivery{OptionID: opt.ID}
+		if opt.Location != nil && p.Office != nil {
+			distance := opt.Location.DistanceTo(*p.Office)
+			d.Distance = &distance
+		}
+		if open, known := opt.IsOpen(at); known {
+			d.Open = &open
+		}
+
+		switch {
+		case opt.Service == "":
+			d.Reason = "the restaurant's service is unknown"
+		case !opt.Service.Delivers():
+			d.Reason = "the restaurant only offers collection"
+		case d.Open != nil && !*d.Open:
+			d.Reason = "the restaurant is closed at the order time"
+		case p.Office == nil:
+			d.Reason = "the poll's office is unknown"
+		case d.Distance == nil:
+			d.Reason = "the restaurant's location is unknown"
+		case opt.DeliveryRadius == 0:
+			d.Reason = "the restaurant's delivery radius is unknown"
+		case *d.Distance > opt.DeliveryRadius:
+			d.Reason = "the office is outside the restaurant's delivery radius"
+		default:
+			d.CanDeliver = true
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries
+}
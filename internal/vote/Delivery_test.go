@@ -0,0 +1,126 @@
+package vote
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// friday is the evening of Friday 16th October 2026, at which the polls within these tests close.
+var friday = time.Date(2026, time.October, 16, 19, 0, 0, 0, time.UTC)
+
+func TestDeliveries(t *testing.T) {
+	evenings := []*restaurant.OpeningHours{{Day: "friday", Opens: "17:00", Closes: "23:00"}}
+	p := &Poll{
+		ID:       "poll",
+		ClosesAt: friday,
+		Office:   &restaurant.Location{Lat: 51.5072, Lng: -0.1276},
+		Options: []*restaurant.Building{
+			{ID: "near", Service: restaurant.Delivery, Location: &restaurant.Location{Lat: 51.5155, Lng: -0.1419}, DeliveryRadius: 5000, Hours: evenings},
+			{ID: "collect", Service: restaurant.Collection, Location: &restaurant.Location{Lat: 51.5155, Lng: -0.1419}},
+			{ID: "far", Service: restaurant.DeliveryAndCollection, Location: &restaurant.Location{Lat: 51.7520, Lng: -1.2577}, DeliveryRadius: 5000},
+			{ID: "lunch", Service: restaurant.Delivery, Location: &restaurant.Location{Lat: 51.5155, Lng: -0.1419}, DeliveryRadius: 5000,
+				Hours: []*restaurant.OpeningHours{{Day: "friday", Opens: "11:00", Closes: "15:00"}}},
+			{ID: "unknown"},
+		},
+	}
+
+	got := p.ViewFor("", time.Now()).Delivery
+	if len(got) != 5 {
+		t.Logf("Expected a report for each option, got %v", got)
+		t.FailNow()
+	}
+
+	if d := got[0]; !d.CanDeliver || d.Distance == nil || *d.Distance < 1000 || *d.Distance > 2000 || d.Open == nil || !*d.Open {
+		t.Logf("Expected the nearby option to deliver around 1.4km, got %+v", d)
+		t.Fail()
+	}
+
+	for i, reason := range []string{
+		"the restaurant only offers collection",
+		"the office is outside the restaurant's delivery radius",
+		"the restaurant is closed at the order time",
+		"the restaurant's service is unknown",
+	} {
+		if d := got[i+1]; d.CanDeliver || d.Reason != reason {
+			t.Logf("Expected %s to be unable to deliver as %q, got %+v", d.OptionID, reason, d)
+			t.Fail()
+		}
+	}
+
+	if got[4].Distance != nil || got[4].Open != nil {
+		t.Logf("Expected the distance and opening of an unknown option to be unknown, got %+v", got[4])
+		t.Fail()
+	}
+}
+
+func TestOpeningHoursOvernight(t *testing.T) {
+	b := &restaurant.Building{Hours: []*restaurant.OpeningHours{{Day: "friday", Opens: "18:00", Closes: "02:00"}}}
+
+	for _, c := range []struct {
+		at   time.Time
+		open bool
+	}{
+		{friday, true},
+		{friday.Add(6 * time.Hour), true},
+		{friday.Add(8 * time.Hour), false},
+		{friday.Add(-2 * time.Hour), false},
+	} {
+		if open, known := b.IsOpen(c.at); !known || open != c.open {
+			t.Logf("Expected the restaurant to be open %v at %s, got %v", c.open, c.at, open)
+			t.Fail()
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	Init(&Container{Model: &MockPollModel{}, Events: &MockEventModel{}, Geocoder: &restaurant.StubGeocoder{Locations: map[string]restaurant.Location{
+		"Address 1": {Lat: 51.5072, Lng: -0.1276},
+	}}})
+
+	poll, _, err := CreatePoll("", "Jack", []*restaurant.Building{{ID: "r1", Name: "Restaurant 1", Address: " address 1"}, {ID: "r2", Name: "Restaurant 2", Address: "Address 2"}}, nil)
+	if err != nil || poll.Options[0].Location == nil || poll.Options[1].Location != nil {
+		t.Logf("Expected only the known address to be located, got %v %v", poll, err)
+		t.Fail()
+	}
+
+	settings, err := parseSettings(url.Values{"office": {"Address 1"}})
+	if err != nil || settings.Office == nil || settings.Office.Lat != 51.5072 {
+		t.Logf("Expected the office to be located using its address, got %v %v", settings, err)
+		t.Fail()
+	}
+
+	settings, err = parseSettings(url.Values{"office": {"51.5, 200"}})
+	if err == nil {
+		t.Logf("Expected an office with an invalid longitude to be invalid, got %v", settings.Office)
+		t.Fail()
+	}
+}
+
+func TestOpeningHoursExceptions(t *testing.T) {
+	b := &restaurant.Building{
+		Hours: []*restaurant.OpeningHours{{Day: "thursday", Opens: "18:00", Closes: "02:00"}, {Day: "friday", Opens: "17:00", Closes: "23:00"}},
+		Exceptions: []*restaurant.HoursException{
+			{Date: "2026-10-16"},
+			{Date: "2026-10-17", Opens: "12:00", Closes: "14:00"},
+		},
+	}
+
+	for _, c := range []struct {
+		at   time.Time
+		open bool
+	}{
+		// thursday's hours still run past midnight into the holiday.
+		{friday.Add(-18 * time.Hour), true},
+		{friday, false},
+		{friday.Add(18 * time.Hour), true},
+		{friday.Add(24 * time.Hour), false},
+	} {
+		if open, known := b.IsOpen(c.at); !known || open != c.open {
+			t.Logf("Expected the restaurant to be open %v at %s, got %v", c.open, c.at, open)
+			t.Fail()
+		}
+	}
+}
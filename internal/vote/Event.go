@@ -0,0 +1,176 @@
+package vote
+
+import (
+	"time"
+)
+
+// EventType represents the kind of action recorded by an Event.
+type EventType string
+
+const (
+	// PollCreated records the creation of a poll, storing the created poll as the event's snapshot.
+	PollCreated EventType = "poll_created"
+	// PollUpdated records a poll being replaced wholesale, storing the updated poll as the event's snapshot.
+	PollUpdated EventType = "poll_updated"
+	// VoteCast records a user casting a vote for a given option.
+	VoteCast EventType = "vote_cast"
+	// UserRemoved records all votes for a given user being removed from a poll.
+	UserRemoved EventType = "user_removed"
+	// PollRolledBack records a poll being restored to an earlier state, storing the restored poll as the event's snapshot.
+	PollRolledBack EventType = "poll_rolled_back"
+	// OptionAdded records an option being added to a poll, storing the updated poll as the event's snapshot.
+	OptionAdded EventType = "option_added"
+	// OptionRemoved records an option being removed from a poll, storing the updated poll as the event's snapshot.
+	OptionRemoved EventType = "option_removed"
+	// OptionRenamed records an option of a poll being renamed, storing the updated poll as the event's snapshot.
+	OptionRenamed EventType = "option_renamed"
+	// OptionSuggested records an option being suggested by a voter, pending approval, storing the updated poll as the event's snapshot.
+	OptionSuggested EventType = "option_suggested"
+	// OptionRejected records a pending option being rejected by the organiser or withdrawn by its suggester, storing the updated poll as the event's snapshot.
+	OptionRejected EventType = "option_rejected"
+	// RightsChanged records the organiser changing the rights of the participant given by the event's user, storing the updated poll as the event's snapshot.
+	RightsChanged EventType = "rights_changed"
+	// OptionVetoed records the event's user vetoing the option given by the event's option ID.
+	OptionVetoed EventType = "option_vetoed"
+	// VetoWithdrawn records the event's user withdrawing their veto of the option given by the event's option ID.
+	VetoWithdrawn EventType = "veto_withdrawn"
+	// RulesChanged records the organiser changing the rules of a poll, storing the updated poll as the event's snapshot.
+	RulesChanged EventType = "rules_changed"
+	// PollDecided records a poll meeting its rules, either once it closed or early should its result have become certain, or a poll without rules closing, storing the decided
+	// poll as the event's snapshot.
+	PollDecided EventType = "poll_decided"
+	// PollExtended records a poll being reopened for not meeting its rules once it closed, storing the extended poll as the event's snapshot.
+	PollExtended EventType = "poll_extended"
+	// PollFailed records a poll closing without meeting its rules, storing the failed poll as the event's snapshot.
+	PollFailed EventType = "poll_failed"
+	// OptionHoursChanged records the opening hours of the option given by the event's option ID being updated from the restaurant catalogue, storing the updated poll as the
+	// event's snapshot.
+	OptionHoursChanged EventType = "option_hours_changed"
+	// OptionUnavailable records the option given by the event's option ID becoming unavailable, its restaurant being closed at the poll's order time.
+	OptionUnavailable EventType = "option_unavailable"
+	// OptionAvailable records the option given by the event's option ID becoming available again, its restaurant being open at the poll's order time.
+	OptionAvailable EventType = "option_available"
+)
+
+// EventTypes lists every type of event recorded for polls.
+var EventTypes = []EventType{
+	PollCreated, PollUpdated, VoteCast, UserRemoved, PollRolledBack, OptionAdded, OptionRemoved, OptionRenamed, OptionSuggested, OptionRejected, RightsChanged, OptionVetoed,
+	VetoWithdrawn, RulesChanged, PollDecided, PollExtended, PollFailed, OptionHoursChanged, OptionUnavailable, OptionAvailable,
+}
+
+// IsOptionChange returns whether the event type records a change to the options of a poll, such events being broadcast to websocket clients alongside the updated poll.
+func (t EventType) IsOptionChange() bool {
+	switch t {
+	case OptionAdded, OptionRemoved, OptionRenamed, OptionSuggested, OptionRejected, OptionHoursChanged, OptionUnavailable, OptionAvailable:
+		return true
+	}
+	return false
+}
+
+// Event represents a singular action that has been applied to a poll. Events are stored in order for each poll, allowing for the state of a poll at any point in its history to
+// be reconstructed.
+type Event struct {
+	PollID    string    `json:"pollId" bson:"pollId"`
+	Workspace string    `json:"workspace" bson:"workspace"`
+	Sequence  int       `json:"sequence" bson:"sequence"`
+	Type      EventType `json:"type" bson:"type"`
+	Time      time.Time `json:"time" bson:"time"`
+	User      string    `json:"user,omitempty" bson:"user,omitempty"`
+	OptionID  string    `json:"optionId,omitempty" bson:"optionId,omitempty"`
+	Snapshot  *Poll     `json:"snapshot,omitempty" bson:"snapshot,omitempty"`
+	// RestoredTo states the sequence number a PollRolledBack event restored the poll to.
+	RestoredTo int `json:"restoredTo,omitempty" bson:"restoredTo,omitempty"`
+}
+
+// NewEvent creates a new event of the given type for the given poll, timestamped with the current time. The sequence number of the event is assigned by the EventModel when the
+// event is stored.
+func NewEvent(t EventType, p *Poll) *Event {
+	return &Event{
+		PollID:    p.ID,
+		Workspace: p.Workspace,
+		Type:      t,
+		Time:      time.Now(),
+	}
+}
+
+// Apply applies the event to the given poll, returning the resulting poll. Events holding a snapshot replace the given poll with a copy of the snapshot, so the returned poll may
+// not be the poll passed to the method.
+func (e *Event) Apply(p *Poll) *Poll {
+	switch e.Type {
+	case PollCreated, PollUpdated, PollRolledBack, OptionAdded, OptionRemoved, OptionRenamed, OptionSuggested, OptionRejected, RightsChanged,
+		RulesChanged, PollDecided, PollExtended, PollFailed, OptionHoursChanged:
+		if e.Snapshot != nil {
+			p = e.Snapshot.Copy()
+		}
+	case VoteCast:
+		p.AddVote(e.OptionID, e.User)
+	case UserRemoved:
+		p.ClearVotesFor(e.User)
+	case OptionVetoed:
+		p.Veto(e.OptionID, e.User)
+	case VetoWithdrawn:
+		p.Unveto(e.OptionID, e.User)
+	case OptionUnavailable:
+		p.setAvailable(e.OptionID, false)
+	case OptionAvailable:
+		p.setAvailable(e.OptionID, true)
+	}
+	return p
+}
+
+// ViewFor returns the event as it should be seen by the given user at the given time, following the visibility of the given poll. Events of private polls hide who cast each
+// vote other than the given user's own along with the option voted for, as the order of events would otherwise tie each vote to a voter, and hold the view of their snapshot
+// rather than the snapshot itself.
+func (e *Event) ViewFor(p *Poll, user string, now time.Time) *Event {
+	if !p.IsPrivate() {
+		return e
+	}
+
+	v := *e
+	if (e.Type == VoteCast || e.Type == UserRemoved || e.Type == OptionVetoed || e.Type == VetoWithdrawn) && e.User != user {
+		v.User = ""
+		if e.Type == VoteCast || e.Type == UserRemoved {
+			v.OptionID = ""
+		}
+	}
+
+	if e.Snapshot != nil {
+		// the poll's current visibility and closing time apply to its whole history, so votes cast before the poll was made private are hidden too.
+		s := e.Snapshot.Copy()
+		s.Visibility = p.Visibility
+		s.ClosesAt = p.ClosesAt
+		v.Snapshot = s.ViewFor(user, now)
+	}
+	return &v
+}
+
+// Replayable returns whether the state of a poll can be reconstructed from the given events, which requires its history to start with a snapshot of the poll. Polls created
+// before the event log was introduced have a history starting part way through, so replaying it would lose the poll's options and settings.
+func Replayable(events []*Event) bool {
+	return len(events) > 0 && events[0].Snapshot != nil
+}
+
+// Replay reconstructs the state of the poll with the given ID by applying the given events in order, stopping after the event with the sequence number upTo. Passing an upTo
+// of 0 or less returns the empty poll the history starts from.
+func Replay(pollID string, events []*Event, upTo int) (p *Poll) {
+	p = &Poll{ID: pollID}
+	for _, e := range events {
+		if e.Sequence > upTo {
+			break
+		}
+		p = e.Apply(p)
+	}
+	p.ID = pollID
+	return
+}
+
+// SequenceAt returns the sequence number of the last event within the given events that occurred at or before the time t, returning 0 should no such event exist.
+func SequenceAt(events []*Event, t time.Time) (seq int) {
+	for _, e := range events {
+		if e.Time.After(t) {
+			break
+		}
+		seq = e.Sequence
+	}
+	return
+}
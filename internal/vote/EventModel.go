@@ -0,0 +1,12 @@
+package vote
+
+// EventModel defines a contract for how the system should interact with the database for storing and accessing the event log of each poll.
+type EventModel interface {
+	// AddEvent appends the given event to the end of the event log for the event's poll, assigning the event the next sequence number for said poll. A status is returned detailing
+	// the status of the operation along with any errors that occur while attempting to store the event.
+	AddEvent(e *Event) (Status, error)
+	// GetEvents returns the event log for the poll with the given ID, ordered by sequence number. Should a poll have no recorded events an empty slice will be returned.
+	GetEvents(pollID string) ([]*Event, Status, error)
+	// Close allows for an EventModel connection to be closed.
+	Close() error
+}
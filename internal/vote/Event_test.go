@@ -0,0 +1,98 @@
+package vote
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+func createEvents() (events []*Event) {
+	start := time.Date(2018, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	created := &Event{PollID: "poll", Sequence: 1, Type: PollCreated, Time: start}
+	created.Snapshot = &Poll{ID: "poll", Options: []*restaurant.Building{res, res2}}
+
+	events = []*Event{
+		created,
+		{PollID: "poll", Sequence: 2, Type: VoteCast, Time: start.Add(time.Minute), User: "Jack", OptionID: "r1"},
+		{PollID: "poll", Sequence: 3, Type: VoteCast, Time: start.Add(2 * time.Minute), User: "Tom", OptionID: "r2"},
+		{PollID: "poll", Sequence: 4, Type: UserRemoved, Time: start.Add(3 * time.Minute), User: "Jack"},
+	}
+	return
+}
+
+func TestReplay(t *testing.T) {
+	p := Replay("poll", createEvents(), 4)
+
+	if len(p.Options) != 2 {
+		t.Log("Options from the created snapshot were not restored")
+		t.Fail()
+	} else if len(p.Votes["r1"]) != 0 || !stringsContains(p.Votes["r2"], "Tom") {
+		t.Logf("Poll Votes: %v", p.Votes)
+		t.Fail()
+	}
+}
+
+func TestReplayEarlierPoint(t *testing.T) {
+	p := Replay("poll", createEvents(), 2)
+
+	if !stringsContains(p.Votes["r1"], "Jack") || len(p.Votes["r2"]) != 0 {
+		t.Logf("Poll Votes: %v", p.Votes)
+		t.Fail()
+	}
+}
+
+func TestReplayDoesNotAlterSnapshot(t *testing.T) {
+	events := createEvents()
+	Replay("poll", events, 4)
+
+	if events[0].Snapshot.Votes != nil {
+		t.Log("Replaying votes altered the snapshot stored within the event log")
+		t.Fail()
+	}
+}
+
+func TestReplayRollback(t *testing.T) {
+	events := createEvents()
+	rollback := &Event{PollID: "poll", Sequence: 5, Type: PollRolledBack, RestoredTo: 2}
+	rollback.Snapshot = Replay("poll", events, 2)
+	events = append(events, rollback)
+
+	p := Replay("poll", events, 5)
+	if !stringsContains(p.Votes["r1"], "Jack") || len(p.Votes["r2"]) != 0 {
+		t.Logf("Poll Votes: %v", p.Votes)
+		t.Fail()
+	}
+}
+
+func TestSequenceAt(t *testing.T) {
+	events := createEvents()
+
+	if SequenceAt(events, events[2].Time.Add(time.Second)) != 3 {
+		t.Fail()
+	} else if SequenceAt(events, events[0].Time.Add(-time.Second)) != 0 {
+		t.Fail()
+	}
+}
+
+func TestEventViewFor(t *testing.T) {
+	events := createEvents()
+	p := &Poll{ID: "poll", Visibility: Secret}
+
+	if v := events[1].ViewFor(p, "Jack", time.Now()); v.User != "Jack" || v.OptionID != "r1" {
+		t.Logf("Expected Jack to see their own vote, got %v", v)
+		t.Fail()
+	}
+
+	if v := events[2].ViewFor(p, "Jack", time.Now()); v.User != "" || v.OptionID != "" || events[2].User != "Tom" {
+		t.Logf("Expected Tom's vote to be hidden from Jack without changing the event, got %v", v)
+		t.Fail()
+	}
+
+	p.Visibility = Anonymous
+	if v := events[2].ViewFor(p, "Jack", time.Now()); v.User != "" || v.OptionID != "" {
+		t.Logf("Expected Tom's vote to be hidden within an anonymous poll, as the order of events would tie it to Tom, got %v", v)
+		t.Fail()
+	}
+}
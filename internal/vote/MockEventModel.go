@@ -0,0 +1,46 @@
+package vote
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MockEventModel provides an in memory implementation of the EventModel interface.
+type MockEventModel struct {
+	mutex  sync.Mutex
+	events map[string][]*Event
+}
+
+// AddEvent appends the given event to the in memory event log for its poll. Should the event's poll ID be 'unknown' an error will be returned along with a 'NotFound' status.
+func (em *MockEventModel) AddEvent(e *Event) (status Status, err error) {
+	if e.PollID == "unknown" {
+		err = fmt.Errorf("the id %s could not be found", e.PollID)
+		status = NotFound
+		return
+	}
+
+	em.mutex.Lock()
+	defer em.mutex.Unlock()
+
+	if em.events == nil {
+		em.events = make(map[string][]*Event)
+	}
+
+	e.Sequence = len(em.events[e.PollID]) + 1
+	em.events[e.PollID] = append(em.events[e.PollID], e)
+	return
+}
+
+// GetEvents returns the in memory event log for the poll with the given ID.
+func (em *MockEventModel) GetEvents(pollID string) (events []*Event, status Status, err error) {
+	em.mutex.Lock()
+	defer em.mutex.Unlock()
+
+	events = append(make([]*Event, 0), em.events[pollID]...)
+	return
+}
+
+// Close has been added to ensure the mock meets the EventModel interface, it does not need to actually complete anything.
+func (em *MockEventModel) Close() (err error) {
+	return
+}
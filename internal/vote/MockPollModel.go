@@ -2,6 +2,8 @@ package vote
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"takeaway/takeaway-server/internal/restaurant"
 )
@@ -18,13 +20,19 @@ var r2 = &restaurant.Building{
 	Address: "Address 2",
 }
 
-// MockPollModel provides a mock implementation of the PollModel interface.
+// MockPollModel provides a mock implementation of the PollModel interface. The mock may be used from several goroutines at once, storing and returning copies of its poll so
+// callers changing a poll do not change the stored poll until it is updated.
 type MockPollModel struct {
-	p *Poll
+	mutex sync.Mutex
+	p     *Poll
 }
 
-// GetPoll returns a saved poll with the id "test", or nil with an error should the ID "unknown" be passed to the method.
-func (pm *MockPollModel) GetPoll(id string) (poll *Poll, status Status, err error) {
+// GetPoll returns a saved poll with the id "test" within the default workspace, or nil with an error should the ID "unknown" be passed to the method or the saved poll belong to a
+// different workspace.
+func (pm *MockPollModel) GetPoll(workspace string, id string) (poll *Poll, status Status, err error) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
 	if id == "unknown" {
 		err = fmt.Errorf("the ID %s is not a valid poll ID", id)
 		status = NotFound
@@ -44,44 +52,134 @@ func (pm *MockPollModel) GetPoll(id string) (poll *Poll, status Status, err erro
 			Options: opts,
 		}
 	}
-	poll = pm.p
+
+	if pm.p.Workspace != workspace {
+		err = fmt.Errorf("the ID %s could not be found within workspace %s", id, workspace)
+		status = NotFound
+		return
+	}
+
+	poll = pm.p.Copy()
+	return
+}
+
+// GetPolls returns the mock's stored Poll object should it belong to the given workspace and have been created at or after the given time.
+func (pm *MockPollModel) GetPolls(workspace string, since time.Time) (polls []*Poll, status Status, err error) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	polls = make([]*Poll, 0)
+	if pm.p != nil && pm.p.Workspace == workspace && !pm.p.CreatedAt.Before(since) {
+		polls = append(polls, pm.p.Copy())
+	}
+	return
+}
+
+// ListPolls applies the given query to the mock's stored Poll object should it belong to the given workspace.
+func (pm *MockPollModel) ListPolls(workspace string, q *PollQuery) (page *PollPage, status Status, err error) {
+	err = q.Normalise()
+	if err != nil {
+		status = Invalid
+		return
+	}
+
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	polls := make([]*Poll, 0)
+	if pm.p != nil && pm.p.Workspace == workspace {
+		polls = append(polls, pm.p.Copy())
+	}
+
+	page, err = q.Apply(polls)
+	if err != nil {
+		status = Invalid
+	}
+	return
+}
+
+// GetUnsettledPolls returns the mock's stored Poll object should it have rules, have closed at or before the given time and not have been settled.
+func (pm *MockPollModel) GetUnsettledPolls(now time.Time) (polls []*Poll, status Status, err error) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	polls = make([]*Poll, 0)
+	if pm.p != nil && pm.p.Rules != nil && pm.p.Outcome == "" && pm.p.State(now) == PollClosed {
+		polls = append(polls, pm.p.Copy())
+	}
+	return
+}
+
+// GetClosingPolls returns the saved poll should its closing time be after from and at or before to.
+func (pm *MockPollModel) GetClosingPolls(from time.Time, to time.Time) (polls []*Poll, status Status, err error) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	polls = make([]*Poll, 0)
+	if pm.p != nil && pm.p.ClosesAt.After(from) && !pm.p.ClosesAt.After(to) {
+		polls = append(polls, pm.p.Copy())
+	}
 	return
 }
 
 // NewPoll creates a new poll returning the created poll. This poll is used as the saved poll for the mock. An error will be returned from this method should the first option's name passed be "unknown", returning nil
-// for the returned poll.
-func (pm *MockPollModel) NewPoll(options []*restaurant.Building) (poll *Poll, status Status, err error) {
+// for the returned poll, or along with an 'Invalid' status should the options not pass ValidateOptions.
+func (pm *MockPollModel) NewPoll(workspace string, options []*restaurant.Building) (poll *Poll, status Status, err error) {
+	err = ValidateOptions(options)
+	if err != nil {
+		status = Invalid
+		return
+	}
+
 	if options[0].Name == "unknown" {
-		err = fmt.Errorf("the specified option %s could not be found", options[0])
+		err = fmt.Errorf("the specified option %s could not be found", options[0].Name)
 		status = NotFound
 		return
 	}
 
 	poll = &Poll{
-		ID:      "new poll",
-		Options: options,
+		ID:        "new poll",
+		Workspace: workspace,
+		Options:   options,
+		CreatedAt: time.Now(),
 	}
 
-	pm.p = poll
+	pm.mutex.Lock()
+	pm.p = poll.Copy()
+	pm.mutex.Unlock()
 
 	return
 }
 
-// UpdatePoll updates the mock's stored Poll object with the given updated Poll object. Should the passed Poll have an ID of 'unknown' an error will be returned with an 'NotFound' status.
+// UpdatePoll updates the mock's stored Poll object with the given updated Poll object. Should the passed Poll have an ID of 'unknown', or the stored poll belong to a different
+// workspace, an error will be returned with an 'NotFound' status, while polls not passing ValidatePoll are returned an 'Invalid' status.
 func (pm *MockPollModel) UpdatePoll(p *Poll) (status Status, err error) {
-	if p.ID == "unknown" {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	if p.ID == "unknown" || (pm.p != nil && pm.p.Workspace != p.Workspace) {
 		err = fmt.Errorf("the id %s could not be found", p.ID)
 		status = NotFound
 		return
 	}
 
-	pm.p = p
+	err = ValidatePoll(p)
+	if err != nil {
+		status = Invalid
+		return
+	}
+
+	pm.p = p.Copy()
 	return
 }
 
-// DeletePoll removes the mock's stored Poll Object. Should the passed id equal 'unknown' an error will be returned along with a 'NotFound' status.
-func (pm *MockPollModel) DeletePoll(id string) (status Status, err error) {
-	if id == "unknown" {
+// DeletePoll removes the mock's stored Poll Object. Should the passed id equal 'unknown', or the stored poll belong to a different workspace, an error will be returned along with a
+// 'NotFound' status.
+func (pm *MockPollModel) DeletePoll(workspace string, id string) (status Status, err error) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	if id == "unknown" || (pm.p != nil && pm.p.Workspace != workspace) {
 		err = fmt.Errorf("the id %s could not be found", id)
 		status = NotFound
 		return
@@ -0,0 +1,67 @@
+package vote
+
+import (
+	"sync"
+	"time"
+)
+
+// MockReviewModel provides an in memory implementation of the ReviewModel interface.
+type MockReviewModel struct {
+	mutex   sync.Mutex
+	reviews []*Review
+}
+
+// SaveReview stores the given review in memory, replacing the review the same user has left for the same poll should there be one.
+func (rm *MockReviewModel) SaveReview(r *Review) (status Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	for i, existing := range rm.reviews {
+		if existing.Workspace == r.Workspace && existing.PollID == r.PollID && existing.User == r.User {
+			rm.reviews[i] = r
+			return
+		}
+	}
+
+	rm.reviews = append(rm.reviews, r)
+	return
+}
+
+// GetPollReviews returns the reviews stored in memory for the poll with the given ID within the given workspace.
+func (rm *MockReviewModel) GetPollReviews(workspace string, pollID string) ([]*Review, Status, error) {
+	return rm.filter(func(r *Review) bool {
+		return r.Workspace == workspace && r.PollID == pollID
+	})
+}
+
+// GetRestaurantReviews returns the reviews stored in memory within the given workspace of any of the restaurants with the given IDs.
+func (rm *MockReviewModel) GetRestaurantReviews(workspace string, restaurantIDs []string) ([]*Review, Status, error) {
+	return rm.filter(func(r *Review) bool {
+		return r.Workspace == workspace && contains(restaurantIDs, r.RestaurantID)
+	})
+}
+
+// GetReviews returns the reviews stored in memory within the given workspace left at or after the given time.
+func (rm *MockReviewModel) GetReviews(workspace string, since time.Time) ([]*Review, Status, error) {
+	return rm.filter(func(r *Review) bool {
+		return r.Workspace == workspace && !r.CreatedAt.Before(since)
+	})
+}
+
+// Close has been added to ensure the mock meets the ReviewModel interface, it does not need to actually complete anything.
+func (rm *MockReviewModel) Close() (err error) {
+	return
+}
+
+func (rm *MockReviewModel) filter(match func(r *Review) bool) (reviews []*Review, status Status, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	reviews = make([]*Review, 0)
+	for _, r := range rm.reviews {
+		if match(r) {
+			reviews = append(reviews, r)
+		}
+	}
+	return
+}
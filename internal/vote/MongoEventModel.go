@@ -0,0 +1,112 @@
+package vote
+
+import (
+	"takeaway/takeaway-server/internal/db"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoEventModel provides a mongo based implementation to the EventModel interface.
+type MongoEventModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// maxSequenceAttempts is the number of times AddEvent tries to store an event before giving up, each attempt losing a race for a sequence number leading to another.
+const maxSequenceAttempts = 10
+
+// EnsureIndexes creates the unique index on the poll ID and sequence number of each event, ensuring two events can never be stored at the same point in a poll's history. It
+// should be called once before any events are added.
+func (em *MongoEventModel) EnsureIndexes() (err error) {
+	err = em.openSessionIfRequired()
+	if err != nil {
+		return
+	}
+
+	return em.session.DB(em.DBName).C("events").EnsureIndex(mgo.Index{Key: []string{"pollId", "sequence"}, Unique: true})
+}
+
+// AddEvent stores the given event within the mongo database, assigning it the next sequence number for its poll. Should another event take the same sequence number first, the
+// unique index created by EnsureIndexes rejects the event and the next sequence number is tried, returning an error along with a 'Conflict' status should every attempt fail.
+func (em *MongoEventModel) AddEvent(e *Event) (status Status, err error) {
+	err = em.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := em.session.DB(em.DBName).C("events")
+	for attempt := 0; attempt < maxSequenceAttempts; attempt++ {
+		last := Event{}
+		err = c.Find(bson.M{"pollId": e.PollID}).Sort("-sequence").One(&last)
+		if err != nil && err != mgo.ErrNotFound {
+			status = NoConnection
+			return
+		}
+
+		e.Sequence = last.Sequence + 1
+		err = c.Insert(e)
+		if !mgo.IsDup(err) {
+			break
+		}
+	}
+
+	if mgo.IsDup(err) {
+		status = Conflict
+	} else if err != nil {
+		status = Invalid
+	}
+
+	return
+}
+
+// GetEvents returns the event log stored within the mongo database for the poll with the given ID, ordered by sequence number.
+func (em *MongoEventModel) GetEvents(pollID string) (events []*Event, status Status, err error) {
+	err = em.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	events = make([]*Event, 0)
+	c := em.session.DB(em.DBName).C("events")
+	err = c.Find(bson.M{"pollId": pollID}).Sort("sequence").All(&events)
+	if err != nil {
+		status = NoConnection
+	}
+
+	return
+}
+
+// Aggregate runs the given aggregation pipeline against the events collection, unmarshalling all resulting documents into result, which must be a pointer to a slice.
+func (em *MongoEventModel) Aggregate(pipeline interface{}, result interface{}) (status Status, err error) {
+	err = em.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := em.session.DB(em.DBName).C("events")
+	err = c.Pipe(pipeline).All(result)
+	if err != nil {
+		status = Invalid
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (em *MongoEventModel) Close() (err error) {
+	if em.session != nil {
+		em.session.Close()
+	}
+	return
+}
+
+func (em *MongoEventModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&em.session, em.URL, em.Username, em.Password)
+}
@@ -1,15 +1,15 @@
 package vote
 
 import (
-	"sync"
+	"time"
+
+	"takeaway/takeaway-server/internal/db"
 	"takeaway/takeaway-server/internal/restaurant"
 
 	"github.com/globalsign/mgo"
 	"gopkg.in/mgo.v2/bson"
 )
 
-var sessionMutex = &sync.Mutex{}
-
 // MongoPollModel provides a mongo based implementation to the PollModel interface.
 type MongoPollModel struct {
 	session  *mgo.Session
@@ -19,9 +19,9 @@ type MongoPollModel struct {
 	Password string
 }
 
-// GetPoll gets a poll from the mongo database with the specified id, returning the found poll as a Poll object, a status
+// GetPoll gets a poll from the mongo database with the specified id within the given workspace, returning the found poll as a Poll object, a status
 // and an error should any issues occur while trying to return the specified poll.
-func (pm *MongoPollModel) GetPoll(id string) (poll *Poll, status Status, err error) {
+func (pm *MongoPollModel) GetPoll(workspace string, id string) (poll *Poll, status Status, err error) {
 	err = pm.openSessionIfRequired()
 	if err != nil {
 		status = NoConnection
@@ -31,7 +31,7 @@ func (pm *MongoPollModel) GetPoll(id string) (poll *Poll, status Status, err err
 	p := Poll{}
 
 	c := pm.session.DB(pm.DBName).C("polls")
-	err = c.Find(bson.M{"id": id}).One(&p)
+	err = c.Find(db.Scope(workspace, bson.M{"id": id})).One(&p)
 
 	if err != nil {
 		status = NotFound
@@ -43,9 +43,164 @@ func (pm *MongoPollModel) GetPoll(id string) (poll *Poll, status Status, err err
 	return
 }
 
-// NewPoll creates a new poll within the mongo database, returning the created Poll object with a status and any errors
-// that occur while attempting to create the poll.
-func (pm *MongoPollModel) NewPoll(options []*restaurant.Building) (poll *Poll, status Status, err error) {
+// GetPolls returns all polls stored within the mongo database for the given workspace created at or after the given time, ordered by creation time.
+func (pm *MongoPollModel) GetPolls(workspace string, since time.Time) (polls []*Poll, status Status, err error) {
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	query := db.Scope(workspace, nil)
+	if !since.IsZero() {
+		query["createdAt"] = bson.M{"$gte": since}
+	}
+
+	polls = make([]*Poll, 0)
+	c := pm.session.DB(pm.DBName).C("polls")
+	err = c.Find(query).Sort("createdAt").All(&polls)
+	if err != nil {
+		status = NoConnection
+	}
+
+	return
+}
+
+// pollIndexes are the indexes supporting the queries made by ListPolls and GetUnsettledPolls.
+var pollIndexes = []mgo.Index{
+	{Key: []string{"workspace", "createdAt", "id"}},
+	{Key: []string{"workspace", "closesAt", "id"}},
+	{Key: []string{"workspace", "creator"}},
+	{Key: []string{"workspace", "participants"}},
+	{Key: []string{"workspace", "options.id"}},
+	{Key: []string{"closesAt"}},
+}
+
+// ListPolls returns the page of polls within the given workspace matching the given query. Filtering, sorting and paging are all completed by the mongo database using the
+// indexes in pollIndexes, with one more poll than the limit being requested to detect whether a following page exists.
+func (pm *MongoPollModel) ListPolls(workspace string, q *PollQuery) (page *PollPage, status Status, err error) {
+	err = q.Normalise()
+	if err != nil {
+		status = Invalid
+		return
+	}
+
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := pm.session.DB(pm.DBName).C("polls")
+	for _, index := range pollIndexes {
+		err = c.EnsureIndex(index)
+		if err != nil {
+			status = NoConnection
+			return
+		}
+	}
+
+	query, err := pollQuery(workspace, q)
+	if err != nil {
+		status = Invalid
+		return
+	}
+
+	field := q.SortField()
+	if q.Descending() {
+		field = "-" + field
+	}
+	id := "id"
+	if q.Descending() {
+		id = "-id"
+	}
+
+	polls := make([]*Poll, 0)
+	err = c.Find(query).Sort(field, id).Limit(q.Limit + 1).All(&polls)
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	page = q.Page(polls)
+	return
+}
+
+// pollQuery converts the given PollQuery into a mongo query for polls within the given workspace.
+func pollQuery(workspace string, q *PollQuery) (query bson.M, err error) {
+	query = db.Scope(workspace, nil)
+	and := make([]bson.M, 0)
+
+	if q.Creator != "" {
+		query["creator"] = q.Creator
+	}
+
+	if q.Participant != "" {
+		query["participants"] = q.Participant
+		if q.Participant != q.Viewer {
+			query["visibility"] = bson.M{"$nin": []Visibility{Anonymous, Secret}}
+		}
+	}
+
+	if q.Restaurant != "" {
+		query["options.id"] = q.Restaurant
+	}
+
+	created := bson.M{}
+	if !q.CreatedAfter.IsZero() {
+		created["$gte"] = q.CreatedAfter
+	}
+	if !q.CreatedBefore.IsZero() {
+		created["$lt"] = q.CreatedBefore
+	}
+	if len(created) > 0 {
+		query["createdAt"] = created
+	}
+
+	// polls without a closing time are stored with either a zero closing time or, for polls created before closing times were introduced, no closing time at all.
+	switch q.State {
+	case PollClosed:
+		and = append(and, bson.M{"closesAt": bson.M{"$gt": time.Time{}, "$lte": q.Now}})
+	case PollOpen:
+		and = append(and, bson.M{"$or": []bson.M{
+			{"closesAt": bson.M{"$exists": false}},
+			{"closesAt": bson.M{"$lte": time.Time{}}},
+			{"closesAt": bson.M{"$gt": q.Now}},
+		}})
+	}
+
+	c, err := q.cursor()
+	if err != nil {
+		return
+	}
+
+	if c != nil {
+		op := "$gt"
+		if q.Descending() {
+			op = "$lt"
+		}
+		field := q.SortField()
+		and = append(and, bson.M{"$or": []bson.M{
+			{field: bson.M{op: c.Value}},
+			{field: c.Value, "id": bson.M{op: c.ID}},
+		}})
+	}
+
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return
+}
+
+// NewPoll creates a new poll within the mongo database for the given workspace, returning the created Poll object with a status and any errors
+// that occur while attempting to create the poll. Options not passing ValidateOptions are returned an 'Invalid' status.
+func (pm *MongoPollModel) NewPoll(workspace string, options []*restaurant.Building) (poll *Poll, status Status, err error) {
+	err = ValidateOptions(options)
+	if err != nil {
+		status = Invalid
+		return
+	}
+
 	err = pm.openSessionIfRequired()
 	if err != nil {
 		status = NoConnection
@@ -53,8 +208,10 @@ func (pm *MongoPollModel) NewPoll(options []*restaurant.Building) (poll *Poll, s
 	}
 
 	data := Poll{
-		ID:      bson.NewObjectId().Hex(),
-		Options: options,
+		ID:        bson.NewObjectId().Hex(),
+		Workspace: workspace,
+		Options:   options,
+		CreatedAt: time.Now(),
 	}
 
 	poll = &data
@@ -70,8 +227,15 @@ func (pm *MongoPollModel) NewPoll(options []*restaurant.Building) (poll *Poll, s
 	return
 }
 
-// UpdatePoll allows a poll stored within the mongo database to be updated with the contents of the specified Poll object.
+// UpdatePoll allows a poll stored within the mongo database to be updated with the contents of the specified Poll object, provided the stored poll belongs to the
+// same workspace as the specified Poll object. Polls not passing ValidatePoll are returned an 'Invalid' status.
 func (pm *MongoPollModel) UpdatePoll(p *Poll) (status Status, err error) {
+	err = ValidatePoll(p)
+	if err != nil {
+		status = Invalid
+		return
+	}
+
 	err = pm.openSessionIfRequired()
 	if err != nil {
 		status = NoConnection
@@ -79,7 +243,7 @@ func (pm *MongoPollModel) UpdatePoll(p *Poll) (status Status, err error) {
 	}
 
 	c := pm.session.DB(pm.DBName).C("polls")
-	err = c.Update(bson.M{"id": p.ID}, p)
+	err = c.Update(db.Scope(p.Workspace, bson.M{"id": p.ID}), p)
 	if err != nil {
 		status = NotFound
 	}
@@ -87,9 +251,49 @@ func (pm *MongoPollModel) UpdatePoll(p *Poll) (status Status, err error) {
 	return
 }
 
-// DeletePoll removes a specified poll from the mongo database. A status is returned detailing the status of the completed deletion, defaulting to Ok. Any errors
+// GetUnsettledPolls returns every poll stored within the mongo database, across all workspaces, with rules that closed at or before the given time without being settled.
+func (pm *MongoPollModel) GetUnsettledPolls(now time.Time) (polls []*Poll, status Status, err error) {
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	polls = make([]*Poll, 0)
+	c := pm.session.DB(pm.DBName).C("polls")
+	err = c.Find(bson.M{
+		"rules":    bson.M{"$exists": true},
+		"outcome":  bson.M{"$exists": false},
+		"closesAt": bson.M{"$gt": time.Time{}, "$lte": now},
+	}).All(&polls)
+	if err != nil {
+		status = NoConnection
+	}
+
+	return
+}
+
+// GetClosingPolls returns every poll stored within the mongo database whose closing time is after from and at or before to.
+func (pm *MongoPollModel) GetClosingPolls(from time.Time, to time.Time) (polls []*Poll, status Status, err error) {
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	polls = make([]*Poll, 0)
+	c := pm.session.DB(pm.DBName).C("polls")
+	err = c.Find(bson.M{"closesAt": bson.M{"$gt": from, "$lte": to}}).All(&polls)
+	if err != nil {
+		status = NoConnection
+	}
+
+	return
+}
+
+// DeletePoll removes a specified poll within the given workspace from the mongo database. A status is returned detailing the status of the completed deletion, defaulting to Ok. Any errors
 // occuring while deleting the specified poll are also returned.
-func (pm *MongoPollModel) DeletePoll(id string) (status Status, err error) {
+func (pm *MongoPollModel) DeletePoll(workspace string, id string) (status Status, err error) {
 	err = pm.openSessionIfRequired()
 	if err != nil {
 		status = NoConnection
@@ -97,7 +301,7 @@ func (pm *MongoPollModel) DeletePoll(id string) (status Status, err error) {
 	}
 
 	c := pm.session.DB(pm.DBName).C("polls")
-	err = c.Remove(bson.M{"id": id})
+	err = c.Remove(db.Scope(workspace, bson.M{"id": id}))
 
 	if err != nil {
 		status = NotFound
@@ -106,6 +310,63 @@ func (pm *MongoPollModel) DeletePoll(id string) (status Status, err error) {
 	return
 }
 
+// Aggregate runs the given aggregation pipeline against the polls collection, unmarshalling all resulting documents into result, which must be a pointer to a slice.
+func (pm *MongoPollModel) Aggregate(pipeline interface{}, result interface{}) (status Status, err error) {
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := pm.session.DB(pm.DBName).C("polls")
+	err = c.Pipe(pipeline).All(result)
+	if err != nil {
+		status = Invalid
+	}
+
+	return
+}
+
+// MigrateVoteKeys rekeys the votes of every stored poll whose votes are keyed by option name, as was the case before votes were keyed by option ID, returning the number of polls
+// migrated. Polls already keyed by ID are left untouched, allowing the migration to be run on every startup.
+func (pm *MongoPollModel) MigrateVoteKeys() (migrated int, status Status, err error) {
+	err = pm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := pm.session.DB(pm.DBName).C("polls")
+	iter := c.Find(nil).Iter()
+
+	for {
+		// each poll is decoded into a new value, as decoding into a used poll would merge its votes into the previous poll's.
+		var p Poll
+		if !iter.Next(&p) {
+			break
+		}
+
+		if !p.KeyVotesByID() {
+			continue
+		}
+
+		p.updateParticipants()
+		err = c.Update(db.Scope(p.Workspace, bson.M{"id": p.ID}), bson.M{"$set": bson.M{"votes": p.Votes, "participants": p.Participants}})
+		if err != nil {
+			iter.Close()
+			status = NoConnection
+			return
+		}
+		migrated++
+	}
+
+	err = iter.Close()
+	if err != nil {
+		status = NoConnection
+	}
+	return
+}
+
 // Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
 func (pm *MongoPollModel) Close() (err error) {
 	err = pm.Close()
@@ -113,19 +374,5 @@ func (pm *MongoPollModel) Close() (err error) {
 }
 
 func (pm *MongoPollModel) openSessionIfRequired() (err error) {
-	if pm.session == nil {
-		sessionMutex.Lock()
-		defer sessionMutex.Unlock()
-		if pm.session == nil {
-			pm.session, err = mgo.Dial(pm.URL)
-			if err != nil {
-				return
-			}
-
-			if pm.Username != "" && pm.Password != "" {
-				err = pm.session.Login(&mgo.Credential{Username: pm.Username, Password: pm.Password})
-			}
-		}
-	}
-	return
+	return db.OpenSessionIfRequired(&pm.session, pm.URL, pm.Username, pm.Password)
 }
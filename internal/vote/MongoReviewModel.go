@@ -0,0 +1,86 @@
+package vote
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/db"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoReviewModel provides a mongo based implementation to the ReviewModel interface.
+type MongoReviewModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// SaveReview upserts the given review within the mongo database, a unique index on the workspace, poll ID and user ensuring each user has a single review of each poll.
+func (rm *MongoReviewModel) SaveReview(r *Review) (status Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	c := rm.session.DB(rm.DBName).C("reviews")
+	err = c.EnsureIndex(mgo.Index{Key: []string{"workspace", "pollId", "user"}, Unique: true})
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	_, err = c.Upsert(db.Scope(r.Workspace, bson.M{"pollId": r.PollID, "user": r.User}), r)
+	if err != nil {
+		status = Invalid
+	}
+
+	return
+}
+
+// GetPollReviews returns the reviews stored within the mongo database for the poll with the given ID within the given workspace, ordered by when they were left.
+func (rm *MongoReviewModel) GetPollReviews(workspace string, pollID string) ([]*Review, Status, error) {
+	return rm.find(db.Scope(workspace, bson.M{"pollId": pollID}))
+}
+
+// GetRestaurantReviews returns the reviews stored within the mongo database within the given workspace of any of the restaurants with the given IDs.
+func (rm *MongoReviewModel) GetRestaurantReviews(workspace string, restaurantIDs []string) ([]*Review, Status, error) {
+	return rm.find(db.Scope(workspace, bson.M{"restaurantId": bson.M{"$in": restaurantIDs}}))
+}
+
+// GetReviews returns the reviews stored within the mongo database within the given workspace left at or after the given time.
+func (rm *MongoReviewModel) GetReviews(workspace string, since time.Time) ([]*Review, Status, error) {
+	return rm.find(db.Scope(workspace, bson.M{"createdAt": bson.M{"$gte": since}}))
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (rm *MongoReviewModel) Close() (err error) {
+	if rm.session != nil {
+		rm.session.Close()
+	}
+	return
+}
+
+func (rm *MongoReviewModel) find(query bson.M) (reviews []*Review, status Status, err error) {
+	err = rm.openSessionIfRequired()
+	if err != nil {
+		status = NoConnection
+		return
+	}
+
+	reviews = make([]*Review, 0)
+	c := rm.session.DB(rm.DBName).C("reviews")
+	err = c.Find(query).Sort("createdAt").All(&reviews)
+	if err != nil {
+		status = NoConnection
+	}
+
+	return
+}
+
+func (rm *MongoReviewModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&rm.session, rm.URL, rm.Username, rm.Password)
+}
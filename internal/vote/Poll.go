@@ -1,12 +1,110 @@
 package vote
 
-import "takeaway/takeaway-server/internal/restaurant"
+import (
+	"sort"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// PollState represents whether a poll is still accepting votes.
+type PollState string
+
+const (
+	// PollOpen states that a poll is still accepting votes.
+	PollOpen PollState = "open"
+	// PollClosed states that a poll's closing time has passed.
+	PollClosed PollState = "closed"
+)
+
+// Visibility represents who may see the votes cast within a poll.
+type Visibility string
+
+const (
+	// Public polls show every user which option each user has voted for.
+	Public Visibility = "public"
+	// Anonymous polls show the number of votes cast for each option, but never the users who cast them, each user only seeing their own vote.
+	Anonymous Visibility = "anonymous"
+	// Secret polls hide the number of votes cast for each option until the poll closes, after which they are shown as for anonymous polls.
+	Secret Visibility = "secret"
+)
 
 // Poll represents a singular vote within the system.
 type Poll struct {
-	ID      string                 `json:"id" bson:"id"`
-	Votes   map[string][]string    `json:"votes" bson:"votes"`
-	Options []*restaurant.Building `json:"options" bson:"options"`
+	ID        string                 `json:"id" bson:"id"`
+	Workspace string                 `json:"workspace" bson:"workspace"`
+	Creator   string                 `json:"creator" bson:"creator"`
+	Votes     map[string][]string    `json:"votes" bson:"votes"`
+	Options   []*restaurant.Building `json:"options" bson:"options"`
+	CreatedAt time.Time              `json:"createdAt" bson:"createdAt"`
+	// ClosesAt states when voting for the poll should end, a zero value stating the poll has no set closing time.
+	ClosesAt time.Time `json:"closesAt" bson:"closesAt"`
+	// Participants lists every user with a vote within the poll, kept alongside Votes to allow polls to be searched by participant.
+	Participants []string `json:"participants" bson:"participants"`
+	// AllowSuggestions states whether users other than the poll's organiser may suggest options, which are held within Pending until the organiser approves them.
+	AllowSuggestions bool             `json:"allowSuggestions" bson:"allowSuggestions"`
+	Pending          []*PendingOption `json:"pending" bson:"pending"`
+	// Visibility states who may see the poll's votes, an empty visibility being treated as Public.
+	Visibility Visibility `json:"visibility,omitempty" bson:"visibility,omitempty"`
+	// Rights holds the rights the organiser has given to participants, keyed by user, while Vetoed lists the users who have vetoed each option, keyed by option ID.
+	Rights map[string]Rights   `json:"rights,omitempty" bson:"rights,omitempty"`
+	Vetoed map[string][]string `json:"vetoed,omitempty" bson:"vetoed,omitempty"`
+	// Rules holds the requirements set by the organiser for the poll to be decided, with Outcome stating how the poll was settled against them and Extensions the number of
+	// times the poll has been extended for not meeting them.
+	Rules      *Rules  `json:"rules,omitempty" bson:"rules,omitempty"`
+	Outcome    Outcome `json:"outcome,omitempty" bson:"outcome,omitempty"`
+	Extensions int     `json:"extensions,omitempty" bson:"extensions,omitempty"`
+	// Office gives the location the poll's order is delivered to, nil should it be unknown.
+	Office *restaurant.Location `json:"office,omitempty" bson:"office,omitempty"`
+	// Unavailable lists the IDs of the options whose restaurant is closed at the poll's order time, votes for which are rejected.
+	Unavailable []string `json:"unavailable,omitempty" bson:"unavailable,omitempty"`
+	// Tally is only set for the views of polls returned by ViewFor, giving the raw and weighted votes for each option unless ResultsHidden states they are hidden. Counts is
+	// only set for views of private polls, giving the number of votes for each option in place of Votes.
+	Tally         []*OptionTally `json:"tally,omitempty" bson:"-"`
+	Counts        map[string]int `json:"counts,omitempty" bson:"-"`
+	ResultsHidden bool           `json:"resultsHidden,omitempty" bson:"-"`
+	// Delivery is only set for the views of polls with an office, reporting whether each option can deliver to the office at the poll's order time.
+	Delivery []*OptionDelivery `json:"delivery,omitempty" bson:"-"`
+	// Ratings is only set for polls returned by GetPoll, aggregating the reviews left for each option's restaurant across every poll within the workspace.
+	Ratings []*Rating `json:"ratings,omitempty" bson:"-"`
+}
+
+// PendingOption represents an option suggested by a voter, awaiting approval by the poll's organiser.
+type PendingOption struct {
+	Option      *restaurant.Building `json:"option" bson:"option"`
+	SuggestedBy string               `json:"suggestedBy" bson:"suggestedBy"`
+	SuggestedAt time.Time            `json:"suggestedAt" bson:"suggestedAt"`
+}
+
+// IsOrganiser returns whether the given user organises the poll, being the user who created it. Polls created without recording their creator are organised by every user.
+func (p *Poll) IsOrganiser(user string) bool {
+	return p.Creator == "" || p.Creator == user
+}
+
+// Suggest holds the given option as pending approval, suggested by the given user.
+func (p *Poll) Suggest(opt *restaurant.Building, user string, now time.Time) {
+	p.Pending = append(p.Pending, &PendingOption{Option: opt, SuggestedBy: user, SuggestedAt: now})
+}
+
+// Suggestion returns the pending suggestion of the option with the given ID, or nil should no such option be pending.
+func (p *Poll) Suggestion(id string) *PendingOption {
+	for _, s := range p.Pending {
+		if s.Option.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// TakeSuggestion removes the pending suggestion of the option with the given ID, returning the removed suggestion or nil should no such option be pending.
+func (p *Poll) TakeSuggestion(id string) *PendingOption {
+	for i, s := range p.Pending {
+		if s.Option.ID == id {
+			p.Pending = append(p.Pending[:i], p.Pending[i+1:]...)
+			return s
+		}
+	}
+	return nil
 }
 
 // AddOption allows for a restaurant to be added to the poll object.
@@ -29,6 +127,7 @@ func (p *Poll) AddVote(opt string, name string) {
 
 	p.ClearVotesFor(name)
 	p.Votes[opt] = append(p.Votes[opt], name)
+	p.updateParticipants()
 }
 
 // ClearVotesFor allows for the votes for a given user to be removed from the poll.
@@ -40,17 +139,254 @@ func (p *Poll) ClearVotesFor(user string) {
 			}
 		}
 	}
+	p.updateParticipants()
 }
 
-// RemoveOption allows for a given restaurant to be removed as an option within the poll. This does mean any votes currently cast for the given restaurant will be lost.
-func (p *Poll) RemoveOption(restaurant *restaurant.Building) {
-	for i, elem := range p.Options {
-		if elem == restaurant {
+// RemoveOption removes the option with the given ID from the poll, returning the removed option or nil should the poll not contain it. Any votes currently cast for the option will
+// be lost, so should be moved beforehand using MoveVotes if they are to be kept.
+func (p *Poll) RemoveOption(id string) (removed *restaurant.Building) {
+	for i, opt := range p.Options {
+		if opt.ID == id {
+			removed = opt
 			p.Options = append(p.Options[:i], p.Options[i+1:]...)
+			break
+		}
+	}
+
+	if removed != nil {
+		delete(p.Votes, id)
+		delete(p.Vetoed, id)
+	}
+	p.updateParticipants()
+	return
+}
+
+// RenameOption changes the name and address of the option with the given ID, returning false should the poll not contain it. Votes are keyed by the option's ID so are kept.
+func (p *Poll) RenameOption(id string, name string, address string) bool {
+	opt := p.Option(id)
+	if opt == nil {
+		return false
+	}
+
+	opt.Name = name
+	opt.Address = address
+	return true
+}
+
+// MoveVotes moves every vote cast for the option with ID from to the option with ID to, leaving no votes for the former.
+func (p *Poll) MoveVotes(from string, to string) {
+	if from == to || len(p.Votes[from]) == 0 {
+		return
+	}
+
+	p.Votes[to] = append(p.Votes[to], p.Votes[from]...)
+	delete(p.Votes, from)
+}
+
+// KeyVotesByID rekeys votes stored against the name of an option, as polls created before votes were keyed by option ID may hold, to the ID of said option. Votes for keys matching
+// neither an option's ID nor its name are left unchanged. Returns whether any votes were moved.
+func (p *Poll) KeyVotesByID() (changed bool) {
+	for _, opt := range p.Options {
+		if opt.ID == "" || opt.Name == opt.ID {
+			continue
 		}
+		if _, ok := p.Votes[opt.Name]; ok && p.Option(opt.Name) == nil {
+			p.MoveVotes(opt.Name, opt.ID)
+			// keys without votes are removed too, as MoveVotes ignores them.
+			delete(p.Votes, opt.Name)
+			changed = true
+		}
+	}
+	return
+}
+
+// Option returns the option with the given ID, or nil should the poll not contain an option with said ID.
+func (p *Poll) Option(id string) *restaurant.Building {
+	for _, opt := range p.Options {
+		if opt.ID == id {
+			return opt
+		}
+	}
+	return nil
+}
+
+// State returns whether the poll is open or closed at the given time.
+func (p *Poll) State(now time.Time) PollState {
+	if !p.ClosesAt.IsZero() && !p.ClosesAt.After(now) {
+		return PollClosed
+	}
+	return PollOpen
+}
+
+// IsPrivate returns whether the users who voted for each option are hidden, being the case for anonymous and secret polls.
+func (p *Poll) IsPrivate() bool {
+	return p.Visibility == Anonymous || p.Visibility == Secret
+}
+
+// ViewFor returns the poll as it should be seen by the given user at the given time, holding the poll's results within Tally and, for polls with an office, whether each option
+// can deliver to the office within Delivery. The votes of private polls are replaced by only the given user's own vote along with the number of votes cast for each option, the
+// results being left out of secret polls until they close. Every other user's name is removed from the participants, rights, vetoes and tallied vetoes of private polls, other
+// than the rights shown to the organiser who set them. Views are returned unchanged, allowing polls received from broadcasts to be viewed again.
+func (p *Poll) ViewFor(user string, now time.Time) *Poll {
+	if p.Tally != nil || p.ResultsHidden {
+		return p
+	}
+
+	v := p.Copy()
+	if p.Office != nil {
+		v.Delivery = p.Deliveries(now)
+	}
+	v.ResultsHidden = p.Visibility == Secret && p.State(now) == PollOpen
+	if !v.ResultsHidden {
+		v.Tally = p.Results()
+	}
+
+	if !p.IsPrivate() {
+		return v
+	}
+
+	v.Votes = make(map[string][]string)
+	v.Counts = make(map[string]int)
+	v.Participants = make([]string, 0)
+	for opt, users := range p.Votes {
+		if len(users) > 0 && !v.ResultsHidden {
+			v.Counts[opt] = len(users)
+		}
+		if user != "" && contains(users, user) {
+			v.Votes[opt] = []string{user}
+			v.Participants = []string{user}
+		}
+	}
+
+	v.Vetoed = nil
+	for _, opt := range p.VetoesBy(user) {
+		if v.Vetoed == nil {
+			v.Vetoed = make(map[string][]string)
+		}
+		v.Vetoed[opt] = []string{user}
+	}
+	for _, t := range v.Tally {
+		t.VetoedBy = v.Vetoed[t.OptionID]
+	}
+
+	if !p.IsOrganiser(user) {
+		v.Rights = nil
+		if r, ok := p.Rights[user]; ok && user != "" {
+			v.Rights = map[string]Rights{user: r}
+		}
+	}
+	return v
+}
+
+// Winner returns the option with the most weighted votes that has not been vetoed, with ties being resolved in favour of the option listed first within the poll. Should no votes
+// have been cast for any of the poll's options, or every option with votes have been vetoed, nil will be returned.
+func (p *Poll) Winner() (winner *restaurant.Building) {
+	most := 0
+	for _, t := range p.Results() {
+		if !t.Vetoed && t.Weighted > most {
+			most = t.Weighted
+			winner = p.Option(t.OptionID)
+		}
+	}
+	return
+}
+
+// Copy creates a deep copy of the poll, ensuring changes made to the returned poll do not affect the original.
+func (p *Poll) Copy() *Poll {
+	c := *p
+
+	c.Participants = append([]string(nil), p.Participants...)
+
+	if p.Unavailable != nil {
+		c.Unavailable = append([]string(nil), p.Unavailable...)
 	}
 
 	if p.Votes != nil {
-		p.Votes[restaurant.Name] = nil
+		c.Votes = make(map[string][]string)
+		for k, v := range p.Votes {
+			c.Votes[k] = append([]string(nil), v...)
+		}
+	}
+
+	if p.Options != nil {
+		c.Options = make([]*restaurant.Building, 0, len(p.Options))
+		for _, opt := range p.Options {
+			c.Options = append(c.Options, opt.Copy())
+		}
+	}
+
+	if p.Rights != nil {
+		c.Rights = make(map[string]Rights)
+		for k, v := range p.Rights {
+			c.Rights[k] = v
+		}
+	}
+
+	if p.Vetoed != nil {
+		c.Vetoed = make(map[string][]string)
+		for k, v := range p.Vetoed {
+			c.Vetoed[k] = append([]string(nil), v...)
+		}
+	}
+
+	if p.Rules != nil {
+		r := *p.Rules
+		r.Invited = append([]string(nil), p.Rules.Invited...)
+		c.Rules = &r
+	}
+
+	if p.Office != nil {
+		o := *p.Office
+		c.Office = &o
+	}
+
+	if p.Delivery != nil {
+		c.Delivery = make([]*OptionDelivery, 0, len(p.Delivery))
+		for _, d := range p.Delivery {
+			dc := *d
+			c.Delivery = append(c.Delivery, &dc)
+		}
+	}
+
+	if p.Tally != nil {
+		c.Tally = make([]*OptionTally, 0, len(p.Tally))
+		for _, t := range p.Tally {
+			tc := *t
+			tc.VetoedBy = append([]string(nil), t.VetoedBy...)
+			c.Tally = append(c.Tally, &tc)
+		}
+	}
+
+	if p.Counts != nil {
+		c.Counts = make(map[string]int)
+		for k, v := range p.Counts {
+			c.Counts[k] = v
+		}
+	}
+
+	if p.Pending != nil {
+		c.Pending = make([]*PendingOption, 0, len(p.Pending))
+		for _, s := range p.Pending {
+			sc := *s
+			sc.Option = s.Option.Copy()
+			c.Pending = append(c.Pending, &sc)
+		}
+	}
+
+	return &c
+}
+
+// updateParticipants recalculates the poll's participants from its votes.
+func (p *Poll) updateParticipants() {
+	voted := make(map[string]bool)
+	p.Participants = make([]string, 0)
+	for _, voters := range p.Votes {
+		for _, u := range voters {
+			if !voted[u] {
+				voted[u] = true
+				p.Participants = append(p.Participants, u)
+			}
+		}
 	}
+	sort.Strings(p.Participants)
 }
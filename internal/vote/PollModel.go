@@ -1,32 +1,66 @@
 package vote
 
 import (
+	"time"
+
 	"takeaway/takeaway-server/internal/restaurant"
 )
 
 var instance *Container
 
-// PollModel defines a contract for how the system should interact with the database for accessing poll information.
+// PollModel defines a contract for how the system should interact with the database for accessing poll information. Every poll belongs to a workspace, with polls only being
+// accessible through the workspace they belong to. The default workspace is represented by an empty string.
 type PollModel interface {
-	// GetPoll allows for a singular poll to be accessed, using its ID. Should any issue occur while attempting to access the poll specified by the ID, an error will be returned. Should a poll be
-	// located using the specified ID, the poll will be returned as a pointer to a Poll object.
-	GetPoll(id string) (*Poll, Status, error)
-	// NewPoll allows for a new poll to be created, given a slice of options. Should a poll be able to be created properly a pointer to said poll will be returned. Should an error occur while
-	// creating a poll, an error should be returned with the returned poll being nil.
-	NewPoll(options []*restaurant.Building) (*Poll, Status, error)
-	// UpdatePoll takes a Poll object as an argument representing the updated state of a poll. This Poll object will be used to update the currently stored poll. Any errors that occur while
-	// attempting to update the poll object will be returned by the function. A status is also returned by the function specifying the status of the update action.
+	// GetPoll allows for a singular poll within the given workspace to be accessed, using its ID. Should any issue occur while attempting to access the poll specified by the ID, an error will be
+	// returned. Should a poll be located using the specified ID, the poll will be returned as a pointer to a Poll object.
+	GetPoll(workspace string, id string) (*Poll, Status, error)
+	// GetPolls returns all polls within the given workspace created at or after the given time, ordered by creation time. Passing a zero time returns every poll within the workspace.
+	GetPolls(workspace string, since time.Time) ([]*Poll, Status, error)
+	// ListPolls returns the page of polls within the given workspace matching the given query, returning an Invalid status should the query not be valid.
+	ListPolls(workspace string, q *PollQuery) (*PollPage, Status, error)
+	// GetUnsettledPolls returns every poll, across all workspaces, with rules that closed at or before the given time without being settled against its rules.
+	GetUnsettledPolls(now time.Time) ([]*Poll, Status, error)
+	// GetClosingPolls returns every poll, across all workspaces, whose closing time is after from and at or before to.
+	GetClosingPolls(from time.Time, to time.Time) ([]*Poll, Status, error)
+	// NewPoll allows for a new poll to be created within the given workspace, given a slice of options. Should a poll be able to be created properly a pointer to said poll will be returned. Should
+	// an error occur while creating a poll, an error should be returned with the returned poll being nil. Options not passing ValidateOptions must be rejected with an Invalid status and
+	// the *ValidationError describing them.
+	NewPoll(workspace string, options []*restaurant.Building) (*Poll, Status, error)
+	// UpdatePoll takes a Poll object as an argument representing the updated state of a poll. This Poll object will be used to update the currently stored poll with the same ID within the poll's
+	// workspace. Any errors that occur while attempting to update the poll object will be returned by the function. A status is also returned by the function specifying the status of the update
+	// action. Polls not passing ValidatePoll must be rejected with an Invalid status and the *ValidationError describing them.
 	UpdatePoll(p *Poll) (Status, error)
-	// DeletePoll attempts to delete a poll from the system with the corresponding passed ID. A status will be returned detailing the status of the operation along with any errors that occur while
-	// attempting to delete the given ID.
-	DeletePoll(id string) (Status, error)
+	// DeletePoll attempts to delete a poll from the given workspace with the corresponding passed ID. A status will be returned detailing the status of the operation along with any errors that
+	// occur while attempting to delete the given ID.
+	DeletePoll(workspace string, id string) (Status, error)
 	// Close allows for a PollModel connection to be closed.
 	Close() error
 }
 
-// Container provides access to injected implementation of PollModel for the application.
+// Suggester defines a contract for components able to suggest restaurants to include as options within a new poll.
+type Suggester interface {
+	// SuggestOptions returns up to n restaurants suited to the given users of the given workspace, best suggestion first. An empty slice of users suggests restaurants for the workspace
+	// as a whole.
+	SuggestOptions(workspace string, users []string, n int) ([]*restaurant.Building, error)
+}
+
+// Publisher defines a contract for components notified of every change made to a poll, such as the webhooks of the poll's workspace. Publish is called while the change is being
+// made so should not block.
+type Publisher interface {
+	// Publish notifies the publisher of the given event, along with the poll as it is seen by a user who has not voted once the event has been applied.
+	Publish(e *Event, p *Poll)
+}
+
+// Container provides access to injected implementations of PollModel and EventModel for the application, along with an optional Suggester used to populate new polls, an
+// optional Geocoder used to locate options and offices given by their address and an optional Publisher notified of every change made to a poll.
 type Container struct {
-	Model PollModel `inject:""`
+	Model  PollModel  `inject:""`
+	Events EventModel `inject:""`
+	// Reviews stores the reviews of closed polls, polls and their options going unreviewed should it be nil.
+	Reviews   ReviewModel
+	Suggester Suggester
+	Geocoder  restaurant.Geocoder
+	Publisher Publisher
 }
 
 // Init allows the vote package to be initialised with the Container c.
@@ -46,4 +80,8 @@ const (
 	NotFound Status = iota + 1
 	// Invalid states that a given input is not valid.
 	Invalid Status = iota + 1
+	// Conflict states that a change cannot be made to a poll in its current state.
+	Conflict Status = iota + 1
+	// Forbidden states that the user making a change to a poll is not allowed to make it.
+	Forbidden Status = iota + 1
 )
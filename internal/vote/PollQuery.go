@@ -0,0 +1,213 @@
+package vote
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPollLimit is the number of polls returned within a page should a query not specify a limit.
+	DefaultPollLimit = 20
+	// MaxPollLimit is the largest number of polls that can be returned within a single page.
+	MaxPollLimit = 100
+)
+
+// sortFields maps the fields polls can be sorted by to the Poll's value for said field.
+var sortFields = map[string]func(p *Poll) time.Time{
+	"createdAt": func(p *Poll) time.Time { return p.CreatedAt },
+	"closesAt":  func(p *Poll) time.Time { return p.ClosesAt },
+}
+
+// PollQuery specifies which polls within a workspace should be listed, along with their order and the page of results to return. Zero values for any filter state that polls
+// should not be filtered by said field.
+type PollQuery struct {
+	// Creator restricts the polls listed to those created by the given user.
+	Creator string
+	// Participant restricts the polls listed to those the given user has voted within. Private polls are only listed when the participant is the Viewer, the user listing
+	// the polls, as listing them would otherwise reveal who voted within them.
+	Participant string
+	Viewer      string
+	// State restricts the polls listed to those that are either open or closed.
+	State PollState
+	// CreatedAfter and CreatedBefore restrict the polls listed to those created within the given range, CreatedAfter being inclusive and CreatedBefore exclusive.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Restaurant restricts the polls listed to those with the restaurant with the given ID as an option.
+	Restaurant string
+	// Sort states the field polls are ordered by, being either 'createdAt' or 'closesAt', with a leading '-' stating the polls are ordered in descending order. Defaults to
+	// '-createdAt', listing the newest polls first.
+	Sort string
+	// Cursor is the cursor returned with the previous page of results, with an empty cursor returning the first page.
+	Cursor string
+	// Limit is the maximum number of polls to return, defaulting to DefaultPollLimit.
+	Limit int
+	// Now is the time used to decide whether a poll is open or closed, defaulting to the current time.
+	Now time.Time
+}
+
+// PollPage represents a single page of polls returned by a PollQuery.
+type PollPage struct {
+	Polls []*Poll `json:"polls"`
+	// NextCursor is the cursor used to fetch the next page of polls, being empty should there be no more polls to list.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// pollCursor is the decoded form of a page's cursor, recording the sort value and ID of the last poll within the page.
+type pollCursor struct {
+	Value time.Time `json:"v"`
+	ID    string    `json:"id"`
+}
+
+// Normalise fills in the query's defaults, returning an error should the query not be valid.
+func (q *PollQuery) Normalise() (err error) {
+	if q.Sort == "" {
+		q.Sort = "-createdAt"
+	}
+
+	if _, ok := sortFields[q.SortField()]; !ok {
+		return fmt.Errorf("polls cannot be sorted by %s", q.Sort)
+	}
+
+	if q.State != "" && q.State != PollOpen && q.State != PollClosed {
+		return fmt.Errorf("%s is not a valid poll state", q.State)
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultPollLimit
+	}
+
+	if q.Limit < 0 || q.Limit > MaxPollLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxPollLimit)
+	}
+
+	if q.Now.IsZero() {
+		q.Now = time.Now()
+	}
+
+	_, err = q.cursor()
+	return
+}
+
+// SortField returns the field polls are sorted by, without any direction.
+func (q *PollQuery) SortField() string {
+	return strings.TrimPrefix(q.Sort, "-")
+}
+
+// Descending returns whether polls are sorted in descending order.
+func (q *PollQuery) Descending() bool {
+	return strings.HasPrefix(q.Sort, "-")
+}
+
+// Matches returns whether the given poll meets the filters of the query, ignoring the query's cursor.
+func (q *PollQuery) Matches(p *Poll) bool {
+	if q.Creator != "" && p.Creator != q.Creator {
+		return false
+	}
+
+	if q.Participant != "" && (!contains(p.Participants, q.Participant) || (p.IsPrivate() && q.Participant != q.Viewer)) {
+		return false
+	}
+
+	if q.State != "" && p.State(q.Now) != q.State {
+		return false
+	}
+
+	if !q.CreatedAfter.IsZero() && p.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+
+	if !q.CreatedBefore.IsZero() && !p.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+
+	if q.Restaurant != "" {
+		found := false
+		for _, o := range p.Options {
+			if o.ID == q.Restaurant {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply filters, sorts and pages the given polls in memory, for use by PollModels unable to run the query within their datasource. The query must have been normalised.
+func (q *PollQuery) Apply(polls []*Poll) (page *PollPage, err error) {
+	c, err := q.cursor()
+	if err != nil {
+		return
+	}
+
+	value := sortFields[q.SortField()]
+
+	matched := make([]*Poll, 0)
+	for _, p := range polls {
+		if q.Matches(p) && (c == nil || q.after(value(p), p.ID, c)) {
+			matched = append(matched, p)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return q.after(value(matched[j]), matched[j].ID, &pollCursor{Value: value(matched[i]), ID: matched[i].ID})
+	})
+
+	return q.Page(matched), nil
+}
+
+// Page creates a page from the given sorted polls, which should contain up to one more poll than the query's limit to allow the presence of a following page to be detected.
+func (q *PollQuery) Page(polls []*Poll) *PollPage {
+	page := &PollPage{Polls: polls}
+	if len(polls) > q.Limit {
+		page.Polls = polls[:q.Limit]
+
+		last := page.Polls[len(page.Polls)-1]
+		b, _ := json.Marshal(pollCursor{Value: sortFields[q.SortField()](last), ID: last.ID})
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return page
+}
+
+// cursor decodes the query's cursor, returning nil should the query not have a cursor.
+func (q *PollQuery) cursor() (c *pollCursor, err error) {
+	if q.Cursor == "" {
+		return
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err == nil {
+		c = &pollCursor{}
+		err = json.Unmarshal(b, c)
+	}
+
+	if err != nil {
+		c = nil
+		err = fmt.Errorf("the cursor %s is not valid", q.Cursor)
+	}
+	return
+}
+
+// after returns whether a poll with the given sort value and ID is ordered after the given cursor, with ties between sort values broken by ID.
+func (q *PollQuery) after(value time.Time, id string, c *pollCursor) bool {
+	if !value.Equal(c.Value) {
+		return value.After(c.Value) != q.Descending()
+	}
+	return (id > c.ID) != q.Descending() && id != c.ID
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
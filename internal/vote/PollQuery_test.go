@@ -0,0 +1,141 @@
+package vote
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+var queryStart = time.Date(2020, time.March, 2, 12, 0, 0, 0, time.UTC)
+
+func queryPolls() []*Poll {
+	polls := make([]*Poll, 0)
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		p := &Poll{
+			ID:        id,
+			Creator:   "Jack",
+			CreatedAt: queryStart.Add(time.Duration(i) * time.Hour),
+			Options:   []*restaurant.Building{{ID: "r1"}},
+		}
+		if i%2 == 0 {
+			p.Creator = "Tom"
+			p.ClosesAt = queryStart.Add(time.Duration(i)*time.Hour + time.Minute)
+			p.Options = append(p.Options, &restaurant.Building{ID: "r2"})
+		}
+		p.AddVote("r1", id)
+		polls = append(polls, p)
+	}
+	return polls
+}
+
+func ids(page *PollPage) (s string) {
+	for _, p := range page.Polls {
+		s += p.ID
+	}
+	return
+}
+
+func TestListPollsDefaultOrder(t *testing.T) {
+	q := &PollQuery{}
+	if err := q.Normalise(); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := q.Apply(queryPolls())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids(page) != "edcba" || page.NextCursor != "" {
+		t.Logf("Expected newest polls first with no next page, got %s and cursor %s", ids(page), page.NextCursor)
+		t.Fail()
+	}
+}
+
+func TestListPollsFilters(t *testing.T) {
+	q := &PollQuery{
+		Creator:      "Tom",
+		Restaurant:   "r2",
+		State:        PollClosed,
+		CreatedAfter: queryStart.Add(time.Hour),
+		Sort:         "createdAt",
+		Now:          queryStart.Add(3 * time.Hour),
+	}
+	if err := q.Normalise(); err != nil {
+		t.Fatal(err)
+	}
+
+	page, _ := q.Apply(queryPolls())
+	if ids(page) != "c" {
+		t.Logf("Expected only poll c to match the filters, got %s", ids(page))
+		t.Fail()
+	}
+
+	q = &PollQuery{Participant: "b"}
+	q.Normalise()
+	page, _ = q.Apply(queryPolls())
+	if ids(page) != "b" {
+		t.Logf("Expected only poll b to have participant b, got %s", ids(page))
+		t.Fail()
+	}
+	private := queryPolls()
+	for _, p := range private {
+		p.Visibility = Anonymous
+	}
+	q = &PollQuery{Participant: "b"}
+	q.Normalise()
+	if page, _ = q.Apply(private); ids(page) != "" {
+		t.Logf("Expected private polls not to be listed by participant, got %s", ids(page))
+		t.Fail()
+	}
+	q.Viewer = "b"
+	if page, _ = q.Apply(private); ids(page) != "b" {
+		t.Logf("Expected participants to list their own private polls, got %s", ids(page))
+		t.Fail()
+	}
+}
+
+func TestListPollsPaging(t *testing.T) {
+	polls := queryPolls()
+	// polls created at the same time are ordered by ID.
+	polls[3].CreatedAt = polls[1].CreatedAt
+
+	seen := ""
+	q := &PollQuery{Sort: "createdAt", Limit: 2}
+	for i := 0; i < 5; i++ {
+		if err := q.Normalise(); err != nil {
+			t.Fatal(err)
+		}
+
+		page, err := q.Apply(polls)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seen += ids(page) + "|"
+		if page.NextCursor == "" {
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+
+	if seen != "ab|dc|e|" {
+		t.Logf("Expected pages ab|dc|e|, got %s", seen)
+		t.Fail()
+	}
+}
+
+func TestListPollsInvalid(t *testing.T) {
+	for _, q := range []*PollQuery{
+		{Sort: "name"},
+		{State: "pending"},
+		{Limit: MaxPollLimit + 1},
+		{Cursor: "not a cursor"},
+	} {
+		if err := q.Normalise(); err == nil {
+			t.Logf("Expected query %+v to be invalid", q)
+			t.Fail()
+		}
+	}
+}
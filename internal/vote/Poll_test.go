@@ -1,19 +1,25 @@
 package vote
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"takeaway/takeaway-server/internal/restaurant"
 )
 
 var (
 	res = &restaurant.Building{
+		ID:   "r1",
 		Name: "r1",
 	}
 	res2 = &restaurant.Building{
+		ID:   "r2",
 		Name: "r2",
 	}
 	newRestaurant = &restaurant.Building{
+		ID:   "new",
 		Name: "New Restaurant",
 	}
 )
@@ -104,18 +110,19 @@ func TestAddOptionNoOptions(t *testing.T) {
 
 func TestRemoveOption(t *testing.T) {
 	poll, _ := beforeEach()
-	poll.RemoveOption(res)
+	removed := poll.RemoveOption(res.ID)
 
-	if poll.Options[0] == res {
+	if removed != res || poll.Options[0] == res {
 		t.Fail()
-	} else if poll.Votes[res.Name] != nil {
+	} else if _, ok := poll.Votes[res.ID]; ok {
+		t.Log("Votes for the removed option have been kept")
 		t.Fail()
 	}
 }
 
 func TestRemoveOptionNoOptions(t *testing.T) {
 	_, empt := beforeEach()
-	empt.RemoveOption(res)
+	empt.RemoveOption(res.ID)
 
 	if empt.Options != nil {
 		t.Fail()
@@ -126,18 +133,76 @@ func TestRemoveOptionNoOptions(t *testing.T) {
 
 func TestRemoveOptionNotAnOption(t *testing.T) {
 	poll, _ := beforeEach()
-	poll.RemoveOption(newRestaurant)
+	poll.RemoveOption(newRestaurant.ID)
 
 	if !restaurantsContains(poll.Options, res) || !restaurantsContains(poll.Options, res2) {
 		t.Log("Poll options have been altered after attempting to remove an option that does not exist within the poll")
 		t.Fail()
-	} else if !stringsContains(poll.Votes[res.Name], "Jack", "Tom") || !stringsContains(poll.Votes[res2.Name], "Will", "TJ") {
+	} else if !stringsContains(poll.Votes[res.ID], "Jack", "Tom") || !stringsContains(poll.Votes[res2.ID], "Will", "TJ") {
 		t.Logf("Poll Votes: %v", poll.Votes)
 		t.Log("Poll votes have been altered after attempting to remove an option that does not exist within the poll")
 		t.Fail()
 	}
 }
 
+func TestRemoveOptionDecoded(t *testing.T) {
+	poll, _ := beforeEach()
+	// options decoded from a request are different values to those within the poll, so must be matched by ID.
+	decoded := *res2
+	poll.RemoveOption(decoded.ID)
+
+	if len(poll.Options) != 1 || poll.Options[0] != res {
+		t.Logf("Expected option r2 to be removed, got %v", poll.Options)
+		t.Fail()
+	}
+}
+
+func TestRenameOption(t *testing.T) {
+	poll, _ := beforeEach()
+
+	if !poll.RenameOption("r1", "Renamed", "Address") || poll.Option("r1").Name != "Renamed" || !stringsContains(poll.Votes["r1"], "Jack", "Tom") {
+		t.Log("Expected option r1 to be renamed while keeping its votes")
+		t.Fail()
+	}
+
+	if poll.RenameOption("unknown", "Renamed", "") {
+		t.Log("Expected renaming an unknown option to fail")
+		t.Fail()
+	}
+}
+
+func TestMoveVotes(t *testing.T) {
+	poll, _ := beforeEach()
+	poll.MoveVotes("r1", "r2")
+
+	if _, ok := poll.Votes["r1"]; ok || !stringsContains(poll.Votes["r2"], "Jack", "Tom", "Will", "TJ") {
+		t.Logf("Expected every vote to be moved to r2, got %v", poll.Votes)
+		t.Fail()
+	}
+}
+
+func TestKeyVotesByID(t *testing.T) {
+	poll := &Poll{
+		Options: []*restaurant.Building{{ID: "a1", Name: "Pizza"}, {ID: "b2", Name: "Curry"}},
+		Votes:   map[string][]string{"Pizza": {"Jack"}, "b2": {"Tom"}, "Curry": nil},
+	}
+
+	if !poll.KeyVotesByID() {
+		t.Log("Expected votes keyed by name to be migrated")
+		t.Fail()
+	}
+
+	if len(poll.Votes) != 2 || !stringsContains(poll.Votes["a1"], "Jack") || !stringsContains(poll.Votes["b2"], "Tom") {
+		t.Logf("Expected votes to be keyed by ID, got %v", poll.Votes)
+		t.Fail()
+	}
+
+	if poll.KeyVotesByID() {
+		t.Log("Expected migrated poll to be left unchanged")
+		t.Fail()
+	}
+}
+
 func restaurantsContains(s []*restaurant.Building, elem *restaurant.Building) (found bool) {
 	for _, item := range s {
 		if item == elem {
@@ -163,3 +228,173 @@ func stringsContains(s []string, elems ...string) (found bool) {
 	found = true
 	return
 }
+
+func TestWinner(t *testing.T) {
+	p := &Poll{
+		Votes:   createVotes(),
+		Options: []*restaurant.Building{{ID: "r1"}, {ID: "r2"}},
+	}
+	p.AddVote("r2", "test")
+
+	if w := p.Winner(); w == nil || w.ID != "r2" {
+		t.Fail()
+	}
+}
+
+func TestWinnerNoVotes(t *testing.T) {
+	_, empt := beforeEach()
+	empt.AddOption(newRestaurant)
+
+	if empt.Winner() != nil {
+		t.Fail()
+	}
+}
+
+func TestViewForPublic(t *testing.T) {
+	p, _ := beforeEach()
+
+	v := p.ViewFor("Jack", time.Now())
+	if len(v.Votes["r1"]) != 2 || len(v.Votes["r2"]) != 2 || v.Counts != nil {
+		t.Logf("Expected every vote of a public poll to be seen, got %v", v.Votes)
+		t.Fail()
+	}
+
+	if len(v.Tally) != 2 || v.Tally[0].Votes != 2 || v.Tally[0].Weighted != 2 || p.Tally != nil {
+		t.Logf("Expected the view to hold the poll's results, got %v", v.Tally)
+		t.Fail()
+	}
+}
+
+func TestViewForAnonymous(t *testing.T) {
+	p, _ := beforeEach()
+	p.Visibility = Anonymous
+
+	v := p.ViewFor("Jack", time.Now())
+	if len(v.Votes) != 1 || len(v.Votes["r1"]) != 1 || v.Votes["r1"][0] != "Jack" {
+		t.Logf("Expected only Jack's own vote to be seen, got %v", v.Votes)
+		t.Fail()
+	}
+
+	if v.Counts["r1"] != 2 || v.Counts["r2"] != 2 || v.ResultsHidden {
+		t.Logf("Expected the number of votes for each option to be seen, got %v", v.Counts)
+		t.Fail()
+	}
+
+	if len(p.Votes["r1"]) != 2 {
+		t.Log("Expected viewing the poll to leave its votes unchanged")
+		t.Fail()
+	}
+
+	if v.ViewFor("Tom", time.Now()) != v {
+		t.Log("Expected a view to be viewed as it is")
+		t.Fail()
+	}
+}
+
+func TestViewForSecret(t *testing.T) {
+	p, _ := beforeEach()
+	p.Visibility = Secret
+	p.ClosesAt = time.Date(2018, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	v := p.ViewFor("", p.ClosesAt.Add(-time.Minute))
+	if len(v.Votes) != 0 || len(v.Counts) != 0 || !v.ResultsHidden || v.Winner() != nil {
+		t.Logf("Expected the results of an open secret poll to be hidden, got %v %v", v.Votes, v.Counts)
+		t.Fail()
+	}
+
+	v = p.ViewFor("", p.ClosesAt)
+	if v.Counts["r1"] != 2 || v.ResultsHidden || v.Winner() == nil || v.Winner().ID != "r1" {
+		t.Logf("Expected the results of a closed secret poll to be revealed, got %v", v.Counts)
+		t.Fail()
+	}
+}
+
+func TestViewForPrivateHidesVoters(t *testing.T) {
+	for _, visibility := range []Visibility{Anonymous, Secret} {
+		p, _ := beforeEach()
+		p.Creator = "Sam"
+		p.Visibility = visibility
+		p.updateParticipants()
+		p.SetRights("Jack", Rights{Weight: 2})
+		p.SetRights("Will", Rights{Weight: 3})
+		p.Vetoed = map[string][]string{"r1": {"Tom"}, "r2": {"Jack", "TJ"}}
+		p.ClosesAt = time.Now().Add(-time.Minute)
+
+		b, err := json.Marshal(p.ViewFor("Jack", time.Now()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, other := range []string{"Tom", "Will", "TJ"} {
+			if strings.Contains(string(b), other) {
+				t.Logf("Expected %s to be hidden from Jack within a %s poll, got %s", other, visibility, b)
+				t.Fail()
+			}
+		}
+
+		v := p.ViewFor("Jack", time.Now())
+		if len(v.Participants) != 1 || v.Participants[0] != "Jack" || v.Rights["Jack"].Weight != 2 {
+			t.Logf("Expected Jack to see only their own participation and rights, got %v %v", v.Participants, v.Rights)
+			t.Fail()
+		}
+		if v := p.ViewFor("Jack", time.Now()); len(v.Tally) != 2 || v.Tally[0].VetoedBy != nil || len(v.Tally[1].VetoedBy) != 1 || !v.Tally[0].Vetoed {
+			t.Logf("Expected the closed %s poll's tally to show which options were vetoed but only Jack's own vetoes, got %+v %+v", visibility, v.Tally[0], v.Tally[1])
+			t.Fail()
+		}
+		if v := p.ViewFor("Sam", time.Now()); len(v.Participants) != 0 || len(v.Rights) != 2 {
+			t.Logf("Expected the organiser to see the rights they set but no participants, got %v %v", v.Participants, v.Rights)
+			t.Fail()
+		}
+	}
+}
+
+func TestWinnerWeighted(t *testing.T) {
+	p, _ := beforeEach()
+	p.SetRights("Will", Rights{Weight: 3})
+
+	results := p.Results()
+	if results[1].Votes != 2 || results[1].Weighted != 4 || results[0].Weighted != 2 {
+		t.Logf("Expected Will's vote to count three times, got %v %v", results[0], results[1])
+		t.Fail()
+	}
+
+	if w := p.Winner(); w == nil || w.ID != "r2" {
+		t.Logf("Expected r2 to win by weighted votes, got %v", w)
+		t.Fail()
+	}
+}
+
+func TestWinnerVetoed(t *testing.T) {
+	p, _ := beforeEach()
+	p.AddVote("r1", "Kate")
+	p.SetRights("Will", Rights{Weight: 1, Vetoes: 1})
+	p.Veto("r1", "Will")
+
+	if w := p.Winner(); w == nil || w.ID != "r2" {
+		t.Logf("Expected the vetoed r1 to not win, got %v", w)
+		t.Fail()
+	}
+
+	if r := p.Results()[0]; !r.Vetoed || len(r.VetoedBy) != 1 || r.VetoedBy[0] != "Will" {
+		t.Logf("Expected r1 to be shown as vetoed by Will, got %v", r)
+		t.Fail()
+	}
+}
+
+func TestSetRightsWithdrawsVetoes(t *testing.T) {
+	p, _ := beforeEach()
+	p.SetRights("Will", Rights{Weight: 1, Vetoes: 2})
+	p.Veto("r1", "Will")
+	p.Veto("r2", "Will")
+
+	p.SetRights("Will", Rights{Weight: 1, Vetoes: 1})
+	if vetoes := p.VetoesBy("Will"); len(vetoes) != 1 || vetoes[0] != "r1" {
+		t.Logf("Expected Will's most recent veto to be withdrawn, got %v", vetoes)
+		t.Fail()
+	}
+
+	p.SetRights("Will", Rights{Weight: MinWeight})
+	if _, ok := p.Rights["Will"]; ok || len(p.Vetoed) != 0 {
+		t.Logf("Expected Will's rights and vetoes to be removed, got %v %v", p.Rights, p.Vetoed)
+		t.Fail()
+	}
+}
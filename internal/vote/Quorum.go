@@ -0,0 +1,189 @@
+package vote
+
+import (
+	"time"
+)
+
+// The limits enforced on the rules of a poll, ExtendBy being given in minutes.
+const (
+	MaxExtensions = 5
+	MaxExtendBy   = 7 * 24 * 60
+)
+
+// FailureAction represents what happens to a poll whose rules have not been met once it closes.
+type FailureAction string
+
+const (
+	// FailPoll states that a poll not meeting its rules fails once it closes, an empty action being treated as FailPoll.
+	FailPoll FailureAction = "fail"
+	// ExtendPoll states that a poll not meeting its rules is reopened for ExtendBy minutes once it closes, up to MaxExtensions times, after which it fails.
+	ExtendPoll FailureAction = "extend"
+)
+
+// Outcome represents how a poll with rules was settled, an empty outcome stating the poll has not yet been settled.
+type Outcome string
+
+const (
+	// Decided polls met their rules, their winner being the result of the poll.
+	Decided Outcome = "decided"
+	// Failed polls closed without meeting their rules, so have no result.
+	Failed Outcome = "failed"
+)
+
+// Rules represents the requirements set by the organiser of a poll that must be met for the poll to be decided. Polls without rules are never settled, their winner simply being
+// the leading option whenever the poll is looked at.
+type Rules struct {
+	// Quorum is the minimum number of users who must vote for the poll to be decided.
+	Quorum int `json:"quorum,omitempty" bson:"quorum,omitempty"`
+	// QuorumPercent is the minimum percentage of the invited users who must vote for the poll to be decided.
+	QuorumPercent int `json:"quorumPercent,omitempty" bson:"quorumPercent,omitempty"`
+	// Invited lists the users invited to vote, no other users being allowed to vote once given. Knowing who may still vote allows a poll to be decided before it closes should its
+	// result become certain.
+	Invited []string `json:"invited,omitempty" bson:"invited,omitempty"`
+	// Margin is the number of weighted votes the winner must lead every other option that has not been vetoed by.
+	Margin int `json:"margin,omitempty" bson:"margin,omitempty"`
+	// OnFailure states what happens to the poll should it close without meeting its rules, with ExtendBy and MaxExtensions applying to polls which are extended.
+	OnFailure     FailureAction `json:"onFailure,omitempty" bson:"onFailure,omitempty"`
+	ExtendBy      int           `json:"extendBy,omitempty" bson:"extendBy,omitempty"`
+	MaxExtensions int           `json:"maxExtensions,omitempty" bson:"maxExtensions,omitempty"`
+}
+
+// IsInvited returns whether the given user may vote within a poll following the rules, every user being invited should the rules not list who is invited.
+func (r *Rules) IsInvited(user string) bool {
+	return len(r.Invited) == 0 || contains(r.Invited, user)
+}
+
+// Voters returns the number of users who have voted within the poll.
+func (p *Poll) Voters() int {
+	voted := make(map[string]bool)
+	for _, users := range p.Votes {
+		for _, u := range users {
+			voted[u] = true
+		}
+	}
+	return len(voted)
+}
+
+// QuorumMet returns whether enough users have voted within the poll to meet the quorum of its rules, being the case for every poll without rules.
+func (p *Poll) QuorumMet() bool {
+	if p.Rules == nil {
+		return true
+	}
+
+	voters := p.Voters()
+	if voters < p.Rules.Quorum {
+		return false
+	}
+	return p.Rules.QuorumPercent == 0 || voters*100 >= p.Rules.QuorumPercent*len(p.Rules.Invited)
+}
+
+// Lead returns the number of weighted votes the poll's winner leads every other option that has not been vetoed by, returning false should the poll have no winner.
+func (p *Poll) Lead() (lead int, ok bool) {
+	winner := p.Winner()
+	if winner == nil {
+		return 0, false
+	}
+
+	var best, next int
+	for _, t := range p.Results() {
+		if t.OptionID == winner.ID {
+			best = t.Weighted
+		} else if !t.Vetoed && t.Weighted > next {
+			next = t.Weighted
+		}
+	}
+	return best - next, true
+}
+
+// MarginMet returns whether the poll's winner leads every other option by at least the margin of its rules, a poll without a winner never meeting its margin.
+func (p *Poll) MarginMet() bool {
+	lead, ok := p.Lead()
+	if !ok {
+		return false
+	}
+	return p.Rules == nil || lead >= p.Rules.Margin
+}
+
+// IsCertain returns whether the result of the poll can no longer change before it closes, treating the votes already cast as final. This is only known for polls whose rules list
+// the invited users, the winner being required to meet the margin even should every invited user yet to vote back the closest option, with no user having vetoes left to use.
+func (p *Poll) IsCertain() bool {
+	if p.Rules == nil || len(p.Rules.Invited) == 0 {
+		return false
+	}
+
+	winner := p.Winner()
+	if winner == nil {
+		return false
+	}
+
+	voted := make(map[string]bool)
+	for _, users := range p.Votes {
+		for _, u := range users {
+			voted[u] = true
+		}
+	}
+	remaining := 0
+	for _, u := range p.Rules.Invited {
+		if !voted[u] {
+			remaining += p.RightsOf(u).Weight
+		}
+	}
+
+	for user, r := range p.Rights {
+		if len(p.VetoesBy(user)) < r.Vetoes {
+			return false
+		}
+	}
+
+	var best int
+	results := p.Results()
+	for _, t := range results {
+		if t.OptionID == winner.ID {
+			best = t.Weighted
+		}
+	}
+	// the winner must lead by at least one vote, as ties could otherwise be broken by the order of the options changing.
+	margin := p.Rules.Margin
+	if margin < 1 {
+		margin = 1
+	}
+	// vetoes may still be withdrawn, so vetoed options are treated as though they could win.
+	for _, t := range results {
+		if t.OptionID != winner.ID && best-(t.Weighted+remaining) < margin {
+			return false
+		}
+	}
+	return true
+}
+
+// Settle applies the poll's rules at the given time, returning the type of event recording the poll's change of status or an empty type should the poll be unchanged. Open polls
+// are decided early, closing at the given time, should their quorum be met and their result be certain. Closed polls are decided should their quorum and margin be met, otherwise
+// being extended or failed as their rules state. Polls without rules, or which have already been settled, are never changed.
+func (p *Poll) Settle(now time.Time) EventType {
+	if p.Rules == nil || p.Outcome != "" {
+		return ""
+	}
+
+	if p.State(now) == PollOpen {
+		if p.QuorumMet() && p.IsCertain() {
+			p.ClosesAt = now
+			p.Outcome = Decided
+			return PollDecided
+		}
+		return ""
+	}
+
+	if p.QuorumMet() && p.MarginMet() {
+		p.Outcome = Decided
+		return PollDecided
+	}
+
+	if p.Rules.OnFailure == ExtendPoll && p.Extensions < p.Rules.MaxExtensions {
+		p.Extensions++
+		p.ClosesAt = now.Add(time.Duration(p.Rules.ExtendBy) * time.Minute)
+		return PollExtended
+	}
+
+	p.Outcome = Failed
+	return PollFailed
+}
@@ -0,0 +1,76 @@
+package vote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettleDecidesEarly(t *testing.T) {
+	p, _ := beforeEach()
+	p.Rules = &Rules{Invited: []string{"Jack", "Tom", "Will", "TJ", "Kate", "Liam"}}
+	now := time.Now()
+
+	p.AddVote("r1", "Kate")
+	if e := p.Settle(now); e != "" {
+		t.Logf("Expected Liam to still be able to tie the poll, got %s", e)
+		t.Fail()
+	}
+
+	p.AddVote("r1", "Liam")
+	p.SetRights("Will", Rights{Weight: 1, Vetoes: 1})
+	if e := p.Settle(now); e != "" {
+		t.Logf("Expected Will to still be able to veto r1, got %s", e)
+		t.Fail()
+	}
+
+	p.SetRights("Will", Rights{Weight: MinWeight})
+	if e := p.Settle(now); e != PollDecided || p.Outcome != Decided || !p.ClosesAt.Equal(now) {
+		t.Logf("Expected the poll to be decided and closed early, got %s %s %v", e, p.Outcome, p.ClosesAt)
+		t.Fail()
+	}
+}
+
+func TestSettleExtendsThenFails(t *testing.T) {
+	p, _ := beforeEach()
+	p.Rules = &Rules{Quorum: 5, OnFailure: ExtendPoll, ExtendBy: 30, MaxExtensions: 1}
+	now := time.Now()
+	p.ClosesAt = now.Add(-time.Minute)
+
+	if e := p.Settle(now); e != PollExtended || p.Extensions != 1 || !p.ClosesAt.Equal(now.Add(30*time.Minute)) || p.Outcome != "" {
+		t.Logf("Expected the poll to be extended by 30 minutes, got %s %v %v", e, p.Extensions, p.ClosesAt)
+		t.Fail()
+	}
+
+	if e := p.Settle(now.Add(time.Minute)); e != "" {
+		t.Logf("Expected the extended poll to be open, got %s", e)
+		t.Fail()
+	}
+
+	if e := p.Settle(now.Add(31 * time.Minute)); e != PollFailed || p.Outcome != Failed {
+		t.Logf("Expected the poll to fail once extended, got %s %s", e, p.Outcome)
+		t.Fail()
+	}
+
+	if e := p.Settle(now.Add(time.Hour)); e != "" {
+		t.Logf("Expected a settled poll to be left unchanged, got %s", e)
+		t.Fail()
+	}
+}
+
+func TestSettleMargin(t *testing.T) {
+	p, _ := beforeEach()
+	p.Rules = &Rules{Quorum: 4, Margin: 1}
+	p.ClosesAt = time.Now().Add(-time.Minute)
+
+	tied := p.Copy()
+	if e := tied.Settle(time.Now()); e != PollFailed {
+		t.Logf("Expected a tied poll to fail its margin, got %s", e)
+		t.Fail()
+	}
+
+	p.AddVote("r1", "Kate")
+	if e := p.Settle(time.Now()); e != PollDecided || p.Winner().ID != "r1" {
+		t.Logf("Expected r1 leading by a vote to decide the poll, got %s", e)
+		t.Fail()
+	}
+}
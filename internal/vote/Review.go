@@ -0,0 +1,77 @@
+package vote
+
+import (
+	"sort"
+	"time"
+)
+
+// The limits enforced on reviews, ratings being given as a whole number of stars.
+const (
+	MinRating        = 1
+	MaxRating        = 5
+	MaxTags          = 5
+	MaxTagLength     = 30
+	MaxCommentLength = 500
+)
+
+// Review represents a participant's rating of the restaurant that won a poll, left once the poll has closed and the food has arrived. Each participant has a single review of
+// each poll, reviewing the poll again replacing their previous review.
+type Review struct {
+	PollID string `json:"pollId" bson:"pollId"`
+	// Workspace is the workspace of the reviewed poll, being empty for the default workspace.
+	Workspace string `json:"workspace,omitempty" bson:"workspace,omitempty"`
+	// RestaurantID and RestaurantName identify the poll's winner at the time of the review, keeping the name should the restaurant later be renamed or removed.
+	RestaurantID   string `json:"restaurantId" bson:"restaurantId"`
+	RestaurantName string `json:"restaurantName" bson:"restaurantName"`
+	User           string `json:"user" bson:"user"`
+	// Rating is a number of stars between MinRating and MaxRating, while Tags are short lowercase labels such as 'late', 'cold' or 'great portions'.
+	Rating    int       `json:"rating" bson:"rating"`
+	Tags      []string  `json:"tags,omitempty" bson:"tags,omitempty"`
+	Comment   string    `json:"comment,omitempty" bson:"comment,omitempty"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// Rating represents the reviews of a single restaurant aggregated together, with Tags counting the reviews giving each tag.
+type Rating struct {
+	RestaurantID string         `json:"restaurantId"`
+	Name         string         `json:"name"`
+	Reviews      int            `json:"reviews"`
+	Average      float64        `json:"average"`
+	Tags         map[string]int `json:"tags,omitempty"`
+}
+
+// Ratings aggregates the given reviews into a rating for each reviewed restaurant, sorted by average rating with the most reviewed restaurants first among equal averages.
+func Ratings(reviews []*Review) []*Rating {
+	byID := make(map[string]*Rating)
+	totals := make(map[string]int)
+	ratings := make([]*Rating, 0)
+	for _, r := range reviews {
+		rt := byID[r.RestaurantID]
+		if rt == nil {
+			rt = &Rating{RestaurantID: r.RestaurantID, Name: r.RestaurantName}
+			byID[r.RestaurantID] = rt
+			ratings = append(ratings, rt)
+		}
+
+		rt.Reviews++
+		totals[r.RestaurantID] += r.Rating
+		for _, t := range r.Tags {
+			if rt.Tags == nil {
+				rt.Tags = make(map[string]int)
+			}
+			rt.Tags[t]++
+		}
+	}
+
+	for _, rt := range ratings {
+		rt.Average = float64(totals[rt.RestaurantID]) / float64(rt.Reviews)
+	}
+
+	sort.SliceStable(ratings, func(i, j int) bool {
+		if ratings[i].Average != ratings[j].Average {
+			return ratings[i].Average > ratings[j].Average
+		}
+		return ratings[i].Reviews > ratings[j].Reviews
+	})
+	return ratings
+}
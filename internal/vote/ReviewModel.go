@@ -0,0 +1,19 @@
+package vote
+
+import "time"
+
+// ReviewModel defines a contract for how the system should interact with the database for storing and accessing the reviews left by the participants of closed polls. As with
+// polls, every review belongs to a workspace, the default workspace being represented by an empty string.
+type ReviewModel interface {
+	// SaveReview stores the given review, replacing any review the same user has previously left for the same poll. A status is returned detailing the status of the operation
+	// along with any errors that occur while attempting to store the review.
+	SaveReview(r *Review) (Status, error)
+	// GetPollReviews returns the reviews left for the poll with the given ID within the given workspace, ordered by when they were left.
+	GetPollReviews(workspace string, pollID string) ([]*Review, Status, error)
+	// GetRestaurantReviews returns every review within the given workspace of any of the restaurants with the given IDs.
+	GetRestaurantReviews(workspace string, restaurantIDs []string) ([]*Review, Status, error)
+	// GetReviews returns every review within the given workspace left at or after the given time, a zero time returning every review.
+	GetReviews(workspace string, since time.Time) ([]*Review, Status, error)
+	// Close allows for a ReviewModel connection to be closed.
+	Close() error
+}
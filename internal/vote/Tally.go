@@ -0,0 +1,112 @@
+package vote
+
+// The limits enforced on the rights given to participants of a poll.
+const (
+	MinWeight = 1
+	MaxWeight = 10
+	MaxVetoes = 5
+)
+
+// Rights represents the rights given to a single participant of a poll by its organiser. Participants without rights have a weight of 1 and may not veto options.
+type Rights struct {
+	// Weight is the number of votes a participant's vote counts as.
+	Weight int `json:"weight" bson:"weight"`
+	// Vetoes is the number of options a participant may veto, vetoed options being unable to win the poll however many votes they have.
+	Vetoes int `json:"vetoes" bson:"vetoes"`
+}
+
+// OptionTally represents the votes cast for a single option of a poll, giving the raw number of votes cast for it alongside the number once each vote is weighted. Views of
+// private polls only list the viewer within VetoedBy, but keep Weighted as the winner is decided by it; a weight given to a single participant can therefore reveal which option
+// they voted for, so organisers of private polls should give weights to groups of participants rather than individuals.
+type OptionTally struct {
+	OptionID string   `json:"optionId"`
+	Votes    int      `json:"votes"`
+	Weighted int      `json:"weighted"`
+	Vetoed   bool     `json:"vetoed"`
+	VetoedBy []string `json:"vetoedBy,omitempty"`
+}
+
+// RightsOf returns the rights of the given user within the poll, being the default rights should the organiser not have set any.
+func (p *Poll) RightsOf(user string) Rights {
+	if r, ok := p.Rights[user]; ok {
+		return r
+	}
+	return Rights{Weight: MinWeight}
+}
+
+// SetRights sets the rights of the given user within the poll, removing the user's rights should the given rights be the default rights. Vetoes beyond the user's new quota are
+// withdrawn, most recent first.
+func (p *Poll) SetRights(user string, r Rights) {
+	if r == (Rights{Weight: MinWeight}) {
+		delete(p.Rights, user)
+	} else {
+		if p.Rights == nil {
+			p.Rights = make(map[string]Rights)
+		}
+		p.Rights[user] = r
+	}
+
+	for vetoes := p.VetoesBy(user); len(vetoes) > r.Vetoes; vetoes = vetoes[:len(vetoes)-1] {
+		p.Unveto(vetoes[len(vetoes)-1], user)
+	}
+}
+
+// Veto records the given user vetoing the option with the given ID, returning false should the user have already vetoed the option.
+func (p *Poll) Veto(opt string, user string) bool {
+	if contains(p.Vetoed[opt], user) {
+		return false
+	}
+
+	if p.Vetoed == nil {
+		p.Vetoed = make(map[string][]string)
+	}
+	p.Vetoed[opt] = append(p.Vetoed[opt], user)
+	return true
+}
+
+// Unveto withdraws the given user's veto of the option with the given ID, returning false should the user not have vetoed the option.
+func (p *Poll) Unveto(opt string, user string) bool {
+	for i, u := range p.Vetoed[opt] {
+		if u == user {
+			p.Vetoed[opt] = append(p.Vetoed[opt][:i], p.Vetoed[opt][i+1:]...)
+			if len(p.Vetoed[opt]) == 0 {
+				delete(p.Vetoed, opt)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// VetoesBy returns the IDs of the options vetoed by the given user, in the order the options are listed within the poll.
+func (p *Poll) VetoesBy(user string) []string {
+	vetoes := make([]string, 0)
+	for _, opt := range p.Options {
+		if contains(p.Vetoed[opt.ID], user) {
+			vetoes = append(vetoes, opt.ID)
+		}
+	}
+	return vetoes
+}
+
+// Results tallies the votes cast for each option, in the order the options are listed. Views of polls hold their results within Tally, which is returned in their place, while
+// views hiding their results return nil.
+func (p *Poll) Results() []*OptionTally {
+	if p.Tally != nil || p.ResultsHidden {
+		return p.Tally
+	}
+
+	results := make([]*OptionTally, 0, len(p.Options))
+	for _, opt := range p.Options {
+		t := &OptionTally{OptionID: opt.ID, Votes: len(p.Votes[opt.ID])}
+		for _, u := range p.Votes[opt.ID] {
+			t.Weighted += p.RightsOf(u).Weight
+		}
+		if vetoes := p.Vetoed[opt.ID]; len(vetoes) > 0 {
+			t.Vetoed = true
+			t.VetoedBy = append([]string(nil), vetoes...)
+		}
+		results = append(results, t)
+	}
+	return results
+}
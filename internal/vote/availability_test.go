@@ -0,0 +1,84 @@
+package vote
+
+import (
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+// closedAround returns exceptions closing a restaurant all day either side of now, so a poll ordering straight away finds it closed whatever the time.
+func closedAround(now time.Time) (exceptions []*restaurant.HoursException) {
+	for _, d := range []int{-1, 0, 1} {
+		exceptions = append(exceptions, &restaurant.HoursException{Date: now.UTC().AddDate(0, 0, d).Format("2006-01-02")})
+	}
+	return
+}
+
+func TestUpdateAvailability(t *testing.T) {
+	p := &Poll{
+		ClosesAt: friday,
+		Options: []*restaurant.Building{
+			{ID: "open", Hours: []*restaurant.OpeningHours{{Day: "friday", Opens: "17:00", Closes: "23:00"}}},
+			{ID: "holiday", Hours: []*restaurant.OpeningHours{{Day: "friday", Opens: "17:00", Closes: "23:00"}}, Exceptions: []*restaurant.HoursException{{Date: "2026-10-16"}}},
+			{ID: "unknown"},
+		},
+		Unavailable: []string{"unknown"},
+	}
+
+	unavailable, available := p.UpdateAvailability(time.Now())
+	if len(unavailable) != 1 || unavailable[0] != "holiday" || len(available) != 1 || available[0] != "unknown" {
+		t.Logf("Expected holiday to become unavailable and unknown available, got %v %v", unavailable, available)
+		t.Fail()
+	}
+	if !stringsContains(p.Unavailable, "holiday") || len(p.Unavailable) != 1 {
+		t.Logf("Expected only holiday to be unavailable, got %v", p.Unavailable)
+		t.Fail()
+	}
+}
+
+func TestUnavailableOptionRejectsVotes(t *testing.T) {
+	organisedPoll(t, false)
+
+	messages, stop := websocket.HubInstance.Listen("new poll")
+	defer stop()
+
+	r2 := &restaurant.Building{ID: "r2", Name: "Restaurant 2", Exceptions: closedAround(time.Now())}
+	if _, _, _, err := AddPollOption("", "new poll", "Jack", r2.Copy()); err != nil {
+		t.Logf("Expected r2 to be added, got %s", err.Error())
+		t.FailNow()
+	}
+
+	timeout := time.After(5 * time.Second)
+	for received := false; !received; {
+		select {
+		case msg := <-messages:
+			// the hub is shared between tests, so messages broadcast by earlier changes may still be received.
+			if e, ok := msg.Data.(*Event); ok && e.Type == OptionUnavailable && e.OptionID == "r2" {
+				received = true
+			}
+		case <-timeout:
+			t.Log("Expected r2 becoming unavailable to be broadcast")
+			t.FailNow()
+		}
+	}
+
+	if _, status, err := CastVote("", "new poll", "Tom", "r2"); err == nil || status != Invalid {
+		t.Logf("Expected a vote for the closed option to be invalid, got %v %v", status, err)
+		t.Fail()
+	}
+
+	r2.Exceptions = nil
+	RefreshRestaurant("", r2)
+
+	poll, _, _ := FindPoll("", "new poll")
+	if len(poll.Unavailable) != 0 || len(poll.Option("r2").Exceptions) != 0 {
+		t.Logf("Expected r2 to be available once its hours changed, got %v", poll.Unavailable)
+		t.Fail()
+	}
+	if _, _, err := CastVote("", "new poll", "Tom", "r2"); err != nil {
+		t.Logf("Expected a vote for the reopened option to be cast, got %s", err.Error())
+		t.Fail()
+	}
+}
@@ -0,0 +1,99 @@
+package vote
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// Error codes returned within the error envelope of the v2 API for errors not caused by a PollModel.
+const (
+	// BadRequest states that a request could not be parsed.
+	BadRequest = "bad_request"
+	// MethodNotAllowed states that a resource does not support the method of a request.
+	MethodNotAllowed = "method_not_allowed"
+	// Internal states that a request failed due to an unexpected error.
+	Internal = "internal"
+)
+
+// statusCodes maps each Status to the error code and http status code returned by the v2 API.
+var statusCodes = map[Status]struct {
+	code string
+	http int
+}{
+	Ok:           {"ok", http.StatusOK},
+	NoConnection: {"no_connection", http.StatusServiceUnavailable},
+	NotFound:     {"not_found", http.StatusNotFound},
+	Invalid:      {"invalid", http.StatusBadRequest},
+	Conflict:     {"conflict", http.StatusConflict},
+	Forbidden:    {"forbidden", http.StatusForbidden},
+}
+
+// Code returns the error code representing the status within the v2 API.
+func (s Status) Code() string {
+	if c, ok := statusCodes[s]; ok {
+		return c.code
+	}
+	return Internal
+}
+
+// HTTPStatus returns the http status code representing the status within the v2 API.
+func (s Status) HTTPStatus() int {
+	if c, ok := statusCodes[s]; ok {
+		return c.http
+	}
+	return http.StatusInternalServerError
+}
+
+// Error represents the body of an error returned by the v2 API.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Details lists the invalid fields of a request rejected by validation.
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// ErrorEnvelope wraps an Error, every error response of the v2 API taking the form {"error": {"code": ..., "message": ...}}.
+type ErrorEnvelope struct {
+	Error Error `json:"error"`
+}
+
+// WriteError writes an error response with the given http status code, error code and message.
+func WriteError(w http.ResponseWriter, status int, code string, message string) {
+	writeEnvelope(w, status, Error{Code: code, Message: message})
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, e Error) {
+	data, err := json.Marshal(ErrorEnvelope{Error: e})
+	if err != nil {
+		log.Printf("The error %s could not be serialised to JSON.\n", e.Message)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// WriteStatusError writes an error response for an operation which failed with the given status and error. An Ok status accompanying an error states the operation failed for a
+// reason other than the PollModel, so is reported as an internal error. Validation errors include the invalid fields as the error's details.
+func WriteStatusError(w http.ResponseWriter, status Status, err error) {
+	if status == Ok {
+		WriteError(w, http.StatusInternalServerError, Internal, err.Error())
+		return
+	}
+
+	e := Error{Code: status.Code(), Message: err.Error()}
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		e.Details = verr.Fields
+	}
+	writeEnvelope(w, status.HTTPStatus(), e)
+}
+
+// WriteMethodNotAllowed writes an error response stating the requested resource does not support the request's method.
+func WriteMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, http.StatusMethodNotAllowed, MethodNotAllowed, r.Method+" is not supported by "+r.URL.Path)
+}
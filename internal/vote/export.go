@@ -0,0 +1,251 @@
+package vote
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+// OrderDuration is the length of the calendar event exported for a decided poll, starting at the poll's order time.
+const OrderDuration = time.Hour
+
+// PollExport represents the results of a poll as exported for spreadsheets and calendars, taken from the poll's view so private polls only export the caller's own vote. Winner
+// is nil should the poll have no winner, have failed, or hide its results.
+type PollExport struct {
+	PollID        string            `json:"pollId"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	OrderTime     time.Time         `json:"orderTime"`
+	State         PollState         `json:"state"`
+	Outcome       Outcome           `json:"outcome,omitempty"`
+	ResultsHidden bool              `json:"resultsHidden,omitempty"`
+	Winner        *ExportedOption   `json:"winner"`
+	Options       []*ExportedOption `json:"options"`
+	Votes         []*ExportedVote   `json:"votes"`
+
+	// rules states whether the poll has rules, so must be decided against them before it can be added to a calendar.
+	rules bool
+}
+
+// ExportedOption represents a single option of an exported poll along with the votes cast for it.
+type ExportedOption struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Address  string   `json:"address,omitempty"`
+	Votes    int      `json:"votes"`
+	Weighted int      `json:"weighted"`
+	Vetoed   bool     `json:"vetoed,omitempty"`
+	Voters   []string `json:"voters"`
+}
+
+// ExportedVote represents a single user's vote within an exported poll.
+type ExportedVote struct {
+	User       string `json:"user"`
+	OptionID   string `json:"optionId"`
+	OptionName string `json:"optionName"`
+}
+
+// Export returns the results of the given view of a poll at the given time, listing the options in the order of the poll and the votes ordered by user.
+func Export(view *Poll, now time.Time) *PollExport {
+	e := &PollExport{
+		PollID:        view.ID,
+		CreatedAt:     view.CreatedAt,
+		OrderTime:     view.OrderTime(now),
+		State:         view.State(now),
+		Outcome:       view.Outcome,
+		ResultsHidden: view.ResultsHidden,
+		Options:       make([]*ExportedOption, 0, len(view.Options)),
+		Votes:         make([]*ExportedVote, 0),
+		rules:         view.Rules != nil,
+	}
+
+	tallies := make(map[string]*OptionTally)
+	for _, t := range view.Results() {
+		tallies[t.OptionID] = t
+	}
+
+	for _, opt := range view.Options {
+		o := &ExportedOption{ID: opt.ID, Name: opt.Name, Address: opt.Address, Voters: append(make([]string, 0), view.Votes[opt.ID]...)}
+		if t := tallies[opt.ID]; t != nil {
+			o.Votes, o.Weighted, o.Vetoed = t.Votes, t.Weighted, t.Vetoed
+		}
+		e.Options = append(e.Options, o)
+
+		for _, u := range view.Votes[opt.ID] {
+			e.Votes = append(e.Votes, &ExportedVote{User: u, OptionID: opt.ID, OptionName: opt.Name})
+		}
+	}
+	sort.SliceStable(e.Votes, func(i, j int) bool {
+		return e.Votes[i].User < e.Votes[j].User
+	})
+
+	if w := view.Winner(); w != nil && view.Outcome != Failed {
+		for _, o := range e.Options {
+			if o.ID == w.ID {
+				e.Winner = o
+			}
+		}
+	}
+	return e
+}
+
+// WriteCSV writes the export as a CSV file with a row for each option, giving the option's votes, whether it won and the users who voted for it separated by semicolons.
+func (e *PollExport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"option id", "option name", "address", "votes", "weighted votes", "vetoed", "winner", "voters"})
+	for _, o := range e.Options {
+		winner := e.Winner != nil && e.Winner.ID == o.ID
+		cw.Write([]string{o.ID, o.Name, o.Address, strconv.Itoa(o.Votes), strconv.Itoa(o.Weighted), strconv.FormatBool(o.Vetoed), strconv.FormatBool(winner), strings.Join(o.Voters, "; ")})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteICS writes the export as an iCalendar file holding an event at the poll's order time at the winning restaurant, stamped with the given time. An error is returned should
+// the poll not have been decided, as checked by checkDecided, there being nothing to put in the calendar.
+func (e *PollExport) WriteICS(w io.Writer, now time.Time) error {
+	if err := e.checkDecided(); err != nil {
+		return err
+	}
+
+	description := make([]string, 0, len(e.Options))
+	for _, o := range e.Options {
+		description = append(description, fmt.Sprintf("%s: %d votes", o.Name, o.Weighted))
+	}
+
+	const stamp = "20060102T150405Z"
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//takeaway-server//poll export//EN",
+		"BEGIN:VEVENT",
+		"UID:" + e.PollID + "@takeaway-server",
+		"DTSTAMP:" + now.UTC().Format(stamp),
+		"DTSTART:" + e.OrderTime.UTC().Format(stamp),
+		"DTEND:" + e.OrderTime.Add(OrderDuration).UTC().Format(stamp),
+		"SUMMARY:" + escapeText("Takeaway from "+e.Winner.Name),
+		"LOCATION:" + escapeText(e.Winner.Address),
+		"DESCRIPTION:" + escapeText("Decided by poll "+e.PollID+"\n"+strings.Join(description, "\n")),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	for _, l := range lines {
+		if _, err := io.WriteString(w, fold(l)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDecided returns an error should the exported poll still be open, not yet have been decided against its rules should it have any, or have no winner, as the winner of
+// such a poll may yet change.
+func (e *PollExport) checkDecided() error {
+	switch {
+	case e.State != PollClosed:
+		return fmt.Errorf("poll %s is still open so has not been decided", e.PollID)
+	case e.rules && e.Outcome != Decided:
+		return fmt.Errorf("poll %s has not been decided against its rules", e.PollID)
+	case e.Winner == nil:
+		return fmt.Errorf("poll %s has no winner to add to a calendar", e.PollID)
+	}
+	return nil
+}
+
+// escapeText escapes the given text for use as an iCalendar text value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// fold terminates the given iCalendar content line, folding it onto continuation lines so no line is longer than 75 octets without splitting a character.
+func fold(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// ExportPoll provides a http handler exporting the results of the poll specified by the 'id' query parameter as a file, the 'format' query parameter choosing between csv, json
+// and ics. Private polls only export the caller's own vote, while only closed polls which have been decided with a winner can be exported as ics, a conflict status being
+// returned otherwise.
+func ExportPoll(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No poll ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	contentType, ok := exportTypes[format]
+	if !ok {
+		log.Printf("Could not export poll %s as unknown format %s\n", id, format)
+		http.Error(w, "format must be one of csv, json or ics", http.StatusBadRequest)
+		return
+	}
+
+	poll, status, err := instance.Model.GetPoll(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == NotFound {
+			log.Printf("Could not find ID %s, returning not found exception.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find ID due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	now := time.Now()
+	e := Export(poll.ViewFor(caller.User(r.Context()), now), now)
+
+	var b bytes.Buffer
+	switch format {
+	case "csv":
+		err = e.WriteCSV(&b)
+	case "json":
+		err = json.NewEncoder(&b).Encode(e)
+	case "ics":
+		if err = e.checkDecided(); err != nil {
+			log.Printf("Could not export poll %s as a calendar event due to: %s\n", id, err.Error())
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		err = e.WriteICS(&b, now)
+	}
+	if err != nil {
+		log.Printf("Could not export poll %s as %s due to: %s\n", id, format, err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Exported poll %s as %s\n", id, format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "poll-"+id+"."+format))
+	w.Write(b.Bytes())
+}
+
+// exportTypes gives the content type of each format polls can be exported as.
+var exportTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json",
+	"ics":  "text/calendar; charset=utf-8",
+}
@@ -0,0 +1,104 @@
+package vote
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// exportedPoll returns a poll closing on friday with votes r1:[Jack,Tom] and r2:[Will,TJ].
+func exportedPoll() *Poll {
+	return &Poll{
+		ID:       "poll",
+		ClosesAt: friday,
+		Options:  []*restaurant.Building{{ID: "r1", Name: "r1"}, {ID: "r2", Name: "r2", Address: "2 High St, London"}},
+		Votes:    createVotes(),
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	p := exportedPoll()
+	p.Votes["r2"] = append(p.Votes["r2"], "Jack")
+
+	var b bytes.Buffer
+	if err := Export(p.ViewFor("", friday), friday).WriteCSV(&b); err != nil {
+		t.Logf("Expected the poll to be exported, got %s", err.Error())
+		t.FailNow()
+	}
+
+	expected := "option id,option name,address,votes,weighted votes,vetoed,winner,voters\n" +
+		"r1,r1,,2,2,false,false,Jack; Tom\n" +
+		"r2,r2,\"2 High St, London\",3,3,false,true,Will; TJ; Jack\n"
+	if b.String() != expected {
+		t.Logf("Expected the options to be exported, got %q", b.String())
+		t.Fail()
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	p := exportedPoll()
+	p.Votes["r1"] = append(p.Votes["r1"], "Will")
+
+	var b bytes.Buffer
+	if err := Export(p.ViewFor("", friday), friday).WriteICS(&b, friday); err != nil {
+		t.Logf("Expected the poll to be exported, got %s", err.Error())
+		t.FailNow()
+	}
+
+	ics := b.String()
+	for _, line := range []string{"BEGIN:VEVENT\r\n", "UID:poll@takeaway-server\r\n", "DTSTART:20261016T190000Z\r\n", "DTEND:20261016T200000Z\r\n", "SUMMARY:Takeaway from r1\r\n"} {
+		if !strings.Contains(ics, line) {
+			t.Logf("Expected the calendar to contain %q, got %q", line, ics)
+			t.Fail()
+		}
+	}
+
+	for i, c := range []struct {
+		now   time.Time
+		rules *Rules
+	}{
+		{friday.Add(-time.Hour), nil},
+		{friday, &Rules{}},
+	} {
+		p.Rules = c.rules
+		if err := Export(p.ViewFor("", c.now), c.now).WriteICS(&b, c.now); err == nil {
+			t.Logf("Expected case %v not to be exported as it has not been decided", i)
+			t.Fail()
+		}
+	}
+
+	if long := fold(strings.Repeat("a", 100)); long != strings.Repeat("a", 75)+"\r\n "+strings.Repeat("a", 25)+"\r\n" {
+		t.Logf("Expected long lines to be folded, got %q", long)
+		t.Fail()
+	}
+}
+
+func TestExportPoll(t *testing.T) {
+	organisedPoll(t, false)
+
+	if w, _ := serveAs(ExportPoll, "Jack", http.MethodGet, "/poll/export?id=new+poll&format=ics", ""); w.Code != http.StatusConflict {
+		t.Logf("Expected a poll without a winner not to be exported to a calendar, got %v", w.Code)
+		t.Fail()
+	}
+
+	CastVote("", "new poll", "Tom", "r1")
+	if w, _ := serveAs(ExportPoll, "Jack", http.MethodGet, "/poll/export?id=new+poll&format=ics", ""); w.Code != http.StatusConflict {
+		t.Logf("Expected an open poll not to be exported to a calendar, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, _ := serveAs(ExportPoll, "Jack", http.MethodGet, "/poll/export?id=new+poll&format=csv", "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Disposition") != `attachment; filename="poll-new poll.csv"` || !strings.Contains(w.Body.String(), "Tom") {
+		t.Logf("Expected the poll to be exported as CSV, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	if w, _ := serveAs(ExportPoll, "Jack", http.MethodGet, "/poll/export?id=new+poll&format=xlsx", ""); w.Code != http.StatusBadRequest {
+		t.Logf("Expected an unknown format to be rejected, got %v", w.Code)
+		t.Fail()
+	}
+}
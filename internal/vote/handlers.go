@@ -2,12 +2,17 @@ package vote
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"takeaway/takeaway-server/internal/caller"
 	"takeaway/takeaway-server/internal/restaurant"
-	"takeaway/takeaway-server/internal/websocket"
+	"time"
 )
 
 const (
@@ -18,12 +23,13 @@ const (
 // locks for polls by ID, ensures that only one goroutine is updating a poll at once.
 var pollLocks sync.Map
 
-type vote struct {
+// Vote represents the body of a request casting a vote within the v1 API.
+type Vote struct {
 	User  string `json:"user"`
 	ResID string `json:"restaurant_ID"`
 }
 
-// GetPoll provides a http handler for accessing a specified vote.
+// GetPoll provides a http handler for accessing a specified vote, including the ratings of its options from the reviews left within the caller's workspace.
 func GetPoll(w http.ResponseWriter, r *http.Request) {
 	log.Println("Recieved request")
 
@@ -43,7 +49,7 @@ func GetPoll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	md := instance.Model
-	poll, status, err := md.GetPoll(id)
+	poll, status, err := md.GetPoll(caller.Workspace(r.Context()), id)
 
 	// if a poll with the given id could not be found return a status not found response.
 	if err != nil {
@@ -59,7 +65,9 @@ func GetPoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := json.Marshal(poll)
+	view := viewFor(r, poll)
+	rate(view)
+	data, err := json.Marshal(view)
 
 	// if poll could not be serialized to JSON, return an internal server error.
 	if err != nil {
@@ -78,7 +86,44 @@ func GetPoll(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-// NewPoll provides a http handler for creating a new vote.
+// ListPolls provides a http handler for listing the polls within the caller's workspace. Polls can be filtered using the 'creator', 'participant', 'state' ('open' or
+// 'closed'), 'restaurant', 'createdAfter' and 'createdBefore' (RFC3339) query parameters, ordered using the 'sort' query parameter ('createdAt' or 'closesAt', prefixed by '-'
+// for descending order) and paged using the 'limit' and 'cursor' query parameters, the cursor for the next page being returned alongside each page.
+func ListPolls(w http.ResponseWriter, r *http.Request) {
+	q, err := parsePollQuery(r.URL.Query())
+	if err != nil {
+		log.Printf("Could not parse poll query due to: %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	q.Viewer = caller.User(r.Context())
+
+	page, status, err := instance.Model.ListPolls(caller.Workspace(r.Context()), q)
+	if err != nil {
+		if status == Invalid {
+			log.Printf("Invalid poll query %v: %s\n", r.URL.Query(), err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			log.Printf("Could not list polls due to: %s\n", err.Error())
+			http.Error(w, "Could not list polls", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	viewPage(page, caller.User(r.Context()))
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		log.Printf("The page %v could not be serialised to JSON.\n", page)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
+}
+
+// NewPoll provides a http handler for creating a new vote. Should the 'suggest' query parameter be specified, up to that many suggested restaurants for the comma separated
+// 'users' query parameter are added to the given options, in which case the request body may be omitted.
 func NewPoll(w http.ResponseWriter, r *http.Request) {
 	b, err := ioutil.ReadAll(r.Body)
 	defer r.Body.Close()
@@ -90,8 +135,12 @@ func NewPoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	suggest := r.URL.Query().Get("suggest")
+
 	var data []*restaurant.Building
-	err = json.Unmarshal(b, &data)
+	if len(b) > 0 || suggest == "" {
+		err = json.Unmarshal(b, &data)
+	}
 
 	// if request could not be properly unmarchelled, return a bad request status to the client.
 	if err != nil {
@@ -100,20 +149,43 @@ func NewPoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	md := instance.Model
-	poll, status, err := md.NewPoll(data)
+	if suggest != "" {
+		n, err := strconv.Atoi(suggest)
+		if err != nil || n < 1 {
+			log.Printf("Invalid number of suggestions %s requested\n", suggest)
+			http.Error(w, "Could not parse number of suggestions", http.StatusBadRequest)
+			return
+		}
+
+		data, err = addSuggestions(caller.Workspace(r.Context()), data, SplitList(r.URL.Query().Get("users")), n)
+		if err != nil {
+			log.Printf("Could not suggest options for new poll due to: %s\n", err.Error())
+			http.Error(w, "Could not suggest options", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	settings, err := parseSettings(r.URL.Query())
+	if err != nil {
+		log.Printf("Invalid poll settings %v requested: %s\n", r.URL.Query(), err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the caller is recorded as the poll's creator, allowing polls to be listed by who created them.
+	poll, status, err := CreatePoll(caller.Workspace(r.Context()), caller.User(r.Context()), data, settings)
 
 	if err != nil {
 		log.Printf("Could not create a new poll due to: %s\n", err.Error())
 		if status == Invalid {
-			http.Error(w, "Supplied options invalid", http.StatusBadRequest)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		} else {
 			http.Error(w, "Poll could not be created", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	rtnString, err := json.Marshal(poll)
+	rtnString, err := json.Marshal(viewFor(r, poll))
 	if err != nil {
 		http.Error(w, "Poll could not be created", http.StatusInternalServerError)
 		return
@@ -149,8 +221,10 @@ func UpdatePoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	md := instance.Model
-	status, err := md.UpdatePoll(&data)
+	// polls can only be updated within the caller's workspace, preventing polls from being moved between workspaces.
+	data.Workspace = caller.Workspace(r.Context())
+
+	status, err := SavePoll(&data, caller.User(r.Context()))
 
 	if err != nil {
 		if status == NotFound {
@@ -159,13 +233,18 @@ func UpdatePoll(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if status == Invalid {
+			log.Printf("Poll with id %s is invalid: %s\n", data.ID, err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		log.Printf("Could not update poll with id %s due to internal model error %s\n", data.ID, err.Error())
 		http.Error(w, "Could not update poll", http.StatusInternalServerError)
 		return
 	}
 
 	log.Printf("successfully updated poll with id %s\n", data.ID)
-	websocket.NotifyChange(&data)
 	w.WriteHeader(http.StatusAccepted)
 }
 
@@ -186,8 +265,7 @@ func DeletePoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	md := instance.Model
-	status, err := md.DeletePoll(id)
+	status, err := RemovePoll(caller.Workspace(r.Context()), id)
 
 	if err != nil {
 		if status == NotFound {
@@ -202,7 +280,7 @@ func DeletePoll(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AddVote provides http handler for adding a vote to a poll.
+// AddVote provides http handler for adding a vote to a poll. Within private polls users may only cast their own vote.
 func AddVote(w http.ResponseWriter, r *http.Request) {
 	// if no ids have been specified within the request, return a bad request status.
 	if len(r.URL.Query()["id"]) == 0 {
@@ -219,27 +297,6 @@ func AddVote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	md := instance.Model
-
-	lock := lockPoll(id)
-	defer lock.Unlock()
-
-	poll, status, err := md.GetPoll(id)
-
-	// if a poll with the given id could not be found return a status not found response.
-	if err != nil {
-		log.Printf("Error = %s\n", err.Error())
-		log.Printf("Status = %v\n", status)
-		if status == NotFound {
-			log.Printf("Could not find ID %s, returning not found exception.\n", id)
-			w.WriteHeader(http.StatusNotFound)
-		} else {
-			log.Printf("Unable to find ID due to being unable to connect to the DB.\n")
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
 	b, err := ioutil.ReadAll(r.Body)
 	defer r.Body.Close()
 
@@ -250,7 +307,7 @@ func AddVote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var data vote
+	var data Vote
 	err = json.Unmarshal(b, &data)
 
 	// if given body can not be unmarshalled into a vote object, return a bad request status.
@@ -266,14 +323,28 @@ func AddVote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	poll.AddVote(data.ResID, data.User)
-	status, err = md.UpdatePoll(poll)
+	status, err := CheckVoter(caller.Workspace(r.Context()), id, caller.User(r.Context()), data.User)
+	if err == nil {
+		_, status, err = CastVote(caller.Workspace(r.Context()), id, data.User, data.ResID)
+	}
 
 	if err != nil {
 		if status == NotFound {
 			// if the specified poll ID could not be found, return a not found status.
 			log.Printf("Could not update poll due to not finding the id %s\n", id)
 			http.Error(w, "Could not find poll with specified ID", http.StatusNotFound)
+		} else if status == Invalid {
+			// if the vote is not for one of the poll's options, return a bad request status.
+			log.Printf("Invalid vote for poll %s: %s\n", id, err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else if status == Conflict {
+			// if the poll has already been settled against its rules, return a conflict status.
+			log.Printf("Could not vote within poll %s due to: %s\n", id, err.Error())
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else if status == Forbidden {
+			// if the vote is made on behalf of another user within a private poll, return a forbidden status.
+			log.Printf("Could not vote within poll %s due to: %s\n", id, err.Error())
+			http.Error(w, err.Error(), http.StatusForbidden)
 		} else {
 			// otherwise return an internal server error status.
 			log.Printf("Could not update poll %s due to being unable to connect to the database\n", id)
@@ -283,11 +354,10 @@ func AddVote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Printf("Updated poll %s with a vote for %s for user %s\n", id, data.ResID, data.User)
-	websocket.NotifyChange(poll)
 	w.WriteHeader(http.StatusAccepted)
 }
 
-// RemoveUser provides a http handler for removing a user from a specified poll.
+// RemoveUser provides a http handler for removing a user from a specified poll. Within private polls users may only remove themselves.
 func RemoveUser(w http.ResponseWriter, r *http.Request) {
 	// if no ids have been specified within the request, return a bad request status.
 	if len(r.URL.Query()["id"]) == 0 {
@@ -319,54 +389,238 @@ func RemoveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	md := instance.Model
-
 	log.Printf("attempting to remove user %s from poll %s\n", user, id)
 
-	lock := lockPoll(id)
-	defer lock.Unlock()
-
-	poll, status, err := md.GetPoll(id)
+	status, err := CheckVoter(caller.Workspace(r.Context()), id, caller.User(r.Context()), user)
+	if err == nil {
+		_, status, err = RemoveVoter(caller.Workspace(r.Context()), id, user)
+	}
 	if err != nil {
 		if status == NotFound {
 			// if the given ID cannot be found, return a not found response.
 			log.Printf("A poll with the given ID %s could not be found within the system\n", id)
 			http.Error(w, "poll with the given ID cannot be found", http.StatusNotFound)
+		} else if status == Conflict {
+			// if the poll has already been settled against its rules, return a conflict status.
+			log.Printf("Could not remove user %s from poll %s due to: %s\n", user, id, err.Error())
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else if status == Forbidden {
+			// if another user is removed from a private poll, return a forbidden status.
+			log.Printf("Could not remove user %s from poll %s due to: %s\n", user, id, err.Error())
+			http.Error(w, err.Error(), http.StatusForbidden)
 		} else {
-			// otherwise a connection could not be created with the datasource
-			log.Printf("Could not connect to the datasource to remove user.\n")
+			// otherwise the poll could not be updated within the datasource.
+			log.Printf("Could not remove user %s from poll %s due to: %s\n", user, id, err.Error())
 			http.Error(w, "User could not be removed from the Poll", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	poll.ClearVotesFor(user)
+	log.Printf("Removed user %s from poll %s\n", user, id)
+
+	w.WriteHeader(http.StatusAccepted)
+	return
+
+}
+
+// addSuggestions adds up to n restaurants suggested for the given users of the given workspace to the given options, skipping any suggestions already present within the options.
+func addSuggestions(workspace string, options []*restaurant.Building, users []string, n int) ([]*restaurant.Building, error) {
+	if instance.Suggester == nil {
+		return nil, fmt.Errorf("no suggester has been configured")
+	}
 
-	status, err = md.UpdatePoll(poll)
+	// request enough suggestions to still add n should some already be present within the options.
+	suggestions, err := instance.Suggester.SuggestOptions(workspace, users, n+len(options))
 	if err != nil {
-		// if an error occurs while updating the poll, return an internal server error status.
-		log.Printf("Could not update poll with ID %s\n", id)
-		http.Error(w, "Could not update poll", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
-	log.Printf("Removed user %s from poll %s\n", user, id)
+	present := make(map[string]bool)
+	for _, opt := range options {
+		present[opt.ID] = true
+	}
+
+	for _, s := range suggestions {
+		if n == 0 {
+			break
+		}
+		if !present[s.ID] {
+			options = append(options, s)
+			present[s.ID] = true
+			n--
+		}
+	}
+
+	return options, nil
+}
+
+// parsePollQuery reads a PollQuery from the given query parameters.
+func parsePollQuery(values url.Values) (q *PollQuery, err error) {
+	q = &PollQuery{
+		Creator:     values.Get("creator"),
+		Participant: values.Get("participant"),
+		State:       PollState(values.Get("state")),
+		Restaurant:  values.Get("restaurant"),
+		Sort:        values.Get("sort"),
+		Cursor:      values.Get("cursor"),
+	}
+
+	if param := values.Get("limit"); param != "" {
+		q.Limit, err = strconv.Atoi(param)
+		if err != nil || q.Limit < 1 {
+			return nil, fmt.Errorf("could not parse limit %s", param)
+		}
+	}
+
+	if param := values.Get("createdAfter"); param != "" {
+		q.CreatedAfter, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse createdAfter %s", param)
+		}
+	}
+
+	if param := values.Get("createdBefore"); param != "" {
+		q.CreatedBefore, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse createdBefore %s", param)
+		}
+	}
 
-	w.WriteHeader(http.StatusAccepted)
 	return
+}
 
+// SplitList splits a comma separated list, such as the 'users' query parameter, into its values with surrounding spaces trimmed, dropping empty values.
+func SplitList(param string) (values []string) {
+	values = make([]string, 0)
+	for _, v := range strings.Split(param, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return
 }
 
-func lockPoll(id string) (lock *sync.Mutex) {
-	l, found := pollLocks.Load(id)
+// parseBool parses a boolean query parameter, treating an empty parameter as false.
+func parseBool(param string) (bool, error) {
+	if param == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(param)
+}
+
+// parseSettings parses the settings of a new poll from the 'allowSuggestions', 'visibility' and 'office' query parameters, along with the poll's rules using parseRules,
+// returning a ValidationError should any be invalid.
+func parseSettings(q url.Values) (*PollSettings, error) {
+	e := &ValidationError{}
 
-	if !found {
-		lock = &sync.Mutex{}
-		pollLocks.Store(id, lock)
-	} else {
-		lock = l.(*sync.Mutex)
+	allow, err := parseBool(q.Get("allowSuggestions"))
+	if err != nil {
+		e.add("allowSuggestions", "must be true or false")
 	}
 
+	v := Visibility(q.Get("visibility"))
+	validateVisibility(e, v)
+
+	rules := parseRules(e, q)
+	office := parseOffice(e, q.Get("office"))
+
+	if err = e.result(); err != nil {
+		return nil, err
+	}
+	return &PollSettings{AllowSuggestions: allow, Visibility: v, Rules: rules, Office: office}, nil
+}
+
+// parseOffice parses the location of a poll's office, given either as 'lat,lng' or as an address located using the injected Geocoder. Offices which are invalid or cannot be
+// located are added to the given ValidationError, with nil being returned should no office be given.
+func parseOffice(e *ValidationError, param string) *restaurant.Location {
+	if param == "" {
+		return nil
+	}
+
+	if parts := strings.Split(param, ","); len(parts) == 2 {
+		lat, lerr := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		lng, gerr := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if lerr == nil && gerr == nil {
+			loc := &restaurant.Location{Lat: lat, Lng: lng}
+			validateLocation(e, "office", loc)
+			return loc
+		}
+	}
+
+	if instance.Geocoder == nil {
+		e.add("office", "must be given as lat,lng")
+		return nil
+	}
+
+	loc, err := instance.Geocoder.Geocode(param)
+	if err != nil {
+		e.add("office", "could not be located: %s", err.Error())
+	}
+	return loc
+}
+
+// parseRules parses the rules of a new poll from the 'quorum', 'quorumPercent', 'invited', 'margin', 'onFailure', 'extendBy' and 'maxExtensions' query parameters, the
+// invited users being comma separated. Invalid parameters are added to the given ValidationError, with nil being returned should none of the parameters be given.
+func parseRules(e *ValidationError, q url.Values) *Rules {
+	r := &Rules{
+		Invited:   SplitList(q.Get("invited")),
+		OnFailure: FailureAction(q.Get("onFailure")),
+	}
+
+	given, parsed := len(r.Invited) > 0 || r.OnFailure != "", true
+	for _, p := range []struct {
+		name  string
+		value *int
+	}{
+		{"quorum", &r.Quorum},
+		{"quorumPercent", &r.QuorumPercent},
+		{"margin", &r.Margin},
+		{"extendBy", &r.ExtendBy},
+		{"maxExtensions", &r.MaxExtensions},
+	} {
+		param := q.Get(p.name)
+		if param == "" {
+			continue
+		}
+
+		given = true
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			e.add(p.name, "must be a whole number")
+			parsed = false
+		}
+		*p.value = n
+	}
+
+	if !given {
+		return nil
+	}
+
+	// the rules are checked once every parameter parses, so invalid numbers are not reported twice.
+	if parsed {
+		validateRules(e, r)
+	}
+	return r
+}
+
+// viewFor returns the view of the given poll for the caller of the given request, private polls only showing the caller their own vote.
+func viewFor(r *http.Request, p *Poll) *Poll {
+	return p.ViewFor(caller.User(r.Context()), time.Now())
+}
+
+// viewPage replaces each poll within the given page with its view for the given user.
+func viewPage(page *PollPage, user string) {
+	now := time.Now()
+	for i, p := range page.Polls {
+		page.Polls[i] = p.ViewFor(user, now)
+	}
+}
+
+// lockPoll locks and returns the mutex guarding changes to the poll with the given ID. LoadOrStore is used so concurrent callers locking a poll for the first time share a mutex.
+func lockPoll(id string) (lock *sync.Mutex) {
+	l, _ := pollLocks.LoadOrStore(id, &sync.Mutex{})
+	lock = l.(*sync.Mutex)
+
 	lock.Lock()
 	return
 }
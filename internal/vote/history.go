@@ -0,0 +1,237 @@
+package vote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+var errNoRollbackTarget = errors.New("one of the parameters to, at or undo must be specified")
+
+// GetHistory provides a http handler for accessing the event log of a specified poll.
+func GetHistory(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// the poll is accessed to ensure it exists within the caller's workspace before its history is returned.
+	poll, status, err := instance.Model.GetPoll(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == NotFound {
+			log.Printf("Could not find ID %s, returning not found exception.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find ID due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	events, _, err := instance.Events.GetEvents(id)
+	if err != nil {
+		log.Printf("Could not retrieve the history of poll %s due to: %s\n", id, err.Error())
+		http.Error(w, "Could not retrieve poll history", http.StatusInternalServerError)
+		return
+	}
+
+	// the history of a private poll would otherwise reveal how each user voted.
+	now := time.Now()
+	for i, e := range events {
+		events[i] = e.ViewFor(poll, caller.User(r.Context()), now)
+	}
+
+	data, err := json.Marshal(events)
+	if err != nil {
+		log.Printf("The history of poll %s could not be serialised to JSON.\n", id)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
+}
+
+// RollbackPoll provides a http handler for restoring a poll to an earlier point in its history. The point to restore to is specified by exactly one of the query parameters
+// 'to' (the sequence number of the last event to keep), 'at' (an RFC 3339 time) or 'undo' (the number of most recent events to undo). The rollback is itself recorded as an
+// event, meaning a rollback can be undone in the same way as any other action. Only the poll's organiser may roll it back, and polls whose history does not start with a
+// snapshot cannot be rolled back. As with any other change, the restored poll is settled and its options' availability refreshed.
+func RollbackPoll(w http.ResponseWriter, r *http.Request) {
+	// if no ids have been specified within the request, return a bad request status.
+	if len(r.URL.Query()["id"]) == 0 {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query()["id"][0]
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("Empty ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	md := instance.Model
+	workspace := caller.Workspace(r.Context())
+
+	lock := lockPoll(id)
+	defer lock.Unlock()
+
+	current, status, err := md.GetPoll(workspace, id)
+	if err != nil {
+		if status == NotFound {
+			log.Printf("Could not find ID %s, returning not found exception.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find ID due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if user := caller.User(r.Context()); !current.IsOrganiser(user) {
+		log.Printf("User %s is not the organiser of poll %s, returning forbidden status.\n", user, id)
+		http.Error(w, "Only the organiser of the poll may roll it back", http.StatusForbidden)
+		return
+	}
+
+	events, _, err := instance.Events.GetEvents(id)
+	if err != nil {
+		log.Printf("Could not retrieve the history of poll %s due to: %s\n", id, err.Error())
+		http.Error(w, "Could not retrieve poll history", http.StatusInternalServerError)
+		return
+	}
+
+	if len(events) == 0 {
+		log.Printf("No history recorded for poll %s, returning not found status.\n", id)
+		http.Error(w, "No history found for poll with specified ID", http.StatusNotFound)
+		return
+	}
+
+	if !Replayable(events) {
+		log.Printf("The history of poll %s does not start with a snapshot, returning conflict status.\n", id)
+		http.Error(w, "The poll was created before its history was recorded, so cannot be rolled back", http.StatusConflict)
+		return
+	}
+
+	seq, err := rollbackTarget(r, events)
+	if err != nil {
+		log.Printf("Could not determine rollback target for poll %s due to: %s\n", id, err.Error())
+		http.Error(w, "Could not parse rollback target", http.StatusBadRequest)
+		return
+	}
+
+	poll := Replay(id, events, seq)
+	poll.Workspace = workspace
+	// voters of a private poll were promised their votes would stay hidden, so rolling back to before the poll was made private keeps it private.
+	if current.IsPrivate() {
+		poll.Visibility = current.Visibility
+	}
+	settled := settle(poll)
+	avail := refreshAvailability(poll)
+	status, err = md.UpdatePoll(poll)
+
+	if err != nil {
+		if status == NotFound {
+			// if the specified poll ID could not be found, return a not found status.
+			log.Printf("Could not roll back poll due to not finding the id %s\n", id)
+			http.Error(w, "Could not find poll with specified ID", http.StatusNotFound)
+		} else if status == Invalid {
+			// if the restored poll is no longer valid, return a bad request status.
+			log.Printf("Could not roll back poll %s due to: %s\n", id, err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			// otherwise return an internal server error status.
+			log.Printf("Could not roll back poll %s due to being unable to connect to the database\n", id)
+			http.Error(w, "Could not roll back poll", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	e := NewEvent(PollRolledBack, poll)
+	e.Snapshot = poll.Copy()
+	e.RestoredTo = seq
+	recordEvent(e)
+	if settled != nil {
+		recordEvent(settled)
+	}
+	for _, ae := range avail {
+		recordEvent(ae)
+	}
+	events = append([]*Event{e, settled}, avail...)
+
+	data, err := json.Marshal(viewFor(r, poll))
+	if err != nil {
+		log.Printf("The poll %v could not be serialised to JSON.\n", poll)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Rolled back poll %s to event %v\n", id, seq)
+	publish(poll, events...)
+	broadcast(poll, events...)
+	w.Write(data)
+}
+
+// rollbackTarget determines the sequence number a rollback request should restore a poll to, given the poll's event log.
+func rollbackTarget(r *http.Request, events []*Event) (seq int, err error) {
+	q := r.URL.Query()
+	last := events[len(events)-1].Sequence
+
+	switch {
+	case q.Get("to") != "":
+		seq, err = strconv.Atoi(q.Get("to"))
+		if err == nil && (seq < 1 || seq > last) {
+			err = fmt.Errorf("event %v is outside of the poll's history of %v events", seq, last)
+		}
+	case q.Get("at") != "":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, q.Get("at"))
+		if err == nil {
+			seq = SequenceAt(events, t)
+			if seq == 0 {
+				err = fmt.Errorf("the poll has no history before %s", t)
+			}
+		}
+	case q.Get("undo") != "":
+		var n int
+		n, err = strconv.Atoi(q.Get("undo"))
+		if err == nil && (n < 1 || n >= last) {
+			err = fmt.Errorf("cannot undo %v events of the poll's history of %v events", n, last)
+		}
+		seq = last - n
+	default:
+		err = errNoRollbackTarget
+	}
+
+	return
+}
+
+// recordEvent stores the given event using the injected EventModel. Failing to store an event is logged rather than returned, as the change the event records has already been
+// applied to the poll.
+func recordEvent(e *Event) {
+	if instance.Events == nil {
+		return
+	}
+
+	_, err := instance.Events.AddEvent(e)
+	if err != nil {
+		log.Printf("Could not record %s event for poll %s due to: %s\n", e.Type, e.PollID, err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+package vote
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetHistory(t *testing.T) {
+	organisedPoll(t, false)
+	CastVote("", "new poll", "Jack", "r1")
+	CastVote("", "new poll", "Tom", "r1")
+
+	w, _ := serveAs(GetHistory, "Jack", http.MethodGet, "/poll/history?id=new%20poll", "")
+	var events []*Event
+	json.Unmarshal(w.Body.Bytes(), &events)
+	if w.Code != http.StatusOK || len(events) != 3 || events[0].Type != PollCreated || events[2].User != "Tom" || events[2].Sequence != 3 {
+		t.Logf("Expected the poll's creation and both votes, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	if w, _ := serveAs(GetHistory, "Jack", http.MethodGet, "/poll/history?id=unknown", ""); w.Code != http.StatusNotFound {
+		t.Logf("Expected the history of an unknown poll not to be found, got %v", w.Code)
+		t.Fail()
+	}
+	if w, _ := serveAs(GetHistory, "Jack", http.MethodGet, "/poll/history", ""); w.Code != http.StatusBadRequest {
+		t.Logf("Expected a request without an ID to be rejected, got %v", w.Code)
+		t.Fail()
+	}
+}
+
+func TestRollbackPoll(t *testing.T) {
+	organisedPoll(t, false)
+	CastVote("", "new poll", "Jack", "r1")
+	CastVote("", "new poll", "Tom", "r1")
+
+	if w, _ := serveAs(RollbackPoll, "Tom", http.MethodPost, "/poll/rollback?id=new%20poll&undo=1", ""); w.Code != http.StatusForbidden {
+		t.Logf("Expected only the organiser to roll back the poll, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p := serveAs(RollbackPoll, "Jack", http.MethodPost, "/poll/rollback?id=new%20poll&undo=1", "")
+	if w.Code != http.StatusOK || len(p.Votes["r1"]) != 1 || p.Votes["r1"][0] != "Jack" || len(p.Options) != 1 || p.Creator != "Jack" {
+		t.Logf("Expected Tom's vote to be undone, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	stored, _, _ := FindPoll("", "new poll")
+	events, _, _ := instance.Events.GetEvents("new poll")
+	if len(stored.Votes["r1"]) != 1 || len(events) != 4 || events[3].Type != PollRolledBack || events[3].RestoredTo != 2 {
+		t.Logf("Expected the rollback to be stored and recorded, got %v %v", stored.Votes, events)
+		t.Fail()
+	}
+
+	for _, query := range []string{"", "&to=9", "&undo=4", "&at=yesterday"} {
+		if w, _ := serveAs(RollbackPoll, "Jack", http.MethodPost, "/poll/rollback?id=new%20poll"+query, ""); w.Code != http.StatusBadRequest {
+			t.Logf("Expected the rollback target %q to be rejected, got %v", query, w.Code)
+			t.Fail()
+		}
+	}
+}
+
+func TestRollbackPollWithoutSnapshot(t *testing.T) {
+	organisedPoll(t, false)
+	// polls created before the event log was introduced only have the events recorded since.
+	instance.Events = &MockEventModel{}
+	CastVote("", "new poll", "Jack", "r1")
+	CastVote("", "new poll", "Tom", "r1")
+
+	if w, _ := serveAs(RollbackPoll, "Jack", http.MethodPost, "/poll/rollback?id=new%20poll&to=1", ""); w.Code != http.StatusConflict {
+		t.Logf("Expected a poll without a snapshot not to be rolled back, got %v", w.Code)
+		t.Fail()
+	}
+
+	stored, _, _ := FindPoll("", "new poll")
+	if len(stored.Options) != 1 || len(stored.Votes["r1"]) != 2 {
+		t.Logf("Expected the poll to be left unchanged, got %+v", stored)
+		t.Fail()
+	}
+}
@@ -0,0 +1,758 @@
+package vote
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+// The operations within this file apply changes to polls using the injected PollModel, recording each change within the poll's event log and notifying any websocket clients
+// watching the poll. They are shared by every API exposing polls, ensuring changes are treated the same however they are made.
+
+// FindPoll returns the poll with the given ID within the given workspace.
+func FindPoll(workspace string, id string) (*Poll, Status, error) {
+	return instance.Model.GetPoll(workspace, id)
+}
+
+// FindPolls returns the page of polls within the given workspace matching the given query.
+func FindPolls(workspace string, q *PollQuery) (*PollPage, Status, error) {
+	return instance.Model.ListPolls(workspace, q)
+}
+
+// PollSettings holds the choices made by the organiser of a poll when creating it.
+type PollSettings struct {
+	// AllowSuggestions states whether users other than the organiser may suggest options.
+	AllowSuggestions bool
+	// Visibility states who may see the poll's votes.
+	Visibility Visibility
+	// Rules states the requirements the poll must meet to be decided, nil leaving the poll without rules.
+	Rules *Rules
+	// Office gives the location the poll's order is delivered to, nil should it be unknown.
+	Office *restaurant.Location
+	// CloseAfter states how long after its creation the poll closes, zero leaving the poll without a closing time.
+	CloseAfter time.Duration
+}
+
+// CreatePoll creates a new poll within the given workspace with the given options, recording the given user as the poll's creator and organiser along with the given settings,
+// which may be nil to use the default settings. Failing to record these is logged rather than returned, as the poll has already been created. Options without a location are
+// located using their address, and options closed at the poll's order time are marked as unavailable.
+func CreatePoll(workspace string, creator string, options []*restaurant.Building, settings *PollSettings) (poll *Poll, status Status, err error) {
+	if settings == nil {
+		settings = &PollSettings{}
+	}
+
+	if err = ValidateVisibility(settings.Visibility); err != nil {
+		return nil, Invalid, err
+	}
+	if settings.Rules != nil {
+		if err = ValidateRules(settings.Rules); err != nil {
+			return nil, Invalid, err
+		}
+	}
+
+	locate(options)
+
+	md := instance.Model
+	poll, status, err = md.NewPoll(workspace, options)
+	if err != nil {
+		return
+	}
+
+	if settings.CloseAfter > 0 {
+		poll.ClosesAt = poll.CreatedAt.Add(settings.CloseAfter)
+	}
+	poll.UpdateAvailability(time.Now())
+	if creator != "" || *settings != (PollSettings{}) || len(poll.Unavailable) > 0 {
+		poll.Creator = creator
+		poll.AllowSuggestions = settings.AllowSuggestions
+		poll.Visibility = settings.Visibility
+		poll.Rules = settings.Rules
+		poll.Office = settings.Office
+		if _, uerr := md.UpdatePoll(poll); uerr != nil {
+			log.Printf("Could not record %s as the creator of poll %s due to: %s\n", creator, poll.ID, uerr.Error())
+		}
+	}
+
+	e := NewEvent(PollCreated, poll)
+	e.Snapshot = poll.Copy()
+	recordEvent(e)
+	// new polls are not broadcast as no websocket client can be watching them yet, but are published.
+	publish(poll, e)
+	return
+}
+
+// SavePoll replaces the stored poll with the same ID and workspace as the given poll with the given poll on behalf of the given user. The poll's participants are recalculated
+// from its votes rather than trusted from the caller. Callers only see a view of private polls, so the stored votes, rights and vetoes of a private poll are kept rather than
+// replaced, and its visibility cannot be changed.
+// The poll's creator and vetoes, which are only changed through their own operations, are kept from the stored poll, as are the rights, pending suggestions, rules and
+// suggestion setting should the user not organise the stored poll. How the poll has been settled against its rules is kept from the stored poll too, with the replaced poll
+// then being settled against its rules and the availability of its options updated.
+func SavePoll(p *Poll, user string) (status Status, err error) {
+	lock := lockPoll(p.ID)
+	defer lock.Unlock()
+
+	p.Tally, p.Counts, p.ResultsHidden, p.Delivery = nil, nil, false, nil
+	p.Outcome, p.Extensions = "", 0
+	if stored, _, gerr := instance.Model.GetPoll(p.Workspace, p.ID); gerr == nil {
+		p.Outcome, p.Extensions = stored.Outcome, stored.Extensions
+		kept := stored.Copy()
+		p.Creator, p.Vetoed = kept.Creator, kept.Vetoed
+		if !stored.IsOrganiser(user) {
+			p.Rights, p.Pending, p.Rules, p.AllowSuggestions = kept.Rights, kept.Pending, kept.Rules, kept.AllowSuggestions
+		}
+		if p.Visibility == "" {
+			p.Visibility = stored.Visibility
+		}
+
+		if stored.IsPrivate() {
+			if p.Visibility != stored.Visibility {
+				verr := &ValidationError{}
+				verr.add("visibility", "the visibility of a %s poll cannot be changed", stored.Visibility)
+				return Invalid, verr
+			}
+			p.Votes, p.Rights = kept.Votes, kept.Rights
+		}
+	}
+
+	p.updateParticipants()
+	s := settle(p)
+	avail := refreshAvailability(p)
+	status, err = instance.Model.UpdatePoll(p)
+	if err != nil {
+		return
+	}
+
+	e := NewEvent(PollUpdated, p)
+	e.Snapshot = p.Copy()
+	recordEvent(e)
+	if s != nil {
+		recordEvent(s)
+	}
+	for _, ae := range avail {
+		recordEvent(ae)
+	}
+
+	publish(p, append([]*Event{e, s}, avail...)...)
+	broadcast(p, append([]*Event{s}, avail...)...)
+	return
+}
+
+// RemovePoll deletes the poll with the given ID from the given workspace.
+func RemovePoll(workspace string, id string) (Status, error) {
+	return instance.Model.DeletePoll(workspace, id)
+}
+
+// CheckVoter returns an error along with a 'Forbidden' status should the given user be changing the vote of another voter within the specified private poll, as the change to the
+// poll's counts would reveal the other voter's vote.
+func CheckVoter(workspace string, id string, user string, voter string) (Status, error) {
+	if user == voter {
+		return Ok, nil
+	}
+
+	p, status, err := FindPoll(workspace, id)
+	if err != nil {
+		return status, err
+	}
+	if p.IsPrivate() {
+		return Forbidden, fmt.Errorf("only %s may change their vote within %s poll %s", voter, p.Visibility, p.ID)
+	}
+	return Ok, nil
+}
+
+// CastVote records a vote by the given user for the option with the given ID within the specified poll, replacing any vote the user has previously made. A Conflict status is
+// returned should the poll have been settled against its rules, and an Invalid status should the option be unavailable.
+func CastVote(workspace string, id string, user string, optionID string) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if err = checkUnsettled(p); err != nil {
+			return nil, Conflict, err
+		}
+		if err = ValidateVote(p, user, optionID); err != nil {
+			return nil, Invalid, err
+		}
+		if !p.IsAvailable(optionID, time.Now()) {
+			verr := &ValidationError{}
+			verr.add("optionId", "%s is closed at the poll's order time", optionID)
+			return nil, Invalid, verr
+		}
+
+		p.AddVote(optionID, user)
+
+		e = NewEvent(VoteCast, p)
+		e.User = user
+		e.OptionID = optionID
+		return
+	})
+}
+
+// RemoveVoter removes every vote made by the given user within the specified poll.
+func RemoveVoter(workspace string, id string, user string) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if err = checkUnsettled(p); err != nil {
+			return nil, Conflict, err
+		}
+		p.ClearVotesFor(user)
+
+		e = NewEvent(UserRemoved, p)
+		e.User = user
+		return
+	})
+}
+
+// AddPollOption adds the given restaurant as an option within the specified poll on behalf of the given user. Options added by the poll's organiser are added immediately, while
+// options added by other users are held as pending until the organiser approves them, provided the poll allows suggestions, with pending stating which took place. A Forbidden
+// status is returned should the poll not allow suggestions, and an Invalid status should the restaurant not pass ValidateOption or share its ID or name with another option.
+func AddPollOption(workspace string, id string, user string, b *restaurant.Building) (poll *Poll, pending bool, status Status, err error) {
+	poll, status, err = modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if !p.IsOrganiser(user) && !p.AllowSuggestions {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may add options", p.ID)
+		}
+
+		if err = ValidateOption(b); err != nil {
+			return nil, Invalid, err
+		}
+		locate([]*restaurant.Building{b})
+
+		// suggestions must not clash with the poll's options, nor with other suggestions, so they can be approved.
+		options := append([]*restaurant.Building{}, p.Options...)
+		for _, s := range p.Pending {
+			options = append(options, s.Option)
+		}
+		if err = ValidateOptions(append(options, b)); err != nil {
+			return nil, Invalid, err
+		}
+
+		if p.IsOrganiser(user) {
+			p.AddOption(b)
+			e = NewEvent(OptionAdded, p)
+		} else {
+			p.Suggest(b, user, time.Now())
+			pending = true
+			e = NewEvent(OptionSuggested, p)
+		}
+
+		e.User = user
+		e.OptionID = b.ID
+		e.Snapshot = p.Copy()
+		return
+	})
+	return
+}
+
+// ApprovePollOption adds the pending option with the given ID to the specified poll, returning a Forbidden status should the given user not be the poll's organiser.
+func ApprovePollOption(workspace string, id string, user string, optionID string) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if !p.IsOrganiser(user) {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may approve options", p.ID)
+		}
+
+		s := p.TakeSuggestion(optionID)
+		if s == nil {
+			return nil, NotFound, fmt.Errorf("the option %s is not pending within poll %s", optionID, p.ID)
+		}
+
+		if err = ValidateOptions(append(append([]*restaurant.Building{}, p.Options...), s.Option)); err != nil {
+			return nil, Invalid, err
+		}
+
+		p.AddOption(s.Option)
+
+		e = NewEvent(OptionAdded, p)
+		e.User = user
+		e.OptionID = optionID
+		e.Snapshot = p.Copy()
+		return
+	})
+}
+
+// RemovePollOption removes the option with the given ID from the specified poll on behalf of the given user. Pending options may be rejected by the organiser or withdrawn by the
+// user who suggested them, while all other options may only be removed by the organiser. Votes cast for a removed option are moved to the option with ID moveTo should one be given,
+// or discarded should discardVotes be set; removing an option with votes without doing either returns a Conflict status, ensuring votes are never lost by accident.
+func RemovePollOption(workspace string, id string, user string, optionID string, moveTo string, discardVotes bool) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if s := p.Suggestion(optionID); s != nil {
+			if !p.IsOrganiser(user) && s.SuggestedBy != user {
+				return nil, Forbidden, fmt.Errorf("only the organiser of poll %s or the user who suggested %s may reject it", p.ID, optionID)
+			}
+
+			p.TakeSuggestion(optionID)
+
+			e = NewEvent(OptionRejected, p)
+			e.User = user
+			e.OptionID = optionID
+			e.Snapshot = p.Copy()
+			return
+		}
+
+		if p.Option(optionID) == nil {
+			return nil, NotFound, fmt.Errorf("the option %s could not be found within poll %s", optionID, p.ID)
+		}
+
+		if !p.IsOrganiser(user) {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may remove options", p.ID)
+		}
+
+		if moveTo != "" {
+			if moveTo == optionID || p.Option(moveTo) == nil {
+				verr := &ValidationError{}
+				verr.add("moveVotesTo", "%s is not another option within poll %s", moveTo, p.ID)
+				return nil, Invalid, verr
+			}
+			p.MoveVotes(optionID, moveTo)
+		} else if !discardVotes && len(p.Votes[optionID]) > 0 {
+			return nil, Conflict, fmt.Errorf("the option %s has %d votes, which must be moved to another option or explicitly discarded", optionID, len(p.Votes[optionID]))
+		}
+
+		p.RemoveOption(optionID)
+
+		e = NewEvent(OptionRemoved, p)
+		e.User = user
+		e.OptionID = optionID
+		e.Snapshot = p.Copy()
+		return
+	})
+}
+
+// RenamePollOption applies the given patch to the option with the given ID within the specified poll, returning a Forbidden status should the given user not be the poll's
+// organiser. Votes are keyed by option ID, so are kept.
+func RenamePollOption(workspace string, id string, user string, optionID string, patch *OptionPatch) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		opt := p.Option(optionID)
+		if opt == nil {
+			return nil, NotFound, fmt.Errorf("the option %s could not be found within poll %s", optionID, p.ID)
+		}
+
+		if !p.IsOrganiser(user) {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may rename options", p.ID)
+		}
+
+		name, address := opt.Name, opt.Address
+		if patch.Name != nil {
+			name = *patch.Name
+		}
+		if patch.Address != nil {
+			address = *patch.Address
+		}
+		p.RenameOption(optionID, name, address)
+
+		if err = ValidateOptions(p.Options); err != nil {
+			return nil, Invalid, err
+		}
+
+		e = NewEvent(OptionRenamed, p)
+		e.User = user
+		e.OptionID = optionID
+		e.Snapshot = p.Copy()
+		return
+	})
+}
+
+// SetParticipantRights sets the rights of the given participant within the specified poll, returning a Forbidden status should the given user not be the poll's organiser and an
+// Invalid status should the rights not pass ValidateRights. Vetoes made by the participant beyond their new quota are withdrawn.
+func SetParticipantRights(workspace string, id string, user string, participant string, r Rights) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if !p.IsOrganiser(user) {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may change the rights of its participants", p.ID)
+		}
+
+		if err = ValidateRights(r); err != nil {
+			return nil, Invalid, err
+		}
+
+		p.SetRights(participant, r)
+
+		e = NewEvent(RightsChanged, p)
+		e.User = participant
+		e.Snapshot = p.Copy()
+		return
+	})
+}
+
+// VetoOption records the given user vetoing the option with the given ID within the specified poll, preventing the option from winning. A Forbidden status is returned should the
+// user have no right to veto options, and a Conflict status should the user have already vetoed the option or used all of their vetoes, or the poll have been settled against its rules.
+func VetoOption(workspace string, id string, user string, optionID string) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if p.Option(optionID) == nil {
+			verr := &ValidationError{}
+			verr.add("optionId", "%s is not an option within poll %s", optionID, p.ID)
+			return nil, Invalid, verr
+		}
+
+		if err = checkUnsettled(p); err != nil {
+			return nil, Conflict, err
+		}
+
+		quota := p.RightsOf(user).Vetoes
+		if quota == 0 {
+			return nil, Forbidden, fmt.Errorf("%s has no right to veto options within poll %s", user, p.ID)
+		}
+		if used := len(p.VetoesBy(user)); used >= quota {
+			return nil, Conflict, fmt.Errorf("%s has already used all %d of their vetoes within poll %s", user, quota, p.ID)
+		}
+		if !p.Veto(optionID, user) {
+			return nil, Conflict, fmt.Errorf("%s has already vetoed %s within poll %s", user, optionID, p.ID)
+		}
+
+		e = NewEvent(OptionVetoed, p)
+		e.User = user
+		e.OptionID = optionID
+		return
+	})
+}
+
+// WithdrawVeto withdraws the given user's veto of the option with the given ID within the specified poll, returning a NotFound status should the user not have vetoed the option.
+func WithdrawVeto(workspace string, id string, user string, optionID string) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if err = checkUnsettled(p); err != nil {
+			return nil, Conflict, err
+		}
+		if !p.Unveto(optionID, user) {
+			return nil, NotFound, fmt.Errorf("%s has not vetoed %s within poll %s", user, optionID, p.ID)
+		}
+
+		e = NewEvent(VetoWithdrawn, p)
+		e.User = user
+		e.OptionID = optionID
+		return
+	})
+}
+
+// SetPollRules sets the rules the specified poll must meet to be decided, nil rules removing the poll's rules. A Forbidden status is returned should the given user not be the
+// poll's organiser, an Invalid status should the rules not pass ValidateRules and a Conflict status should the poll have already been settled. The poll is settled against its
+// new rules straight away, so may be decided, extended or failed by the change.
+func SetPollRules(workspace string, id string, user string, r *Rules) (*Poll, Status, error) {
+	return modifyPoll(workspace, id, func(p *Poll) (e *Event, status Status, err error) {
+		if !p.IsOrganiser(user) {
+			return nil, Forbidden, fmt.Errorf("only the organiser of poll %s may change its rules", p.ID)
+		}
+
+		if err = checkUnsettled(p); err != nil {
+			return nil, Conflict, err
+		}
+
+		if r != nil {
+			if err = ValidateRules(r); err != nil {
+				return nil, Invalid, err
+			}
+		}
+
+		p.Rules = r
+
+		e = NewEvent(RulesChanged, p)
+		e.User = user
+		e.Snapshot = p.Copy()
+		return
+	})
+}
+
+// SubmitReview records the given user's review of the winner of the specified poll, replacing any review they have previously left, with tags being trimmed and lowercased. A
+// Conflict status is returned should the poll be open, have failed or have no winner, a Forbidden status should the user not have voted within the poll and an Invalid status
+// should the review not pass ValidateReview.
+func SubmitReview(workspace string, id string, user string, r *Review) (*Review, Status, error) {
+	if instance.Reviews == nil {
+		return nil, NoConnection, fmt.Errorf("reviews cannot be stored")
+	}
+
+	p, status, err := instance.Model.GetPoll(workspace, id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	now := time.Now()
+	if p.State(now) == PollOpen {
+		return nil, Conflict, fmt.Errorf("poll %s cannot be reviewed until it closes", p.ID)
+	}
+	if p.Outcome == Failed {
+		return nil, Conflict, fmt.Errorf("poll %s failed so has no winner to review", p.ID)
+	}
+	winner := p.Winner()
+	if winner == nil {
+		return nil, Conflict, fmt.Errorf("poll %s has no winner to review", p.ID)
+	}
+	if !contains(p.Participants, user) {
+		return nil, Forbidden, fmt.Errorf("only users who voted within poll %s may review it", p.ID)
+	}
+
+	review := &Review{
+		PollID:         p.ID,
+		Workspace:      p.Workspace,
+		RestaurantID:   winner.ID,
+		RestaurantName: winner.Name,
+		User:           user,
+		Rating:         r.Rating,
+		Comment:        strings.TrimSpace(r.Comment),
+		CreatedAt:      now,
+	}
+	for _, t := range r.Tags {
+		review.Tags = append(review.Tags, strings.ToLower(strings.TrimSpace(t)))
+	}
+	if err = ValidateReview(review); err != nil {
+		return nil, Invalid, err
+	}
+
+	status, err = instance.Reviews.SaveReview(review)
+	if err != nil {
+		return nil, status, err
+	}
+	return review, Ok, nil
+}
+
+// FindReviews returns the reviews left for the specified poll, returning a NotFound status should the poll not exist.
+func FindReviews(workspace string, id string) ([]*Review, Status, error) {
+	if instance.Reviews == nil {
+		return nil, NoConnection, fmt.Errorf("reviews cannot be stored")
+	}
+
+	if _, status, err := instance.Model.GetPoll(workspace, id); err != nil {
+		return nil, status, err
+	}
+	return instance.Reviews.GetPollReviews(workspace, id)
+}
+
+// rate sets the ratings of the given view's options from the reviews left within the poll's workspace. Failing to retrieve the reviews is logged rather than returned, leaving
+// the view without ratings, as the ratings are only informative.
+func rate(view *Poll) {
+	if instance.Reviews == nil || len(view.Options) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(view.Options))
+	for _, opt := range view.Options {
+		ids = append(ids, opt.ID)
+	}
+
+	reviews, _, err := instance.Reviews.GetRestaurantReviews(view.Workspace, ids)
+	if err != nil {
+		log.Printf("Could not retrieve the ratings of the options of poll %s due to: %s\n", view.ID, err.Error())
+		return
+	}
+	view.Ratings = Ratings(reviews)
+}
+
+// SettlePoll settles the specified poll against its rules at the current time, storing and broadcasting the poll should it be decided, extended or failed, or should any of its
+// options become unavailable as a result. The returned event is nil should the poll not have been settled.
+func SettlePoll(workspace string, id string) (poll *Poll, e *Event, status Status, err error) {
+	md := instance.Model
+
+	lock := lockPoll(id)
+	defer lock.Unlock()
+
+	stored, status, err := md.GetPoll(workspace, id)
+	if err != nil {
+		return
+	}
+	poll = stored.Copy()
+
+	e = settle(poll)
+	avail := refreshAvailability(poll)
+	if e == nil && len(avail) == 0 {
+		return
+	}
+
+	status, err = md.UpdatePoll(poll)
+	if err != nil {
+		return nil, nil, status, err
+	}
+
+	if e != nil {
+		recordEvent(e)
+	}
+	for _, ae := range avail {
+		recordEvent(ae)
+	}
+	publish(poll, append([]*Event{e}, avail...)...)
+	broadcast(poll, append([]*Event{e}, avail...)...)
+	return
+}
+
+// locate sets the location of each of the given restaurants without one using the injected Geocoder, should one have been injected. Addresses which cannot be found are logged
+// and the restaurant left without a location, as a restaurant's location is not required.
+func locate(options []*restaurant.Building) {
+	if instance.Geocoder == nil {
+		return
+	}
+
+	for _, opt := range options {
+		if opt == nil || opt.Location != nil || strings.TrimSpace(opt.Address) == "" {
+			continue
+		}
+
+		loc, err := instance.Geocoder.Geocode(opt.Address)
+		if err != nil {
+			log.Printf("Could not locate %s due to: %s\n", opt.Name, err.Error())
+			continue
+		}
+		opt.Location = loc
+	}
+}
+
+// checkUnsettled returns an error should the given poll have been settled against its rules, votes and vetoes no longer being accepted once a poll has been settled.
+func checkUnsettled(p *Poll) error {
+	if p.Outcome != "" {
+		return fmt.Errorf("poll %s has already been %s", p.ID, p.Outcome)
+	}
+	return nil
+}
+
+// settle settles the given poll against its rules at the current time, returning the event recording the change of the poll's status or nil should the poll be unchanged.
+func settle(p *Poll) *Event {
+	t := p.Settle(time.Now())
+	if t == "" {
+		return nil
+	}
+
+	e := NewEvent(t, p)
+	e.Snapshot = p.Copy()
+	return e
+}
+
+// RefreshRestaurant copies the opening hours of the given restaurant into every open poll within the given workspace with the restaurant as an option, ensuring options which
+// become unavailable through a change to their restaurant's hours are marked as such mid-poll. Polls which cannot be updated are logged and skipped.
+func RefreshRestaurant(workspace string, b *restaurant.Building) {
+	q := &PollQuery{Restaurant: b.ID, State: PollOpen, Limit: MaxPollLimit}
+	for {
+		page, _, err := instance.Model.ListPolls(workspace, q)
+		if err != nil {
+			log.Printf("Could not list the polls with restaurant %s as an option due to: %s\n", b.ID, err.Error())
+			return
+		}
+
+		for _, p := range page.Polls {
+			_, _, err = modifyPoll(workspace, p.ID, func(p *Poll) (e *Event, status Status, err error) {
+				opt := p.Option(b.ID)
+				if opt == nil || (reflect.DeepEqual(opt.Hours, b.Hours) && reflect.DeepEqual(opt.Exceptions, b.Exceptions) && opt.TimeZone == b.TimeZone) {
+					return
+				}
+
+				c := b.Copy()
+				opt.Hours, opt.Exceptions, opt.TimeZone = c.Hours, c.Exceptions, c.TimeZone
+
+				e = NewEvent(OptionHoursChanged, p)
+				e.OptionID = b.ID
+				e.Snapshot = p.Copy()
+				return
+			})
+			if err != nil {
+				log.Printf("Could not update the hours of restaurant %s within poll %s due to: %s\n", b.ID, p.ID, err.Error())
+			}
+		}
+
+		if page.NextCursor == "" {
+			return
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// refreshAvailability updates which of the given poll's options are unavailable at the current time, returning an event for each option which has become unavailable or
+// available again.
+func refreshAvailability(p *Poll) (events []*Event) {
+	unavailable, available := p.UpdateAvailability(time.Now())
+	for _, id := range unavailable {
+		e := NewEvent(OptionUnavailable, p)
+		e.OptionID = id
+		events = append(events, e)
+	}
+	for _, id := range available {
+		e := NewEvent(OptionAvailable, p)
+		e.OptionID = id
+		events = append(events, e)
+	}
+	return
+}
+
+// modifyPoll applies the given change to the specified poll while holding the poll's lock, storing the changed poll and recording the event returned by the change. The change is
+// applied to a copy of the stored poll, so should either the change or the PollModel reject it the poll is left unchanged, as it is should the change return no event. The changed
+// poll is then settled against its rules, allowing a vote to decide the poll early, and the availability of its options updated.
+func modifyPoll(workspace string, id string, change func(p *Poll) (*Event, Status, error)) (poll *Poll, status Status, err error) {
+	md := instance.Model
+
+	lock := lockPoll(id)
+	defer lock.Unlock()
+
+	stored, status, err := md.GetPoll(workspace, id)
+	if err != nil {
+		return
+	}
+	poll = stored.Copy()
+
+	e, status, err := change(poll)
+	if err != nil {
+		poll = nil
+		return
+	}
+	if e == nil {
+		poll = stored
+		return
+	}
+	s := settle(poll)
+	avail := refreshAvailability(poll)
+
+	status, err = md.UpdatePoll(poll)
+	if err != nil {
+		poll = nil
+		return
+	}
+
+	recordEvent(e)
+	if s != nil {
+		recordEvent(s)
+	}
+	for _, ae := range avail {
+		recordEvent(ae)
+	}
+	publish(poll, append([]*Event{e, s}, avail...)...)
+	// changes to a poll's options are broadcast as events too, letting clients show what changed rather than only the resulting poll.
+	if !e.Type.IsOptionChange() {
+		e = nil
+	}
+	broadcast(poll, append([]*Event{e, s}, avail...)...)
+	return
+}
+
+// decide records, publishes and broadcasts the decision of the given poll without rules, which is decided once it closes, returning the recorded event. Polls with rules are
+// decided by settle instead, so nil is returned for them.
+func decide(p *Poll) *Event {
+	if p.Rules != nil {
+		return nil
+	}
+
+	e := NewEvent(PollDecided, p)
+	e.Snapshot = p.Copy()
+	recordEvent(e)
+	publish(p, e)
+	broadcast(p, e)
+	return e
+}
+
+// broadcast notifies websocket clients watching the given poll of a change to it, sending the given events before the poll, with nil events being skipped. Every client receives
+// the same message, so private polls are broadcast as seen by a user who has not voted.
+func broadcast(p *Poll, events ...*Event) {
+	now := time.Now()
+	for _, e := range events {
+		if e != nil {
+			websocket.NotifyChange(p.ID, e.ViewFor(p, "", now))
+		}
+	}
+	websocket.NotifyChange(p.ID, p.ViewFor("", now))
+}
+
+// publish notifies the Publisher of each of the given events made to the given poll, with nil events being skipped. As with broadcasts, events and polls are published as seen
+// by a user who has not voted.
+func publish(p *Poll, events ...*Event) {
+	if instance.Publisher == nil {
+		return
+	}
+
+	now := time.Now()
+	view := p.ViewFor("", now)
+	for _, e := range events {
+		if e != nil {
+			instance.Publisher.Publish(e.ViewFor(p, "", now), view)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package vote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// AddOption provides a http handler for adding a single option to the poll specified by the 'id' query parameter, without the caller needing to replace the whole poll. Options
+// added by the poll's organiser are added immediately, returning a created status, while options suggested by other users are held for the organiser's approval, returning an
+// accepted status.
+func AddOption(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	var data restaurant.Building
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		log.Printf("Could not parse %s as an option\n", b)
+		http.Error(w, "Could not parse given option", http.StatusBadRequest)
+		return
+	}
+
+	poll, pending, status, err := AddPollOption(caller.Workspace(r.Context()), id, caller.User(r.Context()), &data)
+	if err != nil {
+		log.Printf("Could not add option %s to poll %s due to: %s\n", data.ID, id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	if pending {
+		log.Printf("Option %s suggested for poll %s by %s\n", data.ID, id, caller.User(r.Context()))
+		writePoll(w, r, http.StatusAccepted, poll)
+		return
+	}
+
+	log.Printf("Added option %s to poll %s\n", data.ID, id)
+	writePoll(w, r, http.StatusCreated, poll)
+}
+
+// RemoveOption provides a http handler for removing the option specified by the 'option' query parameter from the poll specified by the 'id' query parameter. Votes for the
+// option are moved to the option given by the 'moveVotesTo' parameter, or discarded should 'discardVotes' be true, with a conflict status being returned should the option have
+// votes and neither be given. Pending options are rejected, or withdrawn when removed by the user who suggested them.
+func RemoveOption(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, option := q.Get("id"), q.Get("option")
+	if id == "" || option == "" {
+		log.Println("No poll or option ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	discard, err := parseBool(q.Get("discardVotes"))
+	if err != nil {
+		log.Printf("Invalid discardVotes %s requested\n", q.Get("discardVotes"))
+		http.Error(w, "Could not parse discardVotes", http.StatusBadRequest)
+		return
+	}
+
+	poll, status, err := RemovePollOption(caller.Workspace(r.Context()), id, caller.User(r.Context()), option, q.Get("moveVotesTo"), discard)
+	if err != nil {
+		log.Printf("Could not remove option %s from poll %s due to: %s\n", option, id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("Removed option %s from poll %s\n", option, id)
+	writePoll(w, r, http.StatusOK, poll)
+}
+
+// ApproveOption provides a http handler allowing the organiser of the poll specified by the 'id' query parameter to approve the pending option specified by the 'option' query
+// parameter, adding it to the poll's options.
+func ApproveOption(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, option := q.Get("id"), q.Get("option")
+	if id == "" || option == "" {
+		log.Println("No poll or option ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	poll, status, err := ApprovePollOption(caller.Workspace(r.Context()), id, caller.User(r.Context()), option)
+	if err != nil {
+		log.Printf("Could not approve option %s within poll %s due to: %s\n", option, id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("Approved option %s within poll %s\n", option, id)
+	writePoll(w, r, http.StatusOK, poll)
+}
+
+// writePoll writes the view of the given poll for the caller of the given request as the response body.
+func writePoll(w http.ResponseWriter, r *http.Request, status int, poll *Poll) {
+	data, err := json.Marshal(viewFor(r, poll))
+	if err != nil {
+		log.Printf("The poll %v could not be serialised to JSON.\n", poll)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,141 @@
+package vote
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+// organisedPoll creates the poll "new poll", organised by Jack, with the single option r1.
+func organisedPoll(t *testing.T, allowSuggestions bool) {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	Init(&Container{Model: &MockPollModel{}, Events: &MockEventModel{}})
+
+	_, _, err := CreatePoll("", "Jack", []*restaurant.Building{{ID: "r1", Name: "Restaurant 1"}}, &PollSettings{AllowSuggestions: allowSuggestions})
+	if err != nil {
+		t.Logf("Expected the poll to be created, got %s", err.Error())
+		t.FailNow()
+	}
+}
+
+// serveAs calls the given handler on behalf of the given user, returning the recorded response along with the poll it contains.
+func serveAs(handler http.HandlerFunc, user string, method string, url string, body string) (*httptest.ResponseRecorder, *Poll) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	handler(w, r.WithContext(caller.NewContext(r.Context(), "", user)))
+
+	var p Poll
+	json.Unmarshal(w.Body.Bytes(), &p)
+	return w, &p
+}
+
+func TestSuggestAndApproveOption(t *testing.T) {
+	organisedPoll(t, true)
+
+	w, p := serveAs(AddOption, "Kate", http.MethodPost, "/poll/options?id=new%20poll", `{"id": "r2", "name": "Restaurant 2"}`)
+	if w.Code != http.StatusAccepted || len(p.Options) != 1 || len(p.Pending) != 1 || p.Pending[0].SuggestedBy != "Kate" {
+		t.Logf("Expected r2 to be pending approval, got %v %s", w.Code, w.Body.String())
+		t.FailNow()
+	}
+
+	w, _ = serveAs(ApproveOption, "Kate", http.MethodPost, "/poll/options/approve?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusForbidden {
+		t.Logf("Expected only the organiser to approve r2, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p = serveAs(ApproveOption, "Jack", http.MethodPost, "/poll/options/approve?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusOK || p.Option("r2") == nil || len(p.Pending) != 0 {
+		t.Logf("Expected r2 to be approved, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestRejectOption(t *testing.T) {
+	organisedPoll(t, true)
+
+	serveAs(AddOption, "Kate", http.MethodPost, "/poll/options?id=new%20poll", `{"id": "r2", "name": "Restaurant 2"}`)
+
+	w, _ := serveAs(AddOption, "Will", http.MethodPost, "/poll/options?id=new%20poll", `{"id": "r3", "name": "restaurant 2 "}`)
+	if w.Code != http.StatusBadRequest {
+		t.Logf("Expected a suggestion sharing a name with a pending option to be invalid, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, _ = serveAs(RemoveOption, "Will", http.MethodDelete, "/poll/options?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusForbidden {
+		t.Logf("Expected only the organiser or Kate to reject r2, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p := serveAs(RemoveOption, "Jack", http.MethodDelete, "/poll/options?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusOK || len(p.Pending) != 0 || len(p.Options) != 1 {
+		t.Logf("Expected r2 to be rejected, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestOptionsForbidden(t *testing.T) {
+	organisedPoll(t, false)
+
+	for _, c := range []struct {
+		handler http.HandlerFunc
+		method  string
+		url     string
+		body    string
+	}{
+		{AddOption, http.MethodPost, "/poll/options?id=new%20poll", `{"id": "r2", "name": "Restaurant 2"}`},
+		{RemoveOption, http.MethodDelete, "/poll/options?id=new%20poll&option=r1", ""},
+	} {
+		w, _ := serveAs(c.handler, "Kate", c.method, c.url, c.body)
+		if w.Code != http.StatusForbidden {
+			t.Logf("Expected %s %s by a voter to be forbidden, got %v", c.method, c.url, w.Code)
+			t.Fail()
+		}
+	}
+
+	w, p := serveAs(AddOption, "Jack", http.MethodPost, "/poll/options?id=new%20poll", `{"id": "r2", "name": "Restaurant 2"}`)
+	if w.Code != http.StatusCreated || p.Option("r2") == nil {
+		t.Logf("Expected the organiser to add r2, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestOptionChangeBroadcast(t *testing.T) {
+	organisedPoll(t, false)
+
+	messages, stop := websocket.HubInstance.Listen("new poll")
+	defer stop()
+
+	if _, _, _, err := AddPollOption("", "new poll", "Jack", &restaurant.Building{ID: "r2", Name: "Restaurant 2"}); err != nil {
+		t.Logf("Expected r2 to be added, got %s", err.Error())
+		t.FailNow()
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-messages:
+			// the hub is shared between tests, so polls broadcast by earlier changes may still be received.
+			e, ok := msg.Data.(*Event)
+			if !ok {
+				continue
+			}
+			if e.Type != OptionAdded || e.OptionID != "r2" || e.Snapshot.Option("r2") == nil {
+				t.Logf("Expected the option_added event for r2 to be broadcast, got %v", e)
+				t.Fail()
+			}
+			return
+		case <-timeout:
+			t.Log("Expected the change to be broadcast")
+			t.FailNow()
+		}
+	}
+}
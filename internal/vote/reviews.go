@@ -0,0 +1,74 @@
+package vote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+// GetReviews provides a http handler listing the reviews left for the poll specified by the 'id' query parameter.
+func GetReviews(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No poll ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reviews, status, err := FindReviews(caller.Workspace(r.Context()), id)
+	if err != nil {
+		log.Printf("Could not retrieve the reviews of poll %s due to: %s\n", id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reviews)
+}
+
+// AddReview provides a http handler allowing a participant of the closed poll specified by the 'id' query parameter to review the poll's winner, the request body giving the
+// review. The review is left by the caller, so a review naming any other user is rejected. Reviewing a poll again replaces the user's previous review.
+func AddReview(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No poll ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	var data Review
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		log.Printf("Could not parse %s as a review\n", b)
+		http.Error(w, "Could not parse given review", http.StatusBadRequest)
+		return
+	}
+
+	user := caller.User(r.Context())
+	if data.User != "" && data.User != user {
+		log.Printf("%s may not review poll %s on behalf of %s, returning forbidden status.\n", user, id, data.User)
+		http.Error(w, "Reviews may only be left by the user making the request", http.StatusForbidden)
+		return
+	}
+
+	review, status, err := SubmitReview(caller.Workspace(r.Context()), id, user, &data)
+	if err != nil {
+		log.Printf("Could not review poll %s due to: %s\n", id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("%s rated %s %v stars within poll %s\n", review.User, review.RestaurantName, review.Rating, id)
+	writeJSON(w, http.StatusCreated, review)
+}
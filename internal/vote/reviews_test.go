@@ -0,0 +1,61 @@
+package vote
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRatings(t *testing.T) {
+	ratings := Ratings([]*Review{
+		{RestaurantID: "r1", RestaurantName: "Restaurant 1", Rating: 2, Tags: []string{"late", "cold"}},
+		{RestaurantID: "r2", RestaurantName: "Restaurant 2", Rating: 5, Tags: []string{"great portions"}},
+		{RestaurantID: "r1", RestaurantName: "Restaurant 1", Rating: 3, Tags: []string{"late"}},
+	})
+
+	if len(ratings) != 2 || ratings[0].RestaurantID != "r2" || ratings[1].Reviews != 2 || ratings[1].Average != 2.5 || ratings[1].Tags["late"] != 2 {
+		t.Logf("Expected r2 to be rated above r1, got %+v %+v", ratings[0], ratings[1])
+		t.Fail()
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	organisedPoll(t, false)
+	instance.Reviews = &MockReviewModel{}
+	CastVote("", "new poll", "Tom", "r1")
+
+	review := &Review{Rating: 4, Tags: []string{" Great Portions "}}
+	if _, status, err := SubmitReview("", "new poll", "Tom", review); err == nil || status != Conflict {
+		t.Logf("Expected an open poll not to be reviewable, got %v %v", status, err)
+		t.Fail()
+	}
+
+	poll, _, _ := FindPoll("", "new poll")
+	closed := poll.Copy()
+	closed.ClosesAt = time.Now().Add(-time.Minute)
+	instance.Model.UpdatePoll(closed)
+
+	if _, status, err := SubmitReview("", "new poll", "Will", review); err == nil || status != Forbidden {
+		t.Logf("Expected a user who did not vote not to review the poll, got %v %v", status, err)
+		t.Fail()
+	}
+
+	got, _, err := SubmitReview("", "new poll", "Tom", review)
+	if err != nil || got.RestaurantID != "r1" || got.Tags[0] != "great portions" {
+		t.Logf("Expected the winner to be reviewed, got %+v %v", got, err)
+		t.FailNow()
+	}
+	// reviewing the poll again replaces the user's review.
+	SubmitReview("", "new poll", "Tom", &Review{Rating: 2})
+
+	if w, _ := serveAs(AddReview, "Will", http.MethodPost, "/poll/reviews?id=new+poll", `{"user": "Tom", "rating": 1}`); w.Code != http.StatusForbidden {
+		t.Logf("Expected Will not to review the poll on behalf of Tom, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p := serveAs(GetPoll, "Tom", http.MethodGet, "/poll?id=new+poll", "")
+	if w.Code != http.StatusOK || len(p.Ratings) != 1 || p.Ratings[0].Reviews != 1 || p.Ratings[0].Average != 2 {
+		t.Logf("Expected the poll to include the rating of r1, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,82 @@
+package vote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+// SetRights provides a http handler allowing the organiser of the poll specified by the 'id' query parameter to set the rights of the participant specified by the 'user' query
+// parameter, the request body giving the participant's weight and number of vetoes. A weight left out of the body defaults to 1.
+func SetRights(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, user := q.Get("id"), q.Get("user")
+	if id == "" || user == "" {
+		log.Println("No poll ID or user specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	data := Rights{Weight: MinWeight}
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		log.Printf("Could not parse %s as rights\n", b)
+		http.Error(w, "Could not parse given rights", http.StatusBadRequest)
+		return
+	}
+
+	poll, status, err := SetParticipantRights(caller.Workspace(r.Context()), id, caller.User(r.Context()), user, data)
+	if err != nil {
+		log.Printf("Could not set the rights of %s within poll %s due to: %s\n", user, id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("Set the rights of %s within poll %s to %v\n", user, id, data)
+	writePoll(w, r, http.StatusOK, poll)
+}
+
+// AddVeto provides a http handler allowing the caller to veto the option specified by the 'option' query parameter within the poll specified by the 'id' query parameter, provided
+// the poll's organiser has given them vetoes to use.
+func AddVeto(w http.ResponseWriter, r *http.Request) {
+	changeVeto(w, r, VetoOption)
+}
+
+// RemoveVeto provides a http handler allowing the caller to withdraw their veto of the option specified by the 'option' query parameter within the poll specified by the 'id'
+// query parameter.
+func RemoveVeto(w http.ResponseWriter, r *http.Request) {
+	changeVeto(w, r, WithdrawVeto)
+}
+
+func changeVeto(w http.ResponseWriter, r *http.Request, change func(workspace string, id string, user string, optionID string) (*Poll, Status, error)) {
+	q := r.URL.Query()
+	id, option := q.Get("id"), q.Get("option")
+	if id == "" || option == "" {
+		log.Println("No poll or option ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	user := caller.User(r.Context())
+	poll, status, err := change(caller.Workspace(r.Context()), id, user, option)
+	if err != nil {
+		log.Printf("Could not change the veto of %s by %s within poll %s due to: %s\n", option, user, id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("Changed the veto of %s by %s within poll %s\n", option, user, id)
+	writePoll(w, r, http.StatusOK, poll)
+}
@@ -0,0 +1,50 @@
+package vote
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRightsAndVetoes(t *testing.T) {
+	organisedPoll(t, false)
+	AddPollOption("", "new poll", "Jack", r2)
+	CastVote("", "new poll", "Tom", "r1")
+	CastVote("", "new poll", "Will", "r2")
+
+	w, _ := serveAs(SetRights, "Kate", http.MethodPut, "/poll/rights?id=new%20poll&user=Will", `{"weight": 2}`)
+	if w.Code != http.StatusForbidden {
+		t.Logf("Expected only the organiser to set rights, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p := serveAs(SetRights, "Jack", http.MethodPut, "/poll/rights?id=new%20poll&user=Will", `{"weight": 2}`)
+	if w.Code != http.StatusOK || len(p.Tally) != 2 || p.Tally[1].Votes != 1 || p.Tally[1].Weighted != 2 {
+		t.Logf("Expected Will's vote to count twice, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	w, _ = serveAs(AddVeto, "Tom", http.MethodPost, "/poll/veto?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusForbidden {
+		t.Logf("Expected Tom to have no right to veto, got %v", w.Code)
+		t.Fail()
+	}
+
+	serveAs(SetRights, "Jack", http.MethodPut, "/poll/rights?id=new%20poll&user=Tom", `{"vetoes": 1}`)
+	w, p = serveAs(AddVeto, "Tom", http.MethodPost, "/poll/veto?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusOK || !p.Tally[1].Vetoed || p.Winner().ID != "r1" {
+		t.Logf("Expected Tom's veto to stop r2 from winning, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	w, _ = serveAs(AddVeto, "Tom", http.MethodPost, "/poll/veto?id=new%20poll&option=r1", "")
+	if w.Code != http.StatusConflict {
+		t.Logf("Expected Tom to have used their only veto, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p = serveAs(RemoveVeto, "Tom", http.MethodDelete, "/poll/veto?id=new%20poll&option=r2", "")
+	if w.Code != http.StatusOK || p.Tally[1].Vetoed {
+		t.Logf("Expected Tom's veto to be withdrawn, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,48 @@
+package vote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+)
+
+// SetRules provides a http handler allowing the organiser of the poll specified by the 'id' query parameter to set the rules the poll must meet to be decided, the request body
+// giving the rules. A body of null removes the poll's rules. The poll is settled against its new rules straight away, so the returned poll may have been decided or failed.
+func SetRules(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No poll ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	var data *Rules
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		log.Printf("Could not parse %s as rules\n", b)
+		http.Error(w, "Could not parse given rules", http.StatusBadRequest)
+		return
+	}
+
+	poll, status, err := SetPollRules(caller.Workspace(r.Context()), id, caller.User(r.Context()), data)
+	if err != nil {
+		log.Printf("Could not set the rules of poll %s due to: %s\n", id, err.Error())
+		http.Error(w, err.Error(), status.HTTPStatus())
+		return
+	}
+
+	log.Printf("Set the rules of poll %s to %v\n", id, data)
+	writePoll(w, r, http.StatusOK, poll)
+}
@@ -0,0 +1,115 @@
+package vote
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+func TestRulesDecidePoll(t *testing.T) {
+	organisedPoll(t, false)
+	AddPollOption("", "new poll", "Jack", r2)
+
+	w, _ := serveAs(SetRules, "Kate", http.MethodPut, "/poll/rules?id=new%20poll", `{"quorum": 2}`)
+	if w.Code != http.StatusForbidden {
+		t.Logf("Expected only the organiser to set rules, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, _ = serveAs(SetRules, "Jack", http.MethodPut, "/poll/rules?id=new%20poll", `{"quorum": 2, "onFailure": "retry"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Logf("Expected invalid rules to be rejected, got %v", w.Code)
+		t.Fail()
+	}
+
+	w, p := serveAs(SetRules, "Jack", http.MethodPut, "/poll/rules?id=new%20poll", `{"quorum": 2, "invited": ["Tom", "Will", "Kate"]}`)
+	if w.Code != http.StatusOK || p.Rules == nil || p.Rules.Quorum != 2 || p.Outcome != "" {
+		t.Logf("Expected the rules to be set, got %v %s", w.Code, w.Body.String())
+		t.FailNow()
+	}
+
+	if _, status, _ := CastVote("", "new poll", "Liam", "r1"); status != Invalid {
+		t.Logf("Expected Liam to not be invited to vote, got %v", status)
+		t.Fail()
+	}
+
+	messages, stop := websocket.HubInstance.Listen("new poll")
+	defer stop()
+
+	CastVote("", "new poll", "Tom", "r1")
+	poll, _, err := CastVote("", "new poll", "Will", "r1")
+	if err != nil || poll.Outcome != Decided || poll.State(time.Now()) != PollClosed {
+		t.Logf("Expected Kate to be unable to change the result, deciding the poll, got %v", poll)
+		t.Fail()
+	}
+
+	if _, status, _ := CastVote("", "new poll", "Kate", "r2"); status != Conflict {
+		t.Logf("Expected votes to be rejected once the poll is decided, got %v", status)
+		t.Fail()
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-messages:
+			// the hub is shared between tests, so polls broadcast by earlier changes may still be received.
+			if e, ok := msg.Data.(*Event); ok && e.Type == PollDecided && e.Snapshot.Outcome == Decided {
+				return
+			}
+		case <-timeout:
+			t.Log("Expected the poll being decided to be broadcast")
+			t.FailNow()
+		}
+	}
+}
+
+func TestCloserSettlesDuePolls(t *testing.T) {
+	organisedPoll(t, false)
+	SetPollRules("", "new poll", "Jack", &Rules{Quorum: 1})
+
+	poll, _, _ := FindPoll("", "new poll")
+	closed := poll.Copy()
+	closed.ClosesAt = time.Now().Add(-time.Minute)
+	instance.Model.UpdatePoll(closed)
+
+	(&Closer{}).SettleDue(time.Now())
+
+	poll, _, _ = FindPoll("", "new poll")
+	if poll.Outcome != Failed {
+		t.Logf("Expected the closed poll without a quorum to fail, got %q", poll.Outcome)
+		t.Fail()
+	}
+}
+
+func TestCloserDecidesPollsWithoutRules(t *testing.T) {
+	organisedPoll(t, false)
+
+	poll, _, _ := FindPoll("", "new poll")
+	closed := poll.Copy()
+	closed.ClosesAt = time.Now().Add(-time.Second)
+	instance.Model.UpdatePoll(closed)
+
+	closer := &Closer{}
+	closer.SettleDue(time.Now())
+	closer.SettleDue(time.Now())
+
+	history, _, _ := instance.Events.GetEvents("new poll")
+	decided := 0
+	for _, e := range history {
+		if e.Type == PollDecided {
+			decided++
+		}
+	}
+	if decided != 1 {
+		t.Logf("Expected the closed poll to be decided once, got %v decided events", decided)
+		t.Fail()
+	}
+
+	poll, _, _ = FindPoll("", "new poll")
+	if poll.Outcome != "" {
+		t.Logf("Expected the poll without rules to keep accepting votes, got %q", poll.Outcome)
+		t.Fail()
+	}
+}
@@ -0,0 +1,434 @@
+package vote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+
+	"github.com/gorilla/mux"
+)
+
+// The handlers within this file make up the v2 API, which exposes polls as resources identified by their path rather than by query parameters. Every error is returned as an
+// ErrorEnvelope.
+
+// Ballot represents the body of a request casting a vote within the v2 API, the voter being identified by the request's path.
+type Ballot struct {
+	User     string `json:"user"`
+	OptionID string `json:"optionId"`
+}
+
+// OptionPatch represents the body of a request renaming an option, fields left out of the body being kept unchanged.
+type OptionPatch struct {
+	Name    *string `json:"name,omitempty"`
+	Address *string `json:"address,omitempty"`
+}
+
+// ListPollsV2 provides a http handler for GET /v2/polls, accepting the same query parameters as ListPolls.
+func ListPollsV2(w http.ResponseWriter, r *http.Request) {
+	q, err := parsePollQuery(r.URL.Query())
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, BadRequest, err.Error())
+		return
+	}
+	q.Viewer = caller.User(r.Context())
+
+	page, status, err := FindPolls(caller.Workspace(r.Context()), q)
+	if err != nil {
+		log.Printf("Could not list polls due to: %s\n", err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	viewPage(page, caller.User(r.Context()))
+	writeJSON(w, http.StatusOK, page)
+}
+
+// CreatePollV2 provides a http handler for POST /v2/polls, creating a poll with the options given within the request body.
+func CreatePollV2(w http.ResponseWriter, r *http.Request) {
+	var options []*restaurant.Building
+	if !readJSON(w, r, &options) {
+		return
+	}
+
+	settings, err := parseSettings(r.URL.Query())
+	if err != nil {
+		WriteStatusError(w, Invalid, err)
+		return
+	}
+
+	poll, status, err := CreatePoll(caller.Workspace(r.Context()), caller.User(r.Context()), options, settings)
+	if err != nil {
+		log.Printf("Could not create a new poll due to: %s\n", err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v2/polls/"+poll.ID)
+	writeJSON(w, http.StatusCreated, viewFor(r, poll))
+}
+
+// GetPollV2 provides a http handler for GET /v2/polls/{id}, including the ratings of the poll's options.
+func GetPollV2(w http.ResponseWriter, r *http.Request) {
+	poll, status, err := FindPoll(caller.Workspace(r.Context()), mux.Vars(r)["id"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	view := viewFor(r, poll)
+	rate(view)
+	writeJSON(w, http.StatusOK, view)
+}
+
+// UpdatePollV2 provides a http handler for PUT /v2/polls/{id}, replacing the poll with the poll given within the request body.
+func UpdatePollV2(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var data Poll
+	if !readJSON(w, r, &data) {
+		return
+	}
+
+	if data.ID != "" && data.ID != id {
+		WriteError(w, http.StatusBadRequest, BadRequest, fmt.Sprintf("the poll ID %s does not match the path ID %s", data.ID, id))
+		return
+	}
+
+	data.ID = id
+	// polls can only be updated within the caller's workspace, preventing polls from being moved between workspaces.
+	data.Workspace = caller.Workspace(r.Context())
+
+	status, err := SavePoll(&data, caller.User(r.Context()))
+	if err != nil {
+		log.Printf("Could not update poll with id %s due to: %s\n", id, err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, &data))
+}
+
+// DeletePollV2 provides a http handler for DELETE /v2/polls/{id}.
+func DeletePollV2(w http.ResponseWriter, r *http.Request) {
+	status, err := RemovePoll(caller.Workspace(r.Context()), mux.Vars(r)["id"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetVoteV2 provides a http handler for GET /v2/polls/{id}/votes/{user}, returning the option the user has voted for. Within private polls users may only access their own vote.
+func GetVoteV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	poll, status, err := FindPoll(caller.Workspace(r.Context()), vars["id"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	if poll.IsPrivate() && vars["user"] != caller.User(r.Context()) {
+		WriteStatusError(w, Forbidden, fmt.Errorf("the votes of other users within %s poll %s are hidden", poll.Visibility, poll.ID))
+		return
+	}
+
+	for opt, voters := range poll.Votes {
+		if contains(voters, vars["user"]) {
+			writeJSON(w, http.StatusOK, Ballot{User: vars["user"], OptionID: opt})
+			return
+		}
+	}
+
+	WriteStatusError(w, NotFound, fmt.Errorf("%s has not voted within poll %s", vars["user"], poll.ID))
+}
+
+// CastVoteV2 provides a http handler for PUT /v2/polls/{id}/votes/{user}, replacing the user's vote with a vote for the option given within the request body. Within private polls
+// users may only change their own vote.
+func CastVoteV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var data Ballot
+	if !readJSON(w, r, &data) {
+		return
+	}
+
+	if data.OptionID == "" {
+		WriteError(w, http.StatusBadRequest, BadRequest, "an optionId must be specified")
+		return
+	}
+
+	if status, err := CheckVoter(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["user"]); err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	poll, status, err := CastVote(caller.Workspace(r.Context()), vars["id"], vars["user"], data.OptionID)
+	if err != nil {
+		log.Printf("Could not cast vote for %s within poll %s due to: %s\n", vars["user"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// DeleteVoteV2 provides a http handler for DELETE /v2/polls/{id}/votes/{user}, removing the user's votes from the poll. Within private polls users may only remove their own votes.
+func DeleteVoteV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if status, err := CheckVoter(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["user"]); err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	poll, status, err := RemoveVoter(caller.Workspace(r.Context()), vars["id"], vars["user"])
+	if err != nil {
+		log.Printf("Could not remove %s from poll %s due to: %s\n", vars["user"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// AddOptionV2 provides a http handler for POST /v2/polls/{id}/options, adding the restaurant given within the request body as an option. Options suggested by users other than the
+// poll's organiser are accepted as pending approval.
+func AddOptionV2(w http.ResponseWriter, r *http.Request) {
+	var b restaurant.Building
+	if !readJSON(w, r, &b) {
+		return
+	}
+
+	poll, pending, status, err := AddPollOption(caller.Workspace(r.Context()), mux.Vars(r)["id"], caller.User(r.Context()), &b)
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	if pending {
+		writeJSON(w, http.StatusAccepted, viewFor(r, poll))
+		return
+	}
+
+	w.Header().Set("Location", "/v2/polls/"+poll.ID+"/options/"+b.ID)
+	writeJSON(w, http.StatusCreated, viewFor(r, poll))
+}
+
+// GetOptionV2 provides a http handler for GET /v2/polls/{id}/options/{optionId}.
+func GetOptionV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	poll, status, err := FindPoll(caller.Workspace(r.Context()), vars["id"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	opt := poll.Option(vars["optionId"])
+	if opt == nil {
+		WriteStatusError(w, NotFound, fmt.Errorf("the option %s could not be found within poll %s", vars["optionId"], poll.ID))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, opt)
+}
+
+// DeleteOptionV2 provides a http handler for DELETE /v2/polls/{id}/options/{optionId}, removing the option and its votes from the poll, or rejecting the option should it be
+// pending approval.
+func DeleteOptionV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	q := r.URL.Query()
+
+	discard, err := parseBool(q.Get("discardVotes"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, BadRequest, "discardVotes must be true or false")
+		return
+	}
+
+	poll, status, err := RemovePollOption(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["optionId"], q.Get("moveVotesTo"), discard)
+	if err != nil {
+		log.Printf("Could not remove option %s from poll %s due to: %s\n", vars["optionId"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// RenameOptionV2 provides a http handler for PATCH /v2/polls/{id}/options/{optionId}, changing the name or address of an option while keeping the votes cast for it.
+func RenameOptionV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var patch OptionPatch
+	if !readJSON(w, r, &patch) {
+		return
+	}
+
+	poll, status, err := RenamePollOption(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["optionId"], &patch)
+	if err != nil {
+		log.Printf("Could not rename option %s within poll %s due to: %s\n", vars["optionId"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, poll.Option(vars["optionId"]))
+}
+
+// ApproveOptionV2 provides a http handler for POST /v2/polls/{id}/options/{optionId}/approve, allowing the poll's organiser to add a pending option to the poll.
+func ApproveOptionV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	poll, status, err := ApprovePollOption(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["optionId"])
+	if err != nil {
+		log.Printf("Could not approve option %s within poll %s due to: %s\n", vars["optionId"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v2/polls/"+poll.ID+"/options/"+vars["optionId"])
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// SetRightsV2 provides a http handler for PUT /v2/polls/{id}/rights/{user}, allowing the poll's organiser to set the weight and number of vetoes of a participant. A weight left
+// out of the body defaults to 1.
+func SetRightsV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rights := Rights{Weight: MinWeight}
+	if !readJSON(w, r, &rights) {
+		return
+	}
+
+	poll, status, err := SetParticipantRights(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["user"], rights)
+	if err != nil {
+		log.Printf("Could not set the rights of %s within poll %s due to: %s\n", vars["user"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// SetRulesV2 provides a http handler for PUT /v2/polls/{id}/rules, allowing the poll's organiser to set the rules the poll must meet to be decided. A body of null removes the
+// poll's rules.
+func SetRulesV2(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var rules *Rules
+	if !readJSON(w, r, &rules) {
+		return
+	}
+
+	poll, status, err := SetPollRules(caller.Workspace(r.Context()), id, caller.User(r.Context()), rules)
+	if err != nil {
+		log.Printf("Could not set the rules of poll %s due to: %s\n", id, err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// GetReviewsV2 provides a http handler for GET /v2/polls/{id}/reviews, listing the reviews left for the poll.
+func GetReviewsV2(w http.ResponseWriter, r *http.Request) {
+	reviews, status, err := FindReviews(caller.Workspace(r.Context()), mux.Vars(r)["id"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reviews)
+}
+
+// ReviewPollV2 provides a http handler for PUT /v2/polls/{id}/reviews/{user}, recording the user's review of the winner of the closed poll given within the request body. Users
+// may only review a poll themselves, so the user must be the caller.
+func ReviewPollV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if user := caller.User(r.Context()); vars["user"] != user {
+		WriteStatusError(w, Forbidden, fmt.Errorf("%s may not review poll %s on behalf of %s", user, vars["id"], vars["user"]))
+		return
+	}
+
+	var data Review
+	if !readJSON(w, r, &data) {
+		return
+	}
+
+	review, status, err := SubmitReview(caller.Workspace(r.Context()), vars["id"], vars["user"], &data)
+	if err != nil {
+		log.Printf("Could not record the review by %s of poll %s due to: %s\n", vars["user"], vars["id"], err.Error())
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, review)
+}
+
+// VetoOptionV2 provides a http handler for PUT /v2/polls/{id}/vetoes/{optionId}, recording the caller vetoing the option.
+func VetoOptionV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	poll, status, err := VetoOption(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["optionId"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// WithdrawVetoV2 provides a http handler for DELETE /v2/polls/{id}/vetoes/{optionId}, withdrawing the caller's veto of the option.
+func WithdrawVetoV2(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	poll, status, err := WithdrawVeto(caller.Workspace(r.Context()), vars["id"], caller.User(r.Context()), vars["optionId"])
+	if err != nil {
+		WriteStatusError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, viewFor(r, poll))
+}
+
+// readJSON unmarshals the body of the given request into v, writing an error response and returning false should the body not be valid JSON.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		log.Println("Could not read body of request")
+		WriteError(w, http.StatusInternalServerError, Internal, "could not read request body")
+		return false
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		log.Printf("Could not parse %s due to: %s\n", b, err.Error())
+		WriteError(w, http.StatusBadRequest, BadRequest, "could not parse request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		WriteError(w, http.StatusInternalServerError, Internal, "could not serialise response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
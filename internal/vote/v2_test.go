@@ -0,0 +1,212 @@
+package vote
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/websocket"
+
+	"github.com/gorilla/mux"
+)
+
+// hub ensures the websocket hub is running, as handlers block while broadcasting changes.
+var hub sync.Once
+
+func v2Router() *mux.Router {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	Init(&Container{Model: &MockPollModel{}, Events: &MockEventModel{}})
+
+	r := mux.NewRouter()
+	r.HandleFunc("/v2/polls/{id}", GetPollV2).Methods(http.MethodGet)
+	r.HandleFunc("/v2/polls/{id}", UpdatePollV2).Methods(http.MethodPut)
+	r.HandleFunc("/v2/polls/{id}/votes/{user}", GetVoteV2).Methods(http.MethodGet)
+	r.HandleFunc("/v2/polls/{id}/votes/{user}", CastVoteV2).Methods(http.MethodPut)
+	r.HandleFunc("/v2/polls/{id}/options/{optionId}", DeleteOptionV2).Methods(http.MethodDelete)
+	r.HandleFunc("/v2/polls/{id}/options/{optionId}", RenameOptionV2).Methods(http.MethodPatch)
+	return r
+}
+
+func serve(r http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestV2CastVote(t *testing.T) {
+	r := v2Router()
+
+	w := serve(r, http.MethodPut, "/v2/polls/test/votes/Jack", `{"optionId": "r2"}`)
+	if w.Code != http.StatusOK {
+		t.Logf("Expected vote to be cast, got status %v", w.Code)
+		t.Fail()
+	}
+
+	var p Poll
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if len(p.Votes["r1"]) != 1 || len(p.Votes["r2"]) != 3 {
+		t.Logf("Expected Jack's vote to move from r1 to r2, got %v", p.Votes)
+		t.Fail()
+	}
+}
+
+func TestV2Errors(t *testing.T) {
+	r := v2Router()
+
+	for _, c := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{http.MethodGet, "/v2/polls/unknown", "", http.StatusNotFound, "not_found"},
+		{http.MethodPut, "/v2/polls/test/votes/Jack", "{", http.StatusBadRequest, BadRequest},
+		{http.MethodPut, "/v2/polls/test/votes/Jack", "{}", http.StatusBadRequest, BadRequest},
+		{http.MethodDelete, "/v2/polls/test/options/r3", "", http.StatusNotFound, "not_found"},
+		{http.MethodPut, "/v2/polls/test/votes/Jack", `{"optionId": "r3"}`, http.StatusBadRequest, "invalid"},
+		{http.MethodDelete, "/v2/polls/test/options/r1", "", http.StatusConflict, "conflict"},
+		{http.MethodDelete, "/v2/polls/test/options/r1?moveVotesTo=r1", "", http.StatusBadRequest, "invalid"},
+		{http.MethodDelete, "/v2/polls/test/options/r1?discardVotes=maybe", "", http.StatusBadRequest, BadRequest},
+		{http.MethodPatch, "/v2/polls/test/options/r1", `{"name": "Restaurant 2"}`, http.StatusBadRequest, "invalid"},
+	} {
+		w := serve(r, c.method, c.path, c.body)
+
+		var e ErrorEnvelope
+		err := json.Unmarshal(w.Body.Bytes(), &e)
+		if err != nil || w.Code != c.status || e.Error.Code != c.code || e.Error.Message == "" {
+			t.Logf("Expected %s %s to return %v %s, got %v %s", c.method, c.path, c.status, c.code, w.Code, w.Body.String())
+			t.Fail()
+		}
+	}
+}
+
+func TestV2RemoveOptionMovingVotes(t *testing.T) {
+	r := v2Router()
+
+	w := serve(r, http.MethodDelete, "/v2/polls/test/options/r1?moveVotesTo=r2", "")
+
+	var p Poll
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if w.Code != http.StatusOK || len(p.Options) != 1 || len(p.Votes["r2"]) != 4 {
+		t.Logf("Expected r1 to be removed with its votes moved to r2, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestV2RenameOption(t *testing.T) {
+	r := v2Router()
+
+	w := serve(r, http.MethodPatch, "/v2/polls/test/options/r1", `{"name": "Renamed"}`)
+	if w.Code != http.StatusOK {
+		t.Logf("Expected r1 to be renamed, got %v %s", w.Code, w.Body.String())
+		t.FailNow()
+	}
+
+	w = serve(r, http.MethodGet, "/v2/polls/test", "")
+
+	var p Poll
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if p.Option("r1").Name != "Renamed" || p.Option("r1").Address != "Address 1" || len(p.Votes["r1"]) != 2 {
+		t.Logf("Expected r1 to be renamed keeping its address and votes, got %s", w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestV2ValidationDetails(t *testing.T) {
+	r := v2Router()
+
+	w := serve(r, http.MethodPut, "/v2/polls/test/votes/Jack", `{"optionId": "r3"}`)
+
+	var e ErrorEnvelope
+	json.Unmarshal(w.Body.Bytes(), &e)
+	if len(e.Error.Details) != 1 || e.Error.Details[0].Field != "optionId" {
+		t.Logf("Expected the invalid optionId to be detailed, got %s", w.Body.String())
+		t.Fail()
+	}
+}
+
+func TestV2PrivatePoll(t *testing.T) {
+	r := v2Router()
+	CreatePoll("", "Jack", []*restaurant.Building{r1, r2}, &PollSettings{Visibility: Anonymous})
+	CastVote("", "new poll", "Jack", "r1")
+	CastVote("", "new poll", "Tom", "r1")
+	SetParticipantRights("", "new poll", "Jack", "Jack", Rights{Weight: 2})
+
+	as := func(user string, method string, path string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.ServeHTTP(w, req.WithContext(caller.NewContext(req.Context(), "", user)))
+		return w
+	}
+
+	w := as("Tom", http.MethodGet, "/v2/polls/new%20poll", "")
+
+	var p Poll
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if len(p.Votes) != 1 || p.Votes["r1"][0] != "Tom" || p.Counts["r1"] != 2 {
+		t.Logf("Expected Tom to only see their own vote along with the counts, got %s", w.Body.String())
+		t.Fail()
+	}
+
+	if w = as("Tom", http.MethodGet, "/v2/polls/new%20poll/votes/Jack", ""); w.Code != http.StatusForbidden {
+		t.Logf("Expected Jack's vote to be hidden from Tom, got %v", w.Code)
+		t.Fail()
+	}
+	if w = as("Tom", http.MethodPut, "/v2/polls/new%20poll/votes/Jack", `{"optionId":"r2"}`); w.Code != http.StatusForbidden {
+		t.Logf("Expected Tom not to be able to change Jack's vote, got %v", w.Code)
+		t.Fail()
+	}
+	if w = as("Tom", http.MethodDelete, "/v2/polls/new%20poll/votes/Jack", ""); w.Code != http.StatusForbidden {
+		t.Logf("Expected Tom not to be able to remove Jack's vote, got %v", w.Code)
+		t.Fail()
+	}
+	if w = as("Tom", http.MethodPut, "/v2/polls/new%20poll/votes/Tom", `{"optionId":"r1"}`); w.Code != http.StatusOK {
+		t.Logf("Expected Tom to be able to change their own vote, got %v %s", w.Code, w.Body.String())
+		t.Fail()
+	}
+
+	// updating the poll from Tom's view must not discard Jack's vote.
+	p.Options[0].Name = "Renamed"
+	// nor may Tom make themselves the organiser, or change the settings only the organiser may change.
+	p.Creator, p.AllowSuggestions, p.Rights = "", true, map[string]Rights{"Tom": {Weight: 5}}
+	body, _ := json.Marshal(&p)
+	if w = as("Tom", http.MethodPut, "/v2/polls/new%20poll", string(body)); w.Code != http.StatusOK {
+		t.Logf("Expected the poll to be updated, got %v %s", w.Code, w.Body.String())
+		t.FailNow()
+	}
+
+	stored, _, _ := FindPoll("", "new poll")
+	if len(stored.Votes["r1"]) != 2 || stored.Option("r1").Name != "Renamed" || stored.RightsOf("Jack").Weight != 2 {
+		t.Logf("Expected the stored votes and rights to be kept, got %v %v", stored.Votes, stored.Rights)
+		t.Fail()
+	}
+	if stored.Creator != "Jack" || stored.AllowSuggestions || stored.RightsOf("Tom").Weight == 5 {
+		t.Logf("Expected the organiser's settings to be kept, got %s %v %v", stored.Creator, stored.AllowSuggestions, stored.Rights)
+		t.Fail()
+	}
+
+	p.Visibility = Public
+	body, _ = json.Marshal(&p)
+	if w = as("Tom", http.MethodPut, "/v2/polls/new%20poll", string(body)); w.Code != http.StatusBadRequest {
+		t.Logf("Expected an anonymous poll to not be made public, got %v", w.Code)
+		t.Fail()
+	}
+}
+
+func TestStatusCodes(t *testing.T) {
+	if NotFound.HTTPStatus() != http.StatusNotFound || Invalid.HTTPStatus() != http.StatusBadRequest || NoConnection.HTTPStatus() != http.StatusServiceUnavailable {
+		t.Log("Expected statuses to map to their http status codes")
+		t.Fail()
+	}
+
+	if Status(42).Code() != Internal || Status(42).HTTPStatus() != http.StatusInternalServerError {
+		t.Log("Expected unknown statuses to be reported as internal errors")
+		t.Fail()
+	}
+}
@@ -0,0 +1,400 @@
+package vote
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// The limits enforced on the options of a poll.
+const (
+	MinOptions       = 1
+	MaxOptions       = 20
+	MaxNameLength    = 100
+	MaxAddressLength = 200
+)
+
+// FieldError describes why a single field of a request is invalid, the field being given as a path such as 'options[1].name'.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned along with an 'Invalid' status when a poll, option or vote breaks one or more of the rules enforced by the validation functions, listing every
+// invalid field rather than only the first found.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Extensions allows GraphQL responses to include the invalid fields alongside the error message.
+func (e *ValidationError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": Invalid.Code(), "fields": e.Fields}
+}
+
+func (e *ValidationError) add(field string, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// result returns the error itself should any field be invalid, otherwise returning nil.
+func (e *ValidationError) result() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateOptions checks the given options may be used as the options of a poll: there must be between MinOptions and MaxOptions options, each having an ID and a name, no
+// option may share an ID or name with another, and names and addresses must not be longer than MaxNameLength and MaxAddressLength respectively.
+func ValidateOptions(options []*restaurant.Building) error {
+	e := &ValidationError{}
+	validateOptions(e, options)
+	return e.result()
+}
+
+func validateOptions(e *ValidationError, options []*restaurant.Building) {
+	if len(options) < MinOptions || len(options) > MaxOptions {
+		e.add("options", "a poll must have between %d and %d options, got %d", MinOptions, MaxOptions, len(options))
+	}
+
+	ids := make(map[string]bool)
+	names := make(map[string]bool)
+	for i, opt := range options {
+		field := fmt.Sprintf("options[%d]", i)
+		if opt == nil {
+			e.add(field, "must not be null")
+			continue
+		}
+
+		validateOption(e, field, opt)
+
+		if opt.ID != "" {
+			if ids[opt.ID] {
+				e.add(field+".id", "the option %s appears more than once", opt.ID)
+			}
+			ids[opt.ID] = true
+		}
+
+		// names are compared ignoring case and surrounding whitespace, as the same restaurant is often entered differently.
+		name := strings.ToLower(strings.TrimSpace(opt.Name))
+		if name != "" {
+			if names[name] {
+				e.add(field+".name", "the name %s is used by more than one option", opt.Name)
+			}
+			names[name] = true
+		}
+	}
+}
+
+// ValidateOption checks the given restaurant may be added as an option to a poll, having an ID and name along with a name and address within the length limits. Should they be
+// given, its location must be valid coordinates, its delivery radius no more than MaxDeliveryRadius, its service one of the known services and its opening hours valid days and
+// times within a known time zone.
+func ValidateOption(opt *restaurant.Building) error {
+	e := &ValidationError{}
+	if opt == nil {
+		e.add("option", "must not be null")
+	} else {
+		validateOption(e, "option", opt)
+	}
+	return e.result()
+}
+
+func validateOption(e *ValidationError, field string, opt *restaurant.Building) {
+	if strings.TrimSpace(opt.ID) == "" {
+		e.add(field+".id", "must not be empty")
+	}
+
+	if strings.TrimSpace(opt.Name) == "" {
+		e.add(field+".name", "must not be empty")
+	} else if len(opt.Name) > MaxNameLength {
+		e.add(field+".name", "must not be longer than %d characters", MaxNameLength)
+	}
+
+	if len(opt.Address) > MaxAddressLength {
+		e.add(field+".address", "must not be longer than %d characters", MaxAddressLength)
+	}
+
+	if opt.Location != nil {
+		validateLocation(e, field+".location", opt.Location)
+	}
+
+	if opt.DeliveryRadius < 0 || opt.DeliveryRadius > MaxDeliveryRadius {
+		e.add(field+".deliveryRadius", "must be between 0 and %d metres", MaxDeliveryRadius)
+	}
+
+	switch opt.Service {
+	case "", restaurant.Delivery, restaurant.Collection, restaurant.DeliveryAndCollection:
+	default:
+		e.add(field+".service", "must be one of %s, %s or %s", restaurant.Delivery, restaurant.Collection, restaurant.DeliveryAndCollection)
+	}
+
+	for i, h := range opt.Hours {
+		hf := fmt.Sprintf("%s.hours[%d]", field, i)
+		if _, ok := restaurant.ParseDay(h.Day); !ok {
+			e.add(hf+".day", "must be the lowercase name of a day of the week")
+		}
+		if _, ok := restaurant.ParseClock(h.Opens); !ok {
+			e.add(hf+".opens", "must be a time given as hh:mm")
+		}
+		if _, ok := restaurant.ParseClock(h.Closes); !ok {
+			e.add(hf+".closes", "must be a time given as hh:mm")
+		}
+	}
+
+	for i, ex := range opt.Exceptions {
+		ef := fmt.Sprintf("%s.exceptions[%d]", field, i)
+		if _, ok := restaurant.ParseDate(ex.Date); !ok {
+			e.add(ef+".date", "must be a date given as yyyy-mm-dd")
+		}
+		if ex.Opens == "" && ex.Closes == "" {
+			continue
+		}
+		if _, ok := restaurant.ParseClock(ex.Opens); !ok {
+			e.add(ef+".opens", "must be a time given as hh:mm")
+		}
+		if _, ok := restaurant.ParseClock(ex.Closes); !ok {
+			e.add(ef+".closes", "must be a time given as hh:mm")
+		}
+	}
+
+	if opt.TimeZone != "" {
+		if _, err := time.LoadLocation(opt.TimeZone); err != nil {
+			e.add(field+".timeZone", "%s is not a known time zone", opt.TimeZone)
+		}
+	}
+}
+
+func validateLocation(e *ValidationError, field string, l *restaurant.Location) {
+	if l.Lat < -90 || l.Lat > 90 {
+		e.add(field+".lat", "must be between -90 and 90")
+	}
+	if l.Lng < -180 || l.Lng > 180 {
+		e.add(field+".lng", "must be between -180 and 180")
+	}
+}
+
+// ValidatePoll checks the options of the given poll using ValidateOptions, its visibility using ValidateVisibility, the rights of its participants using ValidateRights and its
+// rules using ValidateRules, along with checking its office is a valid location, every vote and veto targets one of the poll's options, no user has voted more than once and no
+// user has vetoed more options than their rights allow.
+func ValidatePoll(p *Poll) error {
+	e := &ValidationError{}
+	validateOptions(e, p.Options)
+	validateVisibility(e, p.Visibility)
+	validateRightsAndVetoes(e, p)
+	if p.Rules != nil {
+		validateRules(e, p.Rules)
+	}
+	if p.Office != nil {
+		validateLocation(e, "office", p.Office)
+	}
+
+	ids := make([]string, 0, len(p.Votes))
+	for id := range p.Votes {
+		ids = append(ids, id)
+	}
+	// the votes are checked in order, keeping the reported fields stable.
+	sort.Strings(ids)
+
+	voted := make(map[string]string)
+	for _, id := range ids {
+		users := p.Votes[id]
+		// options without any votes are left within the map when votes are removed, so are ignored.
+		if len(users) == 0 {
+			continue
+		}
+
+		field := "votes." + id
+		if p.Option(id) == nil {
+			e.add(field, "votes must be for one of the poll's options")
+		}
+
+		for _, user := range users {
+			if strings.TrimSpace(user) == "" {
+				e.add(field, "votes must be made by a user")
+			} else if other, ok := voted[user]; ok {
+				e.add(field, "%s has already voted for %s", user, other)
+			}
+			voted[user] = id
+		}
+	}
+
+	return e.result()
+}
+
+// ValidateVisibility checks the given visibility is one of Public, Anonymous or Secret, an empty visibility being treated as Public.
+func ValidateVisibility(v Visibility) error {
+	e := &ValidationError{}
+	validateVisibility(e, v)
+	return e.result()
+}
+
+func validateVisibility(e *ValidationError, v Visibility) {
+	switch v {
+	case "", Public, Anonymous, Secret:
+	default:
+		e.add("visibility", "must be one of %s, %s or %s", Public, Anonymous, Secret)
+	}
+}
+
+// ValidateRights checks the given rights may be given to a participant, the weight being between MinWeight and MaxWeight and the number of vetoes between 0 and MaxVetoes.
+func ValidateRights(r Rights) error {
+	e := &ValidationError{}
+	validateRights(e, "rights", r)
+	return e.result()
+}
+
+func validateRights(e *ValidationError, field string, r Rights) {
+	if r.Weight < MinWeight || r.Weight > MaxWeight {
+		e.add(field+".weight", "must be between %d and %d", MinWeight, MaxWeight)
+	}
+	if r.Vetoes < 0 || r.Vetoes > MaxVetoes {
+		e.add(field+".vetoes", "must be between 0 and %d", MaxVetoes)
+	}
+}
+
+func validateRightsAndVetoes(e *ValidationError, p *Poll) {
+	users := make([]string, 0, len(p.Rights))
+	for user := range p.Rights {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	for _, user := range users {
+		validateRights(e, "rights."+user, p.Rights[user])
+	}
+
+	ids := make([]string, 0, len(p.Vetoed))
+	for id := range p.Vetoed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	vetoes := make(map[string]int)
+	for _, id := range ids {
+		if len(p.Vetoed[id]) > 0 && p.Option(id) == nil {
+			e.add("vetoed."+id, "vetoes must be for one of the poll's options")
+		}
+
+		for _, user := range p.Vetoed[id] {
+			vetoes[user]++
+			if vetoes[user] > p.RightsOf(user).Vetoes {
+				e.add("vetoed."+id, "%s may not veto more than %d options", user, p.RightsOf(user).Vetoes)
+			}
+		}
+	}
+}
+
+// ValidateRules checks the given rules may be set for a poll: the quorum must be between 0 and the number of invited users, should any be listed, the quorum percentage between
+// 0 and 100 with invited users being listed to take the percentage of, and the margin not negative. Invited users must not be empty or listed twice, while polls which are
+// extended must be extended by between 1 and MaxExtendBy minutes up to between 1 and MaxExtensions times.
+func ValidateRules(r *Rules) error {
+	e := &ValidationError{}
+	validateRules(e, r)
+	return e.result()
+}
+
+func validateRules(e *ValidationError, r *Rules) {
+	if r.Quorum < 0 {
+		e.add("rules.quorum", "must not be negative")
+	} else if len(r.Invited) > 0 && r.Quorum > len(r.Invited) {
+		e.add("rules.quorum", "must not be more than the %d invited users", len(r.Invited))
+	}
+
+	if r.QuorumPercent < 0 || r.QuorumPercent > 100 {
+		e.add("rules.quorumPercent", "must be between 0 and 100")
+	} else if r.QuorumPercent > 0 && len(r.Invited) == 0 {
+		e.add("rules.quorumPercent", "requires the invited users to be listed")
+	}
+
+	invited := make(map[string]bool)
+	for i, user := range r.Invited {
+		field := fmt.Sprintf("rules.invited[%d]", i)
+		if strings.TrimSpace(user) == "" {
+			e.add(field, "must not be empty")
+		} else if invited[user] {
+			e.add(field, "%s has already been invited", user)
+		}
+		invited[user] = true
+	}
+
+	if r.Margin < 0 {
+		e.add("rules.margin", "must not be negative")
+	}
+
+	switch r.OnFailure {
+	case "", FailPoll:
+	case ExtendPoll:
+		if r.ExtendBy < 1 || r.ExtendBy > MaxExtendBy {
+			e.add("rules.extendBy", "must be between 1 and %d minutes", MaxExtendBy)
+		}
+		if r.MaxExtensions < 1 || r.MaxExtensions > MaxExtensions {
+			e.add("rules.maxExtensions", "must be between 1 and %d", MaxExtensions)
+		}
+	default:
+		e.add("rules.onFailure", "must be one of %s or %s", FailPoll, ExtendPoll)
+	}
+}
+
+// ValidateVote checks the given user may vote for the option with the given ID within the given poll, the user being required to be invited should the poll's rules list who is
+// invited.
+func ValidateVote(p *Poll, user string, optionID string) error {
+	e := &ValidationError{}
+	if strings.TrimSpace(user) == "" {
+		e.add("user", "must not be empty")
+	} else if p.Rules != nil && !p.Rules.IsInvited(user) {
+		e.add("user", "%s has not been invited to vote within poll %s", user, p.ID)
+	}
+
+	if optionID == "" {
+		e.add("optionId", "must not be empty")
+	} else if p.Option(optionID) == nil {
+		e.add("optionId", "%s is not an option within poll %s", optionID, p.ID)
+	}
+
+	return e.result()
+}
+
+// ValidateReview checks the given review may be left: the rating must be between MinRating and MaxRating, there must be no more than MaxTags tags, each not empty, not longer
+// than MaxTagLength and not given twice, and the comment must not be longer than MaxCommentLength.
+func ValidateReview(r *Review) error {
+	e := &ValidationError{}
+	if strings.TrimSpace(r.User) == "" {
+		e.add("user", "must not be empty")
+	}
+
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		e.add("rating", "must be between %d and %d", MinRating, MaxRating)
+	}
+
+	if len(r.Tags) > MaxTags {
+		e.add("tags", "must not contain more than %d tags", MaxTags)
+	}
+	seen := make(map[string]bool)
+	for i, t := range r.Tags {
+		field := fmt.Sprintf("tags[%d]", i)
+		if strings.TrimSpace(t) == "" {
+			e.add(field, "must not be empty")
+		} else if len(t) > MaxTagLength {
+			e.add(field, "must not be longer than %d characters", MaxTagLength)
+		} else if seen[t] {
+			e.add(field, "%s has already been given", t)
+		}
+		seen[t] = true
+	}
+
+	if len(r.Comment) > MaxCommentLength {
+		e.add("comment", "must not be longer than %d characters", MaxCommentLength)
+	}
+
+	return e.result()
+}
@@ -0,0 +1,165 @@
+package vote
+
+import (
+	"strings"
+	"testing"
+
+	"takeaway/takeaway-server/internal/restaurant"
+)
+
+// fields returns the fields reported as invalid by the given error, which must be a *ValidationError or nil.
+func fields(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	res := make([]string, 0)
+	for _, f := range err.(*ValidationError).Fields {
+		res = append(res, f.Field)
+	}
+	return res
+}
+
+func TestValidateOptions(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		options []*restaurant.Building
+		fields  string
+	}{
+		{"valid", []*restaurant.Building{r1, r2}, ""},
+		{"empty", []*restaurant.Building{}, "options"},
+		{"duplicate id", []*restaurant.Building{r1, {ID: "r1", Name: "Other"}}, "options[1].id"},
+		{"duplicate name", []*restaurant.Building{r1, {ID: "r3", Name: " restaurant 1"}}, "options[1].name"},
+		{"missing fields", []*restaurant.Building{{Address: "Address"}}, "options[0].id,options[0].name"},
+		{"long name", []*restaurant.Building{{ID: "r3", Name: strings.Repeat("a", MaxNameLength+1)}}, "options[0].name"},
+		{"null", []*restaurant.Building{nil}, "options[0]"},
+	} {
+		got := strings.Join(fields(ValidateOptions(c.options)), ",")
+		if got != c.fields {
+			t.Logf("%s: expected invalid fields %q, got %q", c.name, c.fields, got)
+			t.Fail()
+		}
+	}
+
+	tooMany := make([]*restaurant.Building, 0)
+	for i := 0; i <= MaxOptions; i++ {
+		tooMany = append(tooMany, &restaurant.Building{ID: string(rune('a' + i)), Name: string(rune('a' + i))})
+	}
+	if got := fields(ValidateOptions(tooMany)); len(got) != 1 || got[0] != "options" {
+		t.Logf("Expected more than %d options to be invalid, got %v", MaxOptions, got)
+		t.Fail()
+	}
+}
+
+func TestValidatePoll(t *testing.T) {
+	p := &Poll{ID: "test", Options: []*restaurant.Building{r1, r2}, Votes: map[string][]string{"r1": {"Jack"}, "r2": {}}}
+	if err := ValidatePoll(p); err != nil {
+		t.Logf("Expected poll to be valid, got %s", err.Error())
+		t.Fail()
+	}
+
+	p.Votes["r3"] = []string{"Tom"}
+	p.Votes["r2"] = []string{"Jack"}
+	if got := strings.Join(fields(ValidatePoll(p)), ","); got != "votes.r2,votes.r3" {
+		t.Logf("Expected duplicate and unknown votes to be invalid, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestValidateVote(t *testing.T) {
+	p := &Poll{ID: "test", Options: []*restaurant.Building{r1, r2}}
+
+	if err := ValidateVote(p, "Jack", "r1"); err != nil {
+		t.Logf("Expected vote to be valid, got %s", err.Error())
+		t.Fail()
+	}
+
+	if got := strings.Join(fields(ValidateVote(p, "", "r3")), ","); got != "user,optionId" {
+		t.Logf("Expected the user and option to be invalid, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestModelRejectsInvalidPolls(t *testing.T) {
+	pm := &MockPollModel{}
+
+	if _, status, err := pm.NewPoll("", []*restaurant.Building{r1, r1}); err == nil || status != Invalid {
+		t.Log("Expected poll with duplicate options to be rejected as invalid")
+		t.Fail()
+	}
+
+	p, _, _ := pm.GetPoll("", "test")
+	p = p.Copy()
+	p.Votes["r3"] = []string{"Kate"}
+	if status, err := pm.UpdatePoll(p); err == nil || status != Invalid {
+		t.Log("Expected poll with a vote for an unknown option to be rejected as invalid")
+		t.Fail()
+	}
+}
+
+func TestValidateRights(t *testing.T) {
+	p := &Poll{
+		ID:      "test",
+		Options: []*restaurant.Building{r1, r2},
+		Rights:  map[string]Rights{"Jack": {Weight: 2, Vetoes: 1}, "Tom": {Weight: 0, Vetoes: MaxVetoes + 1}},
+		Vetoed:  map[string][]string{"r1": {"Jack"}, "r2": {"Jack"}, "r3": {"Will"}},
+	}
+
+	got := strings.Join(fields(ValidatePoll(p)), ",")
+	if got != "rights.Tom.weight,rights.Tom.vetoes,vetoed.r2,vetoed.r3,vetoed.r3" {
+		t.Logf("Expected invalid rights and vetoes to be reported, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	r := &Rules{Quorum: 3, QuorumPercent: 101, Invited: []string{"Jack", " ", "Jack"}, Margin: -1, OnFailure: ExtendPoll}
+
+	got := strings.Join(fields(ValidateRules(r)), ",")
+	if got != "rules.quorumPercent,rules.invited[1],rules.invited[2],rules.margin,rules.extendBy,rules.maxExtensions" {
+		t.Logf("Expected invalid rules to be reported, got %q", got)
+		t.Fail()
+	}
+
+	if err := ValidateRules(&Rules{QuorumPercent: 50}); err == nil {
+		t.Log("Expected a quorum percentage without invited users to be invalid")
+		t.Fail()
+	}
+
+	if err := ValidateRules(&Rules{Quorum: 2, Invited: []string{"Jack", "Tom"}, OnFailure: FailPoll}); err != nil {
+		t.Logf("Expected the rules to be valid, got %s", err.Error())
+		t.Fail()
+	}
+}
+
+func TestValidateOptionDelivery(t *testing.T) {
+	opt := &restaurant.Building{
+		ID:             "r1",
+		Name:           "Restaurant 1",
+		Location:       &restaurant.Location{Lat: 91, Lng: 0},
+		DeliveryRadius: -1,
+		Service:        "drone",
+		Hours:          []*restaurant.OpeningHours{{Day: "Friday", Opens: "11:00", Closes: "25:00"}},
+	}
+
+	got := strings.Join(fields(ValidateOption(opt)), ",")
+	if got != "option.location.lat,option.deliveryRadius,option.service,option.hours[0].day,option.hours[0].closes" {
+		t.Logf("Expected invalid delivery details to be reported, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestValidateReview(t *testing.T) {
+	r := &Review{User: "Jack", Rating: 6, Tags: []string{"late", "", "late", strings.Repeat("a", MaxTagLength+1)}, Comment: strings.Repeat("a", MaxCommentLength+1)}
+
+	got := strings.Join(fields(ValidateReview(r)), ",")
+	if got != "rating,tags[1],tags[2],tags[3],comment" {
+		t.Logf("Expected the invalid review to be reported, got %q", got)
+		t.Fail()
+	}
+
+	if err := ValidateReview(&Review{User: "Jack", Rating: 5, Tags: []string{"great portions"}}); err != nil {
+		t.Logf("Expected the review to be valid, got %s", err.Error())
+		t.Fail()
+	}
+}
@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// The headers sent with each delivery, giving the type of event delivered, the ID of the delivery, the unix time the delivery was signed at and its signature.
+const (
+	EventHeader     = "X-Takeaway-Event"
+	DeliveryHeader  = "X-Takeaway-Delivery"
+	TimestampHeader = "X-Takeaway-Timestamp"
+	SignatureHeader = "X-Takeaway-Signature"
+)
+
+// Sign returns the signature of a delivery with the given body signed at the given unix time using the given secret, as sent within the SignatureHeader. Subscribers verify a
+// delivery by computing the HMAC-SHA256 of the timestamp, a full stop and the body using their subscription's secret.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	io.WriteString(mac, timestamp+".")
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher delivers the events published by the vote package to the webhook subscriptions of each poll's workspace. Published events are recorded as pending deliveries which
+// are attempted straight away, failed attempts being retried with exponential backoff until MaxAttempts attempts have failed, after which the delivery is moved to the dead
+// letter list.
+type Dispatcher struct {
+	Model WebhookModel
+	// Client is used to send deliveries, defaulting to a client timing out after 10 seconds which refuses to connect to loopback, link-local and private addresses.
+	Client *http.Client
+	// MaxAttempts states how many times a delivery is attempted before it fails, defaulting to 6. Backoff states how long to wait after the first failed attempt, doubling after
+	// each further failure, defaulting to 30 seconds.
+	MaxAttempts int
+	Backoff     time.Duration
+	// Interval states how often the dispatcher checks for deliveries due to be retried, defaulting to every 10 seconds.
+	Interval time.Duration
+
+	due  chan struct{}
+	once sync.Once
+}
+
+// Publish records a pending delivery of the given event to every subscription of the poll's workspace notified of events of its type, waking the dispatcher to attempt them.
+func (d *Dispatcher) Publish(e *vote.Event, p *vote.Poll) {
+	subscriptions, _, err := d.Model.GetSubscriptions(e.Workspace)
+	if err != nil {
+		log.Printf("Webhooks: could not retrieve subscriptions of workspace %q due to: %s\n", e.Workspace, err)
+		return
+	}
+
+	now := time.Now()
+	for _, s := range subscriptions {
+		if !s.Matches(e.Type) {
+			continue
+		}
+
+		del := &Delivery{
+			SubscriptionID: s.ID,
+			Workspace:      e.Workspace,
+			Event:          e.Type,
+			PollID:         e.PollID,
+			State:          Pending,
+			CreatedAt:      now,
+			NextAttempt:    now,
+		}
+		if _, err := d.Model.NewDelivery(del); err != nil {
+			log.Printf("Webhooks: could not record delivery of %s event to %s due to: %s\n", e.Type, s.ID, err)
+			continue
+		}
+
+		// the payload holds the delivery's ID, so is set once the delivery has been assigned one.
+		del.Payload, err = json.Marshal(&Payload{Delivery: del.ID, Event: e, Poll: p})
+		if err == nil {
+			_, err = d.Model.UpdateDelivery(del)
+		}
+		if err != nil {
+			log.Printf("Webhooks: could not record payload of delivery %s due to: %s\n", del.ID, err)
+		}
+	}
+
+	select {
+	case d.wake() <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts the dispatcher's loop, attempting due deliveries whenever events are published and retrying failed deliveries until the stop channel is closed. Note this method
+// will block so should be ran as a separate goroutine.
+func (d *Dispatcher) Run(stop <-chan struct{}) {
+	interval := d.Interval
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			d.DeliverDue(now)
+		case <-d.wake():
+			d.DeliverDue(time.Now())
+		case <-stop:
+			return
+		}
+	}
+}
+
+// DeliverDue attempts every pending delivery whose next attempt is at or before now.
+func (d *Dispatcher) DeliverDue(now time.Time) {
+	deliveries, _, err := d.Model.GetDueDeliveries(now)
+	if err != nil {
+		log.Printf("Webhooks: could not retrieve due deliveries due to: %s\n", err)
+		return
+	}
+
+	for _, del := range deliveries {
+		d.Deliver(del, now)
+	}
+}
+
+// Deliver attempts the given delivery at the given time, recording the outcome of the attempt. Should the attempt fail the delivery is scheduled to be retried, or failed should
+// it have been attempted MaxAttempts times or its subscription have been deleted.
+func (d *Dispatcher) Deliver(del *Delivery, now time.Time) {
+	s, status, err := d.Model.GetSubscription(del.Workspace, del.SubscriptionID)
+	if err != nil {
+		if status != vote.NotFound {
+			log.Printf("Webhooks: could not retrieve subscription %s due to: %s\n", del.SubscriptionID, err)
+			return
+		}
+
+		log.Printf("Webhooks: failing delivery %s as its subscription has been deleted\n", del.ID)
+		del.State = Failed
+		del.LastError = "the subscription has been deleted"
+		if _, err := d.Model.UpdateDelivery(del); err != nil {
+			log.Printf("Webhooks: could not record failure of delivery %s due to: %s\n", del.ID, err)
+		}
+		return
+	}
+
+	del.Attempts++
+	del.LastStatus, err = d.send(s, del, now)
+	if err == nil {
+		del.State = Delivered
+		del.DeliveredAt = now
+		del.LastError = ""
+	} else {
+		del.LastError = err.Error()
+		if del.Attempts >= d.maxAttempts() {
+			del.State = Failed
+			log.Printf("Webhooks: delivery %s to %s failed after %v attempts: %s\n", del.ID, s.URL, del.Attempts, err)
+		} else {
+			del.NextAttempt = now.Add(d.backoff(del.Attempts))
+		}
+	}
+
+	if _, err := d.Model.UpdateDelivery(del); err != nil {
+		log.Printf("Webhooks: could not record attempt at delivery %s due to: %s\n", del.ID, err)
+	}
+}
+
+// Retry moves the given failed delivery out of the dead letter list, attempting it again straight away with a fresh set of attempts.
+func (d *Dispatcher) Retry(del *Delivery) (vote.Status, error) {
+	del.State = Pending
+	del.Attempts = 0
+	del.NextAttempt = time.Now()
+	status, err := d.Model.UpdateDelivery(del)
+	if err != nil {
+		return status, err
+	}
+
+	select {
+	case d.wake() <- struct{}{}:
+	default:
+	}
+	return vote.Ok, nil
+}
+
+// send posts the delivery's payload to the subscription's URL, returning the status code of the response along with an error should the subscription not accept it.
+func (d *Dispatcher) send(s *Subscription, del *Delivery, now time.Time) (int, error) {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(del.Event))
+	req.Header.Set(DeliveryHeader, del.ID)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, Sign(s.Secret, ts, del.Payload))
+
+	client := d.Client
+	if client == nil {
+		client = defaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("the subscriber responded with status %v", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// defaultClient sends deliveries for dispatchers without a client, refusing to connect to internal addresses, including those reached through a redirect.
+var defaultClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, Control: refuseInternal}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// backoff returns how long to wait before retrying a delivery which has failed the given number of attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.Backoff
+	if backoff == 0 {
+		backoff = 30 * time.Second
+	}
+	return backoff << uint(attempts-1)
+}
+
+func (d *Dispatcher) maxAttempts() int {
+	if d.MaxAttempts == 0 {
+		return 6
+	}
+	return d.MaxAttempts
+}
+
+// wake returns the channel used to wake the dispatcher's loop, creating it should it not yet exist.
+func (d *Dispatcher) wake() chan struct{} {
+	d.once.Do(func() {
+		d.due = make(chan struct{}, 1)
+	})
+	return d.due
+}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/websocket"
+)
+
+var hub sync.Once
+
+// subscriber records the deliveries it receives, responding to each with the given status code.
+type subscriber struct {
+	mutex    sync.Mutex
+	status   int
+	payloads []*Payload
+	headers  []http.Header
+	bodies   [][]byte
+}
+
+func (s *subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	p := &Payload{}
+	json.Unmarshal(body, p)
+	s.payloads = append(s.payloads, p)
+	s.headers = append(s.headers, r.Header)
+	s.bodies = append(s.bodies, body)
+	w.WriteHeader(s.status)
+}
+
+func TestPublish(t *testing.T) {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	sub := &subscriber{status: http.StatusOK}
+	server := httptest.NewServer(sub)
+	defer server.Close()
+
+	model := &MockWebhookModel{}
+	d := &Dispatcher{Model: model, Client: server.Client()}
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}, Publisher: d})
+
+	all := &Subscription{URL: server.URL, Secret: "secret"}
+	created := &Subscription{URL: server.URL, Secret: "secret", Events: []vote.EventType{vote.PollCreated}}
+	other := &Subscription{Workspace: "other", URL: server.URL, Secret: "secret"}
+	for _, s := range []*Subscription{all, created, other} {
+		model.NewSubscription(s)
+	}
+
+	poll, _, err := vote.CreatePoll("", "Jack", []*restaurant.Building{{ID: "r1", Name: "Restaurant 1"}}, nil)
+	if err != nil {
+		t.Fatalf("Could not create poll: %s", err)
+	}
+	vote.CastVote("", poll.ID, "Tom", "r1")
+	d.DeliverDue(time.Now())
+
+	if len(sub.payloads) != 3 {
+		t.Fatalf("Expected the creation to be delivered to both subscriptions and the vote to one, got %v deliveries", len(sub.payloads))
+	}
+	for i, p := range sub.payloads {
+		h := sub.headers[i]
+		if h.Get(SignatureHeader) != Sign("secret", h.Get(TimestampHeader), sub.bodies[i]) || h.Get(DeliveryHeader) != p.Delivery || h.Get(EventHeader) != string(p.Event.Type) {
+			t.Logf("Expected delivery %v to be signed and labelled, got %v", i, h)
+			t.Fail()
+		}
+		if p.Poll == nil || p.Poll.ID != poll.ID {
+			t.Logf("Expected delivery %v to hold the poll, got %+v", i, p.Poll)
+			t.Fail()
+		}
+	}
+	if last := sub.payloads[2]; last.Event.Type != vote.VoteCast || last.Event.User != "Tom" || len(last.Poll.Votes["r1"]) != 1 {
+		t.Logf("Expected the vote to be delivered along with the updated poll, got %+v", last.Event)
+		t.Fail()
+	}
+
+	deliveries, _, _ := model.GetDeliveries("", all.ID, Delivered)
+	if len(deliveries) != 2 || deliveries[0].Event != vote.VoteCast || deliveries[0].Attempts != 1 || deliveries[0].LastStatus != http.StatusOK {
+		t.Logf("Expected both deliveries to be logged as delivered, most recent first, got %+v", deliveries)
+		t.Fail()
+	}
+}
+
+func TestRetries(t *testing.T) {
+	sub := &subscriber{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(sub)
+	defer server.Close()
+
+	model := &MockWebhookModel{}
+	d := &Dispatcher{Model: model, Client: server.Client(), MaxAttempts: 3, Backoff: time.Minute}
+	s := &Subscription{URL: server.URL, Secret: "secret"}
+	model.NewSubscription(s)
+
+	d.Publish(&vote.Event{PollID: "p1", Type: vote.PollDecided}, &vote.Poll{ID: "p1"})
+
+	now := time.Now()
+	for i, wait := range []time.Duration{time.Minute, 2 * time.Minute} {
+		d.DeliverDue(now)
+		pending, _, _ := model.GetDeliveries("", s.ID, Pending)
+		if len(pending) != 1 || pending[0].Attempts != i+1 || !pending[0].NextAttempt.Equal(now.Add(wait)) || pending[0].LastStatus != http.StatusServiceUnavailable {
+			t.Fatalf("Expected attempt %v to be retried after %s, got %+v", i+1, wait, pending)
+		}
+
+		d.DeliverDue(now.Add(wait - time.Second))
+		if len(sub.payloads) != i+1 {
+			t.Fatalf("Expected the delivery not to be retried before its backoff, got %v attempts", len(sub.payloads))
+		}
+		now = now.Add(wait)
+	}
+
+	d.DeliverDue(now)
+	failed, _, _ := model.GetDeliveries("", s.ID, Failed)
+	if len(failed) != 1 || failed[0].Attempts != 3 || failed[0].LastError == "" {
+		t.Fatalf("Expected the delivery to be dead lettered after 3 attempts, got %+v", failed)
+	}
+
+	sub.status = http.StatusNoContent
+	d.Retry(failed[0])
+	d.DeliverDue(time.Now())
+	delivered, _, _ := model.GetDeliveries("", s.ID, Delivered)
+	if len(delivered) != 1 || delivered[0].Attempts != 1 || delivered[0].LastError != "" {
+		t.Logf("Expected the retried delivery to be delivered, got %+v", delivered)
+		t.Fail()
+	}
+}
+
+func TestPublishPrivatePoll(t *testing.T) {
+	hub.Do(func() { go websocket.HubInstance.Run() })
+	sub := &subscriber{status: http.StatusOK}
+	server := httptest.NewServer(sub)
+	defer server.Close()
+
+	model := &MockWebhookModel{}
+	d := &Dispatcher{Model: model, Client: server.Client()}
+	vote.Init(&vote.Container{Model: &vote.MockPollModel{}, Events: &vote.MockEventModel{}, Publisher: d})
+	model.NewSubscription(&Subscription{URL: server.URL, Secret: "secret"})
+
+	settings := &vote.PollSettings{Visibility: vote.Anonymous}
+	poll, _, err := vote.CreatePoll("", "Jack", []*restaurant.Building{{ID: "r1", Name: "Restaurant 1"}, {ID: "r2", Name: "Restaurant 2"}}, settings)
+	if err != nil {
+		t.Fatalf("Could not create poll: %s", err)
+	}
+	vote.CastVote("", poll.ID, "Tom", "r1")
+	vote.CastVote("", poll.ID, "Will", "r2")
+	d.DeliverDue(time.Now())
+
+	if len(sub.bodies) != 3 {
+		t.Fatalf("Expected the creation and both votes to be delivered, got %v deliveries", len(sub.bodies))
+	}
+	for i, body := range sub.bodies {
+		for _, voter := range []string{"Tom", "Will"} {
+			if strings.Contains(string(body), voter) {
+				t.Logf("Expected delivery %v not to reveal %s voted, got %s", i, voter, body)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestDeliverDeletedSubscription(t *testing.T) {
+	model := &MockWebhookModel{}
+	d := &Dispatcher{Model: model}
+	del := &Delivery{SubscriptionID: "deleted", Event: vote.PollDecided, PollID: "p1", State: Pending, NextAttempt: time.Now()}
+	model.NewDelivery(del)
+
+	d.DeliverDue(time.Now())
+	failed, _, _ := model.GetDeliveries("", "deleted", Failed)
+	if len(failed) != 1 || failed[0].LastError == "" {
+		t.Fatalf("Expected the delivery to be failed once its subscription has been deleted, got %+v", failed)
+	}
+
+	due, _, _ := model.GetDueDeliveries(time.Now())
+	if len(due) != 0 {
+		t.Logf("Expected the failed delivery not to be attempted again, got %+v", due)
+		t.Fail()
+	}
+}
+
+func TestValidateSubscription(t *testing.T) {
+	cases := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://hooks.example.com/takeaway", true},
+		{"http://203.0.113.7:8080/hook", true},
+		{"hooks.example.com", false},
+		{"ftp://hooks.example.com", false},
+		{"http://localhost:8080/hook", false},
+		{"http://127.0.0.1/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://10.0.0.5/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://0.0.0.0/hook", false},
+	}
+
+	for _, c := range cases {
+		err := (&Subscription{URL: c.url}).Validate()
+		if (err == nil) != c.valid {
+			t.Logf("Expected %s to be valid: %v, got error %v", c.url, c.valid, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestDeliverInternalAddress(t *testing.T) {
+	sub := &subscriber{status: http.StatusOK}
+	server := httptest.NewServer(sub)
+	defer server.Close()
+
+	model := &MockWebhookModel{}
+	d := &Dispatcher{Model: model}
+	s := &Subscription{URL: server.URL, Secret: "secret"}
+	model.NewSubscription(s)
+
+	d.Publish(&vote.Event{PollID: "p1", Type: vote.PollDecided}, &vote.Poll{ID: "p1"})
+	d.DeliverDue(time.Now())
+
+	if len(sub.payloads) != 0 {
+		t.Logf("Expected the default client to refuse delivering to a loopback address, got %v deliveries", len(sub.payloads))
+		t.Fail()
+	}
+}
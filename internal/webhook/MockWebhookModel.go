@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockWebhookModel provides an in memory implementation of the WebhookModel interface.
+type MockWebhookModel struct {
+	mutex         sync.Mutex
+	subscriptions []*Subscription
+	deliveries    []*Delivery
+	nextID        int
+}
+
+// GetSubscription returns the stored subscription with the given ID within the given workspace, returning an error along with a 'NotFound' status should no such subscription
+// exist.
+func (wm *MockWebhookModel) GetSubscription(workspace string, id string) (s *Subscription, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	for _, sub := range wm.subscriptions {
+		if sub.Workspace == workspace && sub.ID == id {
+			c := *sub
+			return &c, vote.Ok, nil
+		}
+	}
+	return nil, vote.NotFound, fmt.Errorf("the id %s could not be found", id)
+}
+
+// GetSubscriptions returns all stored subscriptions within the given workspace.
+func (wm *MockWebhookModel) GetSubscriptions(workspace string) (subscriptions []*Subscription, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	subscriptions = make([]*Subscription, 0)
+	for _, s := range wm.subscriptions {
+		if s.Workspace == workspace {
+			c := *s
+			subscriptions = append(subscriptions, &c)
+		}
+	}
+	return
+}
+
+// NewSubscription stores the given subscription, assigning it a sequential ID.
+func (wm *MockWebhookModel) NewSubscription(s *Subscription) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.nextID++
+	s.ID = "webhook" + strconv.Itoa(wm.nextID)
+	c := *s
+	wm.subscriptions = append(wm.subscriptions, &c)
+	return
+}
+
+// DeleteSubscription removes the stored subscription with the given ID from the given workspace along with its deliveries, returning an error along with a 'NotFound' status
+// should no such subscription exist.
+func (wm *MockWebhookModel) DeleteSubscription(workspace string, id string) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	for i, s := range wm.subscriptions {
+		if s.Workspace == workspace && s.ID == id {
+			wm.subscriptions = append(wm.subscriptions[:i], wm.subscriptions[i+1:]...)
+
+			kept := wm.deliveries[:0]
+			for _, d := range wm.deliveries {
+				if d.Workspace != workspace || d.SubscriptionID != id {
+					kept = append(kept, d)
+				}
+			}
+			wm.deliveries = kept
+			return
+		}
+	}
+	return vote.NotFound, fmt.Errorf("the id %s could not be found", id)
+}
+
+// GetDelivery returns the stored delivery with the given ID within the given workspace, returning an error along with a 'NotFound' status should no such delivery exist.
+func (wm *MockWebhookModel) GetDelivery(workspace string, id string) (d *Delivery, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	for _, del := range wm.deliveries {
+		if del.Workspace == workspace && del.ID == id {
+			c := *del
+			return &c, vote.Ok, nil
+		}
+	}
+	return nil, vote.NotFound, fmt.Errorf("the id %s could not be found", id)
+}
+
+// GetDeliveries returns the stored deliveries to the given subscription, most recent first, only returning those in the given state should one be given.
+func (wm *MockWebhookModel) GetDeliveries(workspace string, subscriptionID string, state DeliveryState) (deliveries []*Delivery, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	deliveries = make([]*Delivery, 0)
+	for i := len(wm.deliveries) - 1; i >= 0; i-- {
+		d := wm.deliveries[i]
+		if d.Workspace == workspace && d.SubscriptionID == subscriptionID && (state == "" || d.State == state) {
+			c := *d
+			deliveries = append(deliveries, &c)
+		}
+	}
+	return
+}
+
+// GetDueDeliveries returns all stored pending deliveries whose next attempt is at or before the given time.
+func (wm *MockWebhookModel) GetDueDeliveries(now time.Time) (deliveries []*Delivery, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	deliveries = make([]*Delivery, 0)
+	for _, d := range wm.deliveries {
+		if d.State == Pending && !d.NextAttempt.After(now) {
+			c := *d
+			deliveries = append(deliveries, &c)
+		}
+	}
+	return
+}
+
+// NewDelivery stores the given delivery, assigning it a sequential ID.
+func (wm *MockWebhookModel) NewDelivery(d *Delivery) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.nextID++
+	d.ID = "delivery" + strconv.Itoa(wm.nextID)
+	c := *d
+	wm.deliveries = append(wm.deliveries, &c)
+	return
+}
+
+// UpdateDelivery replaces the stored delivery with the same ID as the given delivery, returning an error along with a 'NotFound' status should no such delivery exist.
+func (wm *MockWebhookModel) UpdateDelivery(d *Delivery) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	for i, del := range wm.deliveries {
+		if del.Workspace == d.Workspace && del.ID == d.ID {
+			c := *d
+			wm.deliveries[i] = &c
+			return
+		}
+	}
+	return vote.NotFound, fmt.Errorf("the id %s could not be found", d.ID)
+}
+
+// Close has been added to ensure the mock meets the WebhookModel interface, it does not need to actually complete anything.
+func (wm *MockWebhookModel) Close() (err error) {
+	return
+}
@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoWebhookModel provides a mongo based implementation to the WebhookModel interface, storing subscriptions within the webhooks collection and their deliveries within the
+// deliveries collection.
+type MongoWebhookModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// GetSubscription gets the subscription with the specified id within the given workspace from the mongo database.
+func (wm *MongoWebhookModel) GetSubscription(workspace string, id string) (s *Subscription, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := Subscription{}
+	c := wm.session.DB(wm.DBName).C("webhooks")
+	err = c.Find(db.Scope(workspace, bson.M{"id": id})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	s = &data
+	return
+}
+
+// GetSubscriptions returns all subscriptions stored within the mongo database for the given workspace.
+func (wm *MongoWebhookModel) GetSubscriptions(workspace string) (subscriptions []*Subscription, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	subscriptions = make([]*Subscription, 0)
+	c := wm.session.DB(wm.DBName).C("webhooks")
+	err = c.Find(db.Scope(workspace, nil)).All(&subscriptions)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// NewSubscription stores the given subscription within the mongo database, assigning it a new ID.
+func (wm *MongoWebhookModel) NewSubscription(s *Subscription) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	s.ID = bson.NewObjectId().Hex()
+	c := wm.session.DB(wm.DBName).C("webhooks")
+	err = c.Insert(s)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// DeleteSubscription removes the subscription with the given ID within the given workspace from the mongo database, along with its deliveries.
+func (wm *MongoWebhookModel) DeleteSubscription(workspace string, id string) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := wm.session.DB(wm.DBName).C("webhooks")
+	err = c.Remove(db.Scope(workspace, bson.M{"id": id}))
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	_, err = wm.session.DB(wm.DBName).C("deliveries").RemoveAll(db.Scope(workspace, bson.M{"subscriptionId": id}))
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// GetDelivery gets the delivery with the specified id within the given workspace from the mongo database.
+func (wm *MongoWebhookModel) GetDelivery(workspace string, id string) (d *Delivery, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := Delivery{}
+	c := wm.session.DB(wm.DBName).C("deliveries")
+	err = c.Find(db.Scope(workspace, bson.M{"id": id})).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	d = &data
+	return
+}
+
+// GetDeliveries returns the deliveries to the given subscription stored within the mongo database, most recent first, only returning those in the given state should one be
+// given.
+func (wm *MongoWebhookModel) GetDeliveries(workspace string, subscriptionID string, state DeliveryState) (deliveries []*Delivery, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	filter := bson.M{"subscriptionId": subscriptionID}
+	if state != "" {
+		filter["state"] = state
+	}
+
+	deliveries = make([]*Delivery, 0)
+	c := wm.session.DB(wm.DBName).C("deliveries")
+	err = c.Find(db.Scope(workspace, filter)).Sort("-createdAt").All(&deliveries)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// GetDueDeliveries returns all pending deliveries stored within the mongo database whose next attempt is at or before the given time.
+func (wm *MongoWebhookModel) GetDueDeliveries(now time.Time) (deliveries []*Delivery, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	deliveries = make([]*Delivery, 0)
+	c := wm.session.DB(wm.DBName).C("deliveries")
+	err = c.Find(bson.M{"state": Pending, "nextAttempt": bson.M{"$lte": now}}).Sort("nextAttempt").All(&deliveries)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// NewDelivery stores the given delivery within the mongo database, assigning it a new ID.
+func (wm *MongoWebhookModel) NewDelivery(d *Delivery) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	d.ID = bson.NewObjectId().Hex()
+	c := wm.session.DB(wm.DBName).C("deliveries")
+	err = c.Insert(d)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// UpdateDelivery replaces the delivery stored within the mongo database with the same ID and workspace as the given delivery.
+func (wm *MongoWebhookModel) UpdateDelivery(d *Delivery) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := wm.session.DB(wm.DBName).C("deliveries")
+	err = c.Update(db.Scope(d.Workspace, bson.M{"id": d.ID}), d)
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (wm *MongoWebhookModel) Close() (err error) {
+	if wm.session != nil {
+		wm.session.Close()
+	}
+	return
+}
+
+func (wm *MongoWebhookModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&wm.session, wm.URL, wm.Username, wm.Password)
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// Subscription represents a URL within a workspace notified of changes made to the workspace's polls. Events lists the types of event the subscription is notified of, no events
+// stating it is notified of every event. Each delivery is signed using Secret, which is only returned when the subscription is created.
+type Subscription struct {
+	ID        string           `json:"id" bson:"id"`
+	Workspace string           `json:"workspace" bson:"workspace"`
+	URL       string           `json:"url" bson:"url"`
+	Events    []vote.EventType `json:"events" bson:"events"`
+	Secret    string           `json:"secret,omitempty" bson:"secret"`
+	CreatedAt time.Time        `json:"createdAt" bson:"createdAt"`
+}
+
+// Matches returns whether the subscription is notified of events of the given type.
+func (s *Subscription) Matches(t vote.EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy of the subscription without its secret.
+func (s *Subscription) Redacted() *Subscription {
+	c := *s
+	c.Secret = ""
+	return &c
+}
+
+// Validate checks the subscription's URL is an absolute http or https URL whose host is not a loopback, link-local or private address, and each of its events is a known type
+// of event. Hosts given by name are checked again when each delivery is sent, once they have been resolved.
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL, got %q", s.URL)
+	}
+	if host := strings.ToLower(u.Hostname()); host == "localhost" || strings.HasSuffix(host, ".localhost") || isInternal(net.ParseIP(host)) {
+		return fmt.Errorf("url must not point to a loopback, link-local or private address, got %q", s.URL)
+	}
+
+	for _, e := range s.Events {
+		known := false
+		for _, t := range vote.EventTypes {
+			known = known || e == t
+		}
+		if !known {
+			return fmt.Errorf("%q is not a known type of event", e)
+		}
+	}
+	return nil
+}
+
+// internalNetworks lists the private and shared address ranges which, along with loopback, link-local and unspecified addresses, webhooks may not be delivered to.
+var internalNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, n, _ := net.ParseCIDR(cidr)
+		networks = append(networks, n)
+	}
+	return networks
+}()
+
+// isInternal returns whether the given IP address is a loopback, link-local, unspecified or private address, returning false should no address be given.
+func isInternal(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, n := range internalNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// refuseInternal is used as the Control of the dialer sending deliveries, refusing to connect to internal addresses so subscriptions whose host resolves to one are not
+// delivered to.
+func refuseInternal(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isInternal(ip) {
+		return fmt.Errorf("refusing to deliver to the internal address %s", address)
+	}
+	return nil
+}
+
+// newSecret returns a random secret for signing a subscription's deliveries.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// DeliveryState represents the progress of delivering an event to a subscription.
+type DeliveryState string
+
+const (
+	// Pending states the delivery has yet to succeed and will be attempted again at its NextAttempt.
+	Pending DeliveryState = "pending"
+	// Delivered states the subscription accepted the delivery.
+	Delivered DeliveryState = "delivered"
+	// Failed states every attempt at the delivery failed, the delivery being kept within the dead letter list until it is retried.
+	Failed DeliveryState = "failed"
+)
+
+// Delivery records the delivery of a single event to a subscription, Payload being the body sent to the subscription's URL. LastStatus and LastError describe the response to the
+// most recent attempt.
+type Delivery struct {
+	ID             string          `json:"id" bson:"id"`
+	SubscriptionID string          `json:"subscriptionId" bson:"subscriptionId"`
+	Workspace      string          `json:"workspace" bson:"workspace"`
+	Event          vote.EventType  `json:"event" bson:"event"`
+	PollID         string          `json:"pollId" bson:"pollId"`
+	Payload        json.RawMessage `json:"payload" bson:"payload"`
+	State          DeliveryState   `json:"state" bson:"state"`
+	Attempts       int             `json:"attempts" bson:"attempts"`
+	LastStatus     int             `json:"lastStatus,omitempty" bson:"lastStatus,omitempty"`
+	LastError      string          `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt" bson:"createdAt"`
+	NextAttempt    time.Time       `json:"nextAttempt" bson:"nextAttempt"`
+	DeliveredAt    time.Time       `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+}
+
+// Payload represents the body of each delivery, holding the event along with the poll as it is seen by a user who has not voted once the event has been applied.
+type Payload struct {
+	Delivery string      `json:"delivery"`
+	Event    *vote.Event `json:"event"`
+	Poll     *vote.Poll  `json:"poll"`
+}
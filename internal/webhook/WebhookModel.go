@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"time"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+var instance *Container
+
+// WebhookModel defines a contract for how the system should interact with the database for accessing webhook subscriptions and their deliveries. As with polls, every
+// subscription belongs to a workspace, the default workspace being represented by an empty string.
+type WebhookModel interface {
+	// GetSubscription allows for a singular subscription within the given workspace to be accessed using its ID, returning an error along with a 'NotFound' status should it not
+	// exist.
+	GetSubscription(workspace string, id string) (*Subscription, vote.Status, error)
+	// GetSubscriptions returns all subscriptions within the given workspace.
+	GetSubscriptions(workspace string) ([]*Subscription, vote.Status, error)
+	// NewSubscription stores the given subscription within its workspace, assigning it a new ID.
+	NewSubscription(s *Subscription) (vote.Status, error)
+	// DeleteSubscription attempts to delete the subscription with the given ID from the given workspace, along with its deliveries.
+	DeleteSubscription(workspace string, id string) (vote.Status, error)
+	// GetDelivery allows for a singular delivery within the given workspace to be accessed using its ID.
+	GetDelivery(workspace string, id string) (*Delivery, vote.Status, error)
+	// GetDeliveries returns the deliveries to the subscription with the given ID within the given workspace, most recent first, only returning those in the given state should
+	// one be given.
+	GetDeliveries(workspace string, subscriptionID string, state DeliveryState) ([]*Delivery, vote.Status, error)
+	// GetDueDeliveries returns all pending deliveries, across every workspace, whose next attempt is at or before the given time.
+	GetDueDeliveries(now time.Time) ([]*Delivery, vote.Status, error)
+	// NewDelivery stores the given delivery, assigning it a new ID.
+	NewDelivery(d *Delivery) (vote.Status, error)
+	// UpdateDelivery replaces the stored delivery with the same ID and workspace as the given delivery.
+	UpdateDelivery(d *Delivery) (vote.Status, error)
+	// Close allows for a WebhookModel connection to be closed.
+	Close() error
+}
+
+// Container provides access to injected implementation of WebhookModel for the application, along with the Dispatcher delivering events to subscriptions.
+type Container struct {
+	Model      WebhookModel `inject:""`
+	Dispatcher *Dispatcher
+}
+
+// Init allows the webhook package to be initialised with the Container c.
+func Init(c *Container) {
+	instance = c
+}
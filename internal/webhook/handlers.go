@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetSubscription provides a http handler for accessing a specified webhook subscription within the caller's workspace. The subscription's secret is not returned.
+func GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := requireID(w, r)
+	if !ok {
+		return
+	}
+
+	s, status, err := instance.Model.GetSubscription(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find webhook %s, returning not found status.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find webhook due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.Redacted())
+}
+
+// GetSubscriptions provides a http handler for listing all webhook subscriptions within the caller's workspace, without their secrets.
+func GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, _, err := instance.Model.GetSubscriptions(caller.Workspace(r.Context()))
+	if err != nil {
+		log.Printf("Could not retrieve webhooks due to: %s\n", err.Error())
+		http.Error(w, "Could not retrieve webhooks", http.StatusInternalServerError)
+		return
+	}
+
+	for i, s := range subscriptions {
+		subscriptions[i] = s.Redacted()
+	}
+	writeJSON(w, http.StatusOK, subscriptions)
+}
+
+// NewSubscription provides a http handler for subscribing a URL to the events of the caller's workspace. A secret is generated for the subscription should none be given, the
+// secret only being returned by this handler.
+func NewSubscription(w http.ResponseWriter, r *http.Request) {
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	// if request body could not be parsed, return an internal server error to the client.
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	s := &Subscription{}
+	if err = json.Unmarshal(b, s); err != nil {
+		log.Printf("Could not parse %s into a webhook", b)
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	if err = s.Validate(); err != nil {
+		log.Printf("Supplied webhook invalid: %s\n", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if s.Secret == "" {
+		if s.Secret, err = newSecret(); err != nil {
+			log.Printf("Could not generate a webhook secret due to: %s\n", err.Error())
+			http.Error(w, "Webhook could not be created", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// subscriptions can only be created within the caller's workspace.
+	s.Workspace = caller.Workspace(r.Context())
+	s.CreatedAt = time.Now()
+	if _, err = instance.Model.NewSubscription(s); err != nil {
+		log.Printf("Could not create a new webhook due to: %s\n", err.Error())
+		http.Error(w, "Webhook could not be created", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Created webhook with id %v for %s\n", s.ID, s.URL)
+	writeJSON(w, http.StatusCreated, s)
+}
+
+// DeleteSubscription provides a http handler for removing a webhook subscription along with its delivery log.
+func DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := requireID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := instance.Model.DeleteSubscription(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find the ID %s\n", id)
+			http.Error(w, "ID not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+// GetDeliveries provides a http handler for the delivery log of a webhook subscription, most recent delivery first. The state parameter restricts the log to deliveries in the
+// given state, the failed state listing the subscription's dead letters.
+func GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := requireID(w, r)
+	if !ok {
+		return
+	}
+
+	state := DeliveryState(r.URL.Query().Get("state"))
+	if state != "" && state != Pending && state != Delivered && state != Failed {
+		log.Printf("Invalid delivery state %s requested\n", state)
+		http.Error(w, "state must be pending, delivered or failed", http.StatusBadRequest)
+		return
+	}
+
+	workspace := caller.Workspace(r.Context())
+	if _, status, err := instance.Model.GetSubscription(workspace, id); err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find webhook %s, returning not found status.\n", id)
+			http.Error(w, "ID not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not retrieve deliveries", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	deliveries, _, err := instance.Model.GetDeliveries(workspace, id, state)
+	if err != nil {
+		log.Printf("Could not retrieve deliveries of webhook %s due to: %s\n", id, err.Error())
+		http.Error(w, "Could not retrieve deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+// RetryDelivery provides a http handler for moving a failed delivery out of the dead letter list, attempting it again with a fresh set of attempts.
+func RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := requireID(w, r)
+	if !ok {
+		return
+	}
+
+	del, status, err := instance.Model.GetDelivery(caller.Workspace(r.Context()), id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find delivery %s, returning not found status.\n", id)
+			http.Error(w, "ID not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Could not retry delivery", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if del.State != Failed {
+		log.Printf("Delivery %s is %s so cannot be retried\n", id, del.State)
+		http.Error(w, "Only failed deliveries can be retried", http.StatusConflict)
+		return
+	}
+
+	if _, err = instance.Dispatcher.Retry(del); err != nil {
+		log.Printf("Could not retry delivery %s due to: %s\n", id, err.Error())
+		http.Error(w, "Could not retry delivery", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Retrying delivery %s\n", id)
+	writeJSON(w, http.StatusAccepted, del)
+}
+
+// requireID returns the id query parameter of the given request, writing a bad request status and returning false should it be missing or empty.
+func requireID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(data)
+}
@@ -3,19 +3,31 @@ package websocket
 import (
 	"encoding/json"
 	"log"
-	"takeaway/takeaway-server/internal/vote"
+	"sync"
 )
 
+// listenerBuffer is the number of messages buffered for each listener, messages being dropped for listeners that fall further behind.
+const listenerBuffer = 16
+
 // HubInstance is a singleton instance of the Hub struct.
 var HubInstance = newHub()
 
+// Message represents a payload to be broadcast to all clients watching the poll with the given ID.
+type Message struct {
+	PollID string
+	Data   interface{}
+}
+
 // Hub provides a collection for managing all websocket connections to the server,
 // based off https://github.com/gorilla/websocket/blob/master/examples/chat/hub.go.
 type Hub struct {
-	Broadcast  chan *vote.Poll
+	Broadcast  chan *Message
 	clients    map[string][]*Client
 	register   chan *Client
 	unregister chan *Client
+	// listeners receive messages broadcast for a poll within the server's own process, rather than over a websocket connection.
+	listeners map[string][]chan *Message
+	mutex     sync.Mutex
 }
 
 // Run starts the event loop for the given Hub object, note this method will block so should be ran as a seporate goroutine.
@@ -29,13 +41,16 @@ func (h *Hub) Run() {
 			h.clients[client.ID] = append(h.clients[client.ID], client)
 		case client := <-h.unregister:
 			h.delete(client)
-		case poll := <-h.Broadcast:
-			data, err := json.Marshal(poll)
+		case msg := <-h.Broadcast:
+			h.notifyListeners(msg)
+
+			data, err := json.Marshal(msg.Data)
 			if err != nil {
-				log.Printf("Hub: could not marshal poll object due to, %s \n", err)
+				log.Printf("Hub: could not marshal message for poll %s due to, %s \n", msg.PollID, err)
+				continue
 			}
 			// send given data to all registered clients for the specified poll's ID.
-			for _, c := range h.clients[poll.ID] {
+			for _, c := range h.clients[msg.PollID] {
 				// attempt to place data onto given client's send channel, should this not be possible assume the given
 				// client is not valid and unregister it.
 				select {
@@ -56,17 +71,61 @@ func (h *Hub) delete(client *Client) {
 	close(client.send)
 }
 
+// Listen registers a listener for messages broadcast for the poll with the given ID, allowing subscribers within the server, such as GraphQL subscriptions, to receive the same
+// updates as websocket clients. The returned function stops the listener, closing its channel.
+func (h *Hub) Listen(pollID string) (<-chan *Message, func()) {
+	ch := make(chan *Message, listenerBuffer)
+
+	h.mutex.Lock()
+	h.listeners[pollID] = append(h.listeners[pollID], ch)
+	h.mutex.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+
+			for i, l := range h.listeners[pollID] {
+				if l == ch {
+					h.listeners[pollID] = append(h.listeners[pollID][:i], h.listeners[pollID][i+1:]...)
+					break
+				}
+			}
+			if len(h.listeners[pollID]) == 0 {
+				delete(h.listeners, pollID)
+			}
+			close(ch)
+		})
+	}
+}
+
+func (h *Hub) notifyListeners(msg *Message) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, l := range h.listeners[msg.PollID] {
+		// listeners must not block the hub, so messages are dropped for listeners unable to keep up.
+		select {
+		case l <- msg:
+		default:
+			log.Printf("Hub: dropped message for a listener of poll %s\n", msg.PollID)
+		}
+	}
+}
+
 // newHub create a brand new Hub objecct instance, returning a pointer to said instance.
 func newHub() *Hub {
 	return &Hub{
-		Broadcast:  make(chan *vote.Poll),
+		Broadcast:  make(chan *Message),
 		clients:    make(map[string][]*Client),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		listeners:  make(map[string][]chan *Message),
 	}
 }
 
-// NotifyChange is a utility method allowing an updated poll object to be broadcasted using the HubInstance.
-func NotifyChange(p *vote.Poll) {
-	HubInstance.Broadcast <- p
+// NotifyChange is a utility method allowing data relating to the poll with the given ID, such as the updated poll object, to be broadcasted using the HubInstance.
+func NotifyChange(pollID string, data interface{}) {
+	HubInstance.Broadcast <- &Message{PollID: pollID, Data: data}
 }
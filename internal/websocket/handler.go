@@ -17,9 +17,11 @@ func HandleWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Println(err)
+		return
 	}
 
-	c := &Client{conn: conn, send: make(chan []byte, 256)}
+	// clients subscribe to updates for the poll specified by the id query parameter.
+	c := &Client{conn: conn, send: make(chan []byte, 256), ID: r.URL.Query().Get("id")}
 	hub.register <- c
 
 	go c.writeLoop()
@@ -0,0 +1,103 @@
+package workspace
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// MockWorkspaceModel provides an in memory implementation of the WorkspaceModel interface.
+type MockWorkspaceModel struct {
+	mutex      sync.Mutex
+	workspaces []*Workspace
+	nextID     int
+}
+
+// GetWorkspace returns the stored workspace with the given ID, returning an error along with a 'NotFound' status should no such workspace exist.
+func (wm *MockWorkspaceModel) GetWorkspace(id string) (w *Workspace, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	i := wm.indexOf(id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	w = wm.workspaces[i]
+	return
+}
+
+// GetWorkspacesFor returns all stored workspaces the given user is a member of.
+func (wm *MockWorkspaceModel) GetWorkspacesFor(user string) (workspaces []*Workspace, status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	workspaces = make([]*Workspace, 0)
+	for _, w := range wm.workspaces {
+		if w.HasMember(user) {
+			workspaces = append(workspaces, w)
+		}
+	}
+	return
+}
+
+// NewWorkspace stores the given workspace, assigning it a sequential ID.
+func (wm *MockWorkspaceModel) NewWorkspace(w *Workspace) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.nextID++
+	w.ID = "workspace" + strconv.Itoa(wm.nextID)
+	wm.workspaces = append(wm.workspaces, w)
+	return
+}
+
+// UpdateWorkspace replaces the stored workspace with the same ID as the given workspace, returning an error along with a 'NotFound' status should no such workspace exist.
+func (wm *MockWorkspaceModel) UpdateWorkspace(w *Workspace) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	i := wm.indexOf(w.ID)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", w.ID)
+		status = vote.NotFound
+		return
+	}
+
+	wm.workspaces[i] = w
+	return
+}
+
+// DeleteWorkspace removes the stored workspace with the given ID, returning an error along with a 'NotFound' status should no such workspace exist.
+func (wm *MockWorkspaceModel) DeleteWorkspace(id string) (status vote.Status, err error) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	i := wm.indexOf(id)
+	if i < 0 {
+		err = fmt.Errorf("the id %s could not be found", id)
+		status = vote.NotFound
+		return
+	}
+
+	wm.workspaces = append(wm.workspaces[:i], wm.workspaces[i+1:]...)
+	return
+}
+
+// Close has been added to ensure the mock meets the WorkspaceModel interface, it does not need to actually complete anything.
+func (wm *MockWorkspaceModel) Close() (err error) {
+	return
+}
+
+func (wm *MockWorkspaceModel) indexOf(id string) int {
+	for i, w := range wm.workspaces {
+		if w.ID == id {
+			return i
+		}
+	}
+	return -1
+}
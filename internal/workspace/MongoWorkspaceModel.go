@@ -0,0 +1,120 @@
+package workspace
+
+import (
+	"takeaway/takeaway-server/internal/db"
+	"takeaway/takeaway-server/internal/vote"
+
+	"github.com/globalsign/mgo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MongoWorkspaceModel provides a mongo based implementation to the WorkspaceModel interface.
+type MongoWorkspaceModel struct {
+	session  *mgo.Session
+	DBName   string
+	URL      string
+	Username string
+	Password string
+}
+
+// GetWorkspace gets the workspace with the specified id from the mongo database.
+func (wm *MongoWorkspaceModel) GetWorkspace(id string) (w *Workspace, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	data := Workspace{}
+	c := wm.session.DB(wm.DBName).C("workspaces")
+	err = c.Find(bson.M{"id": id}).One(&data)
+	if err != nil {
+		status = vote.NotFound
+		return
+	}
+
+	w = &data
+	return
+}
+
+// GetWorkspacesFor returns all workspaces stored within the mongo database the given user is a member of.
+func (wm *MongoWorkspaceModel) GetWorkspacesFor(user string) (workspaces []*Workspace, status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	workspaces = make([]*Workspace, 0)
+	c := wm.session.DB(wm.DBName).C("workspaces")
+	err = c.Find(bson.M{"members": user}).All(&workspaces)
+	if err != nil {
+		status = vote.NoConnection
+	}
+
+	return
+}
+
+// NewWorkspace stores the given workspace within the mongo database, assigning it a new ID.
+func (wm *MongoWorkspaceModel) NewWorkspace(w *Workspace) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	w.ID = bson.NewObjectId().Hex()
+	c := wm.session.DB(wm.DBName).C("workspaces")
+	err = c.Insert(w)
+	if err != nil {
+		status = vote.Invalid
+	}
+
+	return
+}
+
+// UpdateWorkspace replaces the workspace stored within the mongo database with the same ID as the given workspace.
+func (wm *MongoWorkspaceModel) UpdateWorkspace(w *Workspace) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := wm.session.DB(wm.DBName).C("workspaces")
+	err = c.Update(bson.M{"id": w.ID}, w)
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// DeleteWorkspace removes the workspace with the given ID from the mongo database.
+func (wm *MongoWorkspaceModel) DeleteWorkspace(id string) (status vote.Status, err error) {
+	err = wm.openSessionIfRequired()
+	if err != nil {
+		status = vote.NoConnection
+		return
+	}
+
+	c := wm.session.DB(wm.DBName).C("workspaces")
+	err = c.Remove(bson.M{"id": id})
+	if err != nil {
+		status = vote.NotFound
+	}
+
+	return
+}
+
+// Close allows the model to be closed properly, ensuring any mongo sessions are properly closed.
+func (wm *MongoWorkspaceModel) Close() (err error) {
+	if wm.session != nil {
+		wm.session.Close()
+	}
+	return
+}
+
+func (wm *MongoWorkspaceModel) openSessionIfRequired() (err error) {
+	return db.OpenSessionIfRequired(&wm.session, wm.URL, wm.Username, wm.Password)
+}
@@ -0,0 +1,35 @@
+package workspace
+
+// Workspace represents a group of users sharing polls, poll templates and a restaurant catalogue. Polls and restaurants within a workspace can only be accessed by its members.
+type Workspace struct {
+	ID      string   `json:"id" bson:"id"`
+	Name    string   `json:"name" bson:"name"`
+	Members []string `json:"members" bson:"members"`
+}
+
+// HasMember states whether the given user is a member of the workspace.
+func (w *Workspace) HasMember(user string) bool {
+	for _, m := range w.Members {
+		if m == user {
+			return true
+		}
+	}
+	return false
+}
+
+// AddMember adds the given user as a member of the workspace should they not already be a member.
+func (w *Workspace) AddMember(user string) {
+	if !w.HasMember(user) {
+		w.Members = append(w.Members, user)
+	}
+}
+
+// RemoveMember removes the given user from the members of the workspace.
+func (w *Workspace) RemoveMember(user string) {
+	for i, m := range w.Members {
+		if m == user {
+			w.Members = append(w.Members[:i], w.Members[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package workspace
+
+import "takeaway/takeaway-server/internal/vote"
+
+var instance *Container
+
+// WorkspaceModel defines a contract for how the system should interact with the database for accessing workspaces.
+type WorkspaceModel interface {
+	// GetWorkspace allows for a singular workspace to be accessed using its ID. Should any issue occur while attempting to access the workspace an error will be returned along with a
+	// status detailing the issue.
+	GetWorkspace(id string) (*Workspace, vote.Status, error)
+	// GetWorkspacesFor returns all workspaces the given user is a member of.
+	GetWorkspacesFor(user string) ([]*Workspace, vote.Status, error)
+	// NewWorkspace stores the given workspace, assigning it a new ID.
+	NewWorkspace(w *Workspace) (vote.Status, error)
+	// UpdateWorkspace replaces the stored workspace with the same ID as the given workspace.
+	UpdateWorkspace(w *Workspace) (vote.Status, error)
+	// DeleteWorkspace attempts to delete the workspace with the given ID. Data belonging to the workspace is not removed, so the DeleteWorkspace handler only deletes workspaces
+	// none of the Container's Holders hold data within.
+	DeleteWorkspace(id string) (vote.Status, error)
+	// Close allows for a WorkspaceModel connection to be closed.
+	Close() error
+}
+
+// Holder reports whether data of the given kind, such as polls, is stored within a workspace.
+type Holder struct {
+	Kind  string
+	Holds func(workspace string) (bool, error)
+}
+
+// Container provides access to injected implementation of WorkspaceModel for the application, along with the Holders checked before a workspace is deleted.
+type Container struct {
+	Model   WorkspaceModel `inject:""`
+	Holders []Holder
+}
+
+// Init allows the workspace package to be initialised with the Container c.
+func Init(c *Container) {
+	instance = c
+}
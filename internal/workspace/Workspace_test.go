@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+func TestAddMember(t *testing.T) {
+	w := &Workspace{Members: []string{"Jack"}}
+	w.AddMember("Tom")
+	w.AddMember("Jack")
+
+	if len(w.Members) != 2 || !w.HasMember("Tom") {
+		t.Fail()
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	w := &Workspace{Members: []string{"Jack", "Tom"}}
+	w.RemoveMember("Jack")
+
+	if w.HasMember("Jack") || !w.HasMember("Tom") {
+		t.Fail()
+	}
+}
+
+func TestRemoveMemberNotAMember(t *testing.T) {
+	w := &Workspace{Members: []string{"Jack"}}
+	w.RemoveMember("Will")
+
+	if len(w.Members) != 1 {
+		t.Fail()
+	}
+}
+
+func TestDeleteWorkspace(t *testing.T) {
+	model := &MockWorkspaceModel{}
+	ws := &Workspace{Name: "office", Members: []string{"Jack"}}
+	model.NewWorkspace(ws)
+
+	polls := true
+	Init(&Container{Model: model, Holders: []Holder{
+		{Kind: "polls", Holds: func(id string) (bool, error) { return polls && id == ws.ID, nil }},
+		{Kind: "webhooks", Holds: func(id string) (bool, error) { return false, nil }},
+	}})
+
+	del := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/workspace?id="+ws.ID, nil)
+		DeleteWorkspace(w, r.WithContext(caller.NewContext(r.Context(), "", "Jack")))
+		return w
+	}
+
+	if w := del(); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "polls") {
+		t.Fatalf("Expected a workspace holding polls not to be deleted, got %v %s", w.Code, w.Body.String())
+	}
+	if _, _, err := model.GetWorkspace(ws.ID); err != nil {
+		t.Fatalf("Expected the workspace to be kept, got %s", err)
+	}
+
+	polls = false
+	if w := del(); w.Code != http.StatusOK {
+		t.Fatalf("Expected an empty workspace to be deleted, got %v %s", w.Code, w.Body.String())
+	}
+	if _, status, _ := model.GetWorkspace(ws.ID); status != vote.NotFound {
+		t.Logf("Expected the workspace to have been deleted, got %v", status)
+		t.Fail()
+	}
+}
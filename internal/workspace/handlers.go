@@ -0,0 +1,199 @@
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+// GetWorkspace provides a http handler for accessing a specified workspace the caller is a member of.
+func GetWorkspace(w http.ResponseWriter, r *http.Request) {
+	ws, ok := memberWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ws)
+}
+
+// GetWorkspaces provides a http handler for listing all workspaces the caller is a member of.
+func GetWorkspaces(w http.ResponseWriter, r *http.Request) {
+	user := caller.User(r.Context())
+	if user == "" {
+		log.Println("No user specified. Returning unauthorised status.")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaces, _, err := instance.Model.GetWorkspacesFor(user)
+	if err != nil {
+		log.Printf("Could not retrieve workspaces for %s due to: %s\n", user, err.Error())
+		http.Error(w, "Could not retrieve workspaces", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, workspaces)
+}
+
+// NewWorkspace provides a http handler for creating a new workspace, with the caller becoming its first member.
+func NewWorkspace(w http.ResponseWriter, r *http.Request) {
+	user := caller.User(r.Context())
+	if user == "" {
+		log.Println("No user specified. Returning unauthorised status.")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	// if request body could not be parsed, return an internal server error to the client.
+	if err != nil {
+		log.Println("Could not read body of request")
+		http.Error(w, "Could not parse request", http.StatusInternalServerError)
+		return
+	}
+
+	var data Workspace
+	err = json.Unmarshal(b, &data)
+	if err != nil || data.Name == "" {
+		log.Printf("Could not parse %s into a workspace", b)
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	data.AddMember(user)
+	status, err := instance.Model.NewWorkspace(&data)
+	if err != nil {
+		log.Printf("Could not create a new workspace due to: %s\n", err.Error())
+		if status == vote.Invalid {
+			http.Error(w, "Supplied workspace invalid", http.StatusBadRequest)
+		} else {
+			http.Error(w, "Workspace could not be created", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("Created workspace with id %v for %s\n", data.ID, user)
+	writeJSON(w, http.StatusCreated, &data)
+}
+
+// DeleteWorkspace provides a http handler for removing a workspace the caller is a member of. Workspaces still holding polls, templates, restaurants or any other data are not
+// removed, a conflict status being returned instead, as their data would otherwise be left behind without a workspace.
+func DeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	ws, ok := memberWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	held := make([]string, 0)
+	for _, h := range instance.Holders {
+		holds, err := h.Holds(ws.ID)
+		if err != nil {
+			log.Printf("Could not check workspace %s for %s due to: %s\n", ws.ID, h.Kind, err.Error())
+			http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+			return
+		}
+		if holds {
+			held = append(held, h.Kind)
+		}
+	}
+	if len(held) > 0 {
+		log.Printf("Could not delete workspace %s as it still holds %s\n", ws.ID, strings.Join(held, ", "))
+		http.Error(w, "Workspace still holds "+strings.Join(held, ", ")+" which must be deleted first", http.StatusConflict)
+		return
+	}
+
+	_, err := instance.Model.DeleteWorkspace(ws.ID)
+	if err != nil {
+		log.Printf("Could not delete workspace %s due to: %s\n", ws.ID, err.Error())
+		http.Error(w, "Could not deal with request", http.StatusInternalServerError)
+		return
+	}
+}
+
+// AddMember provides a http handler allowing a member of a workspace to add the user specified by the 'user' query parameter to the workspace.
+func AddMember(w http.ResponseWriter, r *http.Request) {
+	changeMembers(w, r, (*Workspace).AddMember)
+}
+
+// RemoveMember provides a http handler allowing a member of a workspace to remove the user specified by the 'user' query parameter from the workspace.
+func RemoveMember(w http.ResponseWriter, r *http.Request) {
+	changeMembers(w, r, (*Workspace).RemoveMember)
+}
+
+func changeMembers(w http.ResponseWriter, r *http.Request, change func(*Workspace, string)) {
+	ws, ok := memberWorkspace(w, r)
+	if !ok {
+		return
+	}
+
+	user := r.URL.Query().Get("user")
+	// if no user is specified as a query parameter, return a bad request status.
+	if user == "" {
+		log.Println("No User specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	change(ws, user)
+	_, err := instance.Model.UpdateWorkspace(ws)
+	if err != nil {
+		log.Printf("Could not update members of workspace %s due to: %s\n", ws.ID, err.Error())
+		http.Error(w, "Could not update workspace", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// memberWorkspace returns the workspace specified by the 'id' query parameter, writing an error response and returning false should the workspace not exist or the caller not be
+// one of its members.
+func memberWorkspace(w http.ResponseWriter, r *http.Request) (ws *Workspace, ok bool) {
+	id := r.URL.Query().Get("id")
+	// if no id is specified as a query parameter, return a bad request status.
+	if id == "" {
+		log.Println("No ID specified. Returning bad request status.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ws, status, err := instance.Model.GetWorkspace(id)
+	if err != nil {
+		if status == vote.NotFound {
+			log.Printf("Could not find workspace %s, returning not found status.\n", id)
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			log.Printf("Unable to find workspace due to being unable to connect to the DB.\n")
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// workspaces are reported as not found to non members, avoiding revealing which workspaces exist.
+	if !ws.HasMember(caller.User(r.Context())) {
+		log.Printf("User %s is not a member of workspace %s. Returning not found status.\n", caller.User(r.Context()), id)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("The value %v could not be serialised to JSON.\n", v)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(data)
+}
@@ -0,0 +1,93 @@
+package workspace
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/vote"
+)
+
+const (
+	// WorkspaceHeader is the header a request specifies the workspace it is made within by.
+	WorkspaceHeader = "X-Workspace"
+	// UserHeader is the header a request specifies the user making it by.
+	UserHeader = "X-User"
+)
+
+const (
+	// Unauthorised is the error code of requests to a workspace not specifying a user.
+	Unauthorised = "unauthorised"
+	// Forbidden is the error code of requests to a workspace made by a user who is not a member of said workspace.
+	Forbidden = "forbidden"
+)
+
+// Middleware records the workspace and user a request is made by within the request's context, for use by the handlers of each package through the caller package. The workspace
+// and user are read from the X-Workspace and X-User headers, falling back to the 'workspace' and 'caller' query parameters for clients such as browser websockets that cannot set
+// headers. Requests not specifying a workspace are made within the default workspace, which every user is a member of, while requests to any other workspace are rejected unless
+// made by a member of said workspace.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(WorkspaceHeader)
+		if id == "" {
+			id = r.URL.Query().Get("workspace")
+		}
+
+		user := r.Header.Get(UserHeader)
+		if user == "" {
+			user = r.URL.Query().Get("caller")
+		}
+
+		if code, err := Authorise(id, user); err != nil {
+			log.Printf("Rejecting request by %s to workspace %s due to: %s\n", user, id, err.Error())
+			writeError(w, r, statuses[code], code, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(caller.NewContext(r.Context(), id, user)))
+	})
+}
+
+// statuses maps the error codes returned by Authorise to the http status of the rejected request.
+var statuses = map[string]int{
+	Unauthorised:             http.StatusUnauthorized,
+	Forbidden:                http.StatusForbidden,
+	vote.NotFound.Code():     http.StatusNotFound,
+	vote.NoConnection.Code(): http.StatusInternalServerError,
+}
+
+// Authorise checks the given user may make requests within the workspace with the given ID, returning an error along with its code should they not. Every user may make requests
+// within the default workspace, represented by an empty ID.
+func Authorise(id string, user string) (code string, err error) {
+	if id == "" {
+		return
+	}
+
+	if user == "" {
+		return Unauthorised, fmt.Errorf("a user must be specified to access a workspace")
+	}
+
+	ws, status, err := instance.Model.GetWorkspace(id)
+	if err != nil {
+		if status == vote.NotFound {
+			return status.Code(), fmt.Errorf("workspace not found")
+		}
+		return vote.NoConnection.Code(), fmt.Errorf("could not access workspace")
+	}
+
+	if !ws.HasMember(user) {
+		return Forbidden, fmt.Errorf("user is not a member of the workspace")
+	}
+	return
+}
+
+// writeError writes an error response for a rejected request, using the JSON error envelope for requests made to the v2 API.
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string, message string) {
+	if strings.HasPrefix(r.URL.Path, "/v2/") {
+		vote.WriteError(w, status, code, message)
+		return
+	}
+	http.Error(w, message, status)
+}
@@ -1,88 +1,293 @@
 package main
 
 import (
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
-	"strconv"
+	"os"
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/chatops"
+	"takeaway/takeaway-server/internal/config"
+	"takeaway/takeaway-server/internal/graph"
+	"takeaway/takeaway-server/internal/notify"
+	"takeaway/takeaway-server/internal/recommend"
+	"takeaway/takeaway-server/internal/restaurant"
+	"takeaway/takeaway-server/internal/rpc"
+	"takeaway/takeaway-server/internal/schedule"
+	"takeaway/takeaway-server/internal/stats"
 	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/webhook"
 	"takeaway/takeaway-server/internal/websocket"
+	"takeaway/takeaway-server/internal/workspace"
+	"time"
 
 	"github.com/facebookgo/inject"
-	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 )
 
-var (
-	useMockData   = flag.Bool("useMockData", false, "specify whether or not the server should utilise mock data sources.")
-	mongoPort     = flag.Int("mongoPort", 27017, "specify the port the mongo server is currently running on.")
-	mongoHost     = flag.String("mongoHost", "localhost", "specify the host the mongo server is currently running on.")
-	mongoDB       = flag.String("mongoDB", "takeawayServer", "name of the mongo database where the server should be storing data to.")
-	mongoUsername = flag.String("mongoUsername", "", "username for authenticating with specified mongo database. Can be omitted if authentication is not required.")
-	mongoPassword = flag.String("mongoPassword", "", "password for authenticating with specified mongo datbase. Can be omitted if authentication is not required.")
-)
-
 func main() {
-	flag.Parse()
+	cfg, args, err := config.Load(flag.CommandLine, os.Args[1:], os.LookupEnv)
+	if err != nil {
+		log.Fatalf("Could not load configuration due to: %s\n", err.Error())
+	}
+
+	// the config subcommand prints the configuration rather than starting the server, e.g. 'takeaway-server -config prod.yaml config print'.
+	if len(args) > 0 && args[0] == "config" {
+		if err := runConfig(args[1:], cfg, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
 
 	voteCtx := &vote.Container{}
-	if *useMockData {
+	scheduleCtx := &schedule.Container{}
+	workspaceCtx := &workspace.Container{}
+	catalogueCtx := &catalogue.Container{}
+	webhookCtx := &webhook.Container{}
+	notifyCtx := &notify.Container{}
+	var channels chatops.ChannelModel
+	if cfg.Store == config.Mock {
 		log.Println("utilising mock data.")
-		inject.Populate(voteCtx, &vote.MockPollModel{})
+		inject.Populate(voteCtx, &vote.MockPollModel{}, &vote.MockEventModel{})
+		voteCtx.Reviews = &vote.MockReviewModel{}
+		inject.Populate(scheduleCtx, &schedule.MockTemplateModel{})
+		inject.Populate(workspaceCtx, &workspace.MockWorkspaceModel{})
+		inject.Populate(catalogueCtx, &catalogue.MockRestaurantModel{})
+		inject.Populate(webhookCtx, &webhook.MockWebhookModel{})
+		inject.Populate(notifyCtx, &notify.MockNotificationModel{})
+		channels = &chatops.MockChannelModel{}
+
+		// the mock data is located offline, using the addresses of the mock poll's options.
+		geocoder := &restaurant.StubGeocoder{Locations: map[string]restaurant.Location{
+			"Address 1": {Lat: 51.5072, Lng: -0.1276},
+			"Address 2": {Lat: 51.5155, Lng: -0.1419},
+		}}
+		voteCtx.Geocoder = geocoder
+		catalogueCtx.Geocoder = geocoder
 	} else {
-		log.Printf("using mongo instance at %s on port %v\n", *mongoHost, *mongoPort)
-		if *mongoUsername != "" && *mongoPassword != "" {
-			log.Printf("Auth details: \n Username: %s\n Password: %s\n", *mongoUsername, *mongoPassword)
+		log.Printf("using mongo instance at %s on port %v\n", cfg.Mongo.Host, cfg.Mongo.Port)
+		if cfg.Mongo.Username != "" {
+			log.Printf("authenticating as %s\n", cfg.Mongo.Username)
+		}
+		log.Printf("outputting data to %s\n", cfg.Mongo.Database)
+		polls := &vote.MongoPollModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		}
+		// polls stored before votes were keyed by option ID are migrated before any requests are served.
+		if n, _, err := polls.MigrateVoteKeys(); err != nil {
+			log.Printf("Could not migrate the votes of stored polls due to: %s\n", err.Error())
+		} else if n > 0 {
+			log.Printf("Migrated the votes of %v polls to be keyed by option ID\n", n)
+		}
+		events := &vote.MongoEventModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		}
+		if err := events.EnsureIndexes(); err != nil {
+			log.Printf("Could not index the event log due to: %s\n", err.Error())
+		}
+		inject.Populate(voteCtx, polls, events)
+		voteCtx.Reviews = &vote.MongoReviewModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
 		}
-		log.Printf("outputting data to %s\n", *mongoDB)
-		inject.Populate(voteCtx, &vote.MongoPollModel{
-			URL:      *mongoHost + ":" + strconv.Itoa(*mongoPort),
-			DBName:   *mongoDB,
-			Username: *mongoUsername,
-			Password: *mongoPassword,
+		inject.Populate(scheduleCtx, &schedule.MongoTemplateModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
 		})
+		inject.Populate(workspaceCtx, &workspace.MongoWorkspaceModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		})
+		inject.Populate(catalogueCtx, &catalogue.MongoRestaurantModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		})
+		inject.Populate(webhookCtx, &webhook.MongoWebhookModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		})
+		inject.Populate(notifyCtx, &notify.MongoNotificationModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		})
+		channels = &chatops.MongoChannelModel{
+			URL:      cfg.Mongo.URL(),
+			DBName:   cfg.Mongo.Database,
+			Username: cfg.Mongo.Username,
+			Password: cfg.Mongo.Password,
+		}
 	}
 
+	recommender := &recommend.Recommender{Model: voteCtx.Model}
+	recommend.Init(recommender)
+	voteCtx.Suggester = recommender
+
+	// every change made to a poll is delivered to the webhooks of its workspace.
+	webhookCtx.Dispatcher = &webhook.Dispatcher{Model: webhookCtx.Model}
+	voteCtx.Publisher = webhookCtx.Dispatcher
+
+	notifyCtx.Notifier = &notify.Notifier{
+		Polls:        voteCtx.Model,
+		Model:        notifyCtx.Model,
+		Channels:     notificationChannels(cfg.Notifications),
+		RemindBefore: cfg.Notifications.RemindBefore,
+	}
+
+	stats.Init(&stats.Analyser{Polls: voteCtx.Model, Events: voteCtx.Events, Reviews: voteCtx.Reviews})
 	vote.Init(voteCtx)
+	graph.Init(&graph.Resolver{Restaurants: catalogueCtx.Model, Hub: websocket.HubInstance})
+	schedule.Init(scheduleCtx)
+	workspaceCtx.Holders = workspaceHolders(voteCtx.Model, scheduleCtx.Model, catalogueCtx.Model, webhookCtx.Model, notifyCtx.Model)
+	workspace.Init(workspaceCtx)
+	webhook.Init(webhookCtx)
+	notify.Init(notifyCtx)
+	catalogue.Init(catalogueCtx)
+	chatops.Init(&chatops.Integration{
+		SigningSecret: cfg.Chat.SigningSecret,
+		Token:         cfg.Chat.Token,
+		Channels:      channels,
+		Restaurants:   catalogueCtx.Model,
+		Poster:        &chatops.WebhookPoster{},
+	})
+
+	// the import subcommand imports restaurants into the catalogue rather than starting the server, e.g. 'takeaway-server -mongoHost db import restaurants.csv'.
+	if len(args) > 0 && args[0] == "import" {
+		if err := runImport(args[1:], os.Stdout); err != nil {
+			log.Fatalf("Could not import restaurants due to: %s\n", err.Error())
+		}
+		return
+	}
+
+	scheduler := &schedule.Scheduler{
+		Templates: scheduleCtx.Model,
+		Polls:     voteCtx.Model,
+	}
+	go scheduler.Run(make(chan struct{}))
+
+	closer := &vote.Closer{}
+	go closer.Run(make(chan struct{}))
+
+	go webhookCtx.Dispatcher.Run(make(chan struct{}))
+	go notifyCtx.Notifier.Run(make(chan struct{}))
 
 	hub := websocket.HubInstance
 	go hub.Run()
 
-	r := mux.NewRouter().StrictSlash(true)
-	r.HandleFunc("/poll", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			vote.GetPoll(w, r)
-		case http.MethodPut:
-			vote.NewPoll(w, r)
-		case http.MethodPost:
-			vote.UpdatePoll(w, r)
-		case http.MethodDelete:
-			vote.DeletePoll(w, r)
-		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
-		}
-	})
-	r.HandleFunc("/vote", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			vote.AddVote(w, r)
-		case http.MethodDelete:
-			vote.RemoveUser(w, r)
-		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+	r := newRouter(hub, voteCtx.Model)
+
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled() {
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			log.Fatalf("Could not load the TLS certificate due to: %s\n", err.Error())
 		}
-	})
-	r.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		websocket.HandleWs(hub, w, r)
-	})
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		log.Fatalf("Could not listen for gRPC requests on %s due to: %s\n", cfg.GRPC.Addr, err.Error())
+	}
+	if tlsConfig != nil {
+		// gRPC is served over HTTP/2, which must be negotiated during the TLS handshake.
+		grpcTLS := tlsConfig.Clone()
+		grpcTLS.NextProtos = []string{"h2"}
+		lis = tls.NewListener(lis, grpcTLS)
+	}
+	go func() {
+		log.Fatal(rpc.Serve(lis, hub))
+	}()
 
-	fmt.Println("Starting server on port 8080. Press ctrl + C to stop it.......")
+	fmt.Printf("Starting server on %s. Press ctrl + C to stop it.......\n", cfg.HTTP.Addr)
 
 	handler := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedOrigins: cfg.HTTP.CORSOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowedHeaders: []string{"Content-Type", workspace.WorkspaceHeader, workspace.UserHeader},
 	}).Handler(r)
-	log.Fatal(http.ListenAndServe(":8080", handler))
+	server := &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	if tlsConfig != nil {
+		log.Fatal(server.ListenAndServeTLS("", ""))
+	}
+	log.Fatal(server.ListenAndServe())
+}
+
+// workspaceHolders returns the data a workspace must no longer hold before it can be deleted.
+func workspaceHolders(polls vote.PollModel, templates schedule.TemplateModel, restaurants catalogue.RestaurantModel, webhooks webhook.WebhookModel, contacts notify.NotificationModel) []workspace.Holder {
+	return []workspace.Holder{
+		{Kind: "polls", Holds: func(ws string) (bool, error) {
+			p, _, err := polls.GetPolls(ws, time.Time{})
+			return len(p) > 0, err
+		}},
+		{Kind: "templates", Holds: func(ws string) (bool, error) {
+			t, _, err := templates.GetTemplates(ws)
+			return len(t) > 0, err
+		}},
+		{Kind: "restaurants", Holds: func(ws string) (bool, error) {
+			r, _, err := restaurants.GetRestaurants(ws)
+			return len(r) > 0, err
+		}},
+		{Kind: "webhooks", Holds: func(ws string) (bool, error) {
+			s, _, err := webhooks.GetSubscriptions(ws)
+			return len(s) > 0, err
+		}},
+		{Kind: "contacts", Holds: func(ws string) (bool, error) {
+			c, _, err := contacts.GetContacts(ws)
+			return len(c) > 0, err
+		}},
+	}
+}
+
+// notificationChannels returns the channels notifications may be sent through, email only being available should an SMTP server be given.
+func notificationChannels(nc config.NotifyConfig) map[string]notify.Channel {
+	push := &notify.PushChannel{Subject: nc.VAPIDSubject}
+	if nc.VAPIDPrivateKey != "" {
+		key, err := notify.ParseVAPIDKey(nc.VAPIDPrivateKey)
+		if err != nil {
+			log.Fatalf("Could not parse the VAPID private key due to: %s\n", err.Error())
+		}
+		push.Key = key
+	}
+
+	channels := map[string]notify.Channel{
+		notify.Push:    push,
+		notify.Webhook: &notify.WebhookChannel{},
+		notify.Log:     &notify.LogChannel{Path: nc.Log},
+	}
+	if nc.SMTP.Addr != "" {
+		channels[notify.Email] = &notify.SMTPChannel{Addr: nc.SMTP.Addr, From: nc.SMTP.From, Username: nc.SMTP.Username, Password: nc.SMTP.Password}
+	}
+	return channels
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/chatops"
+	"takeaway/takeaway-server/internal/notify"
+	"takeaway/takeaway-server/internal/openapi"
+	"takeaway/takeaway-server/internal/recommend"
+	"takeaway/takeaway-server/internal/schedule"
+	"takeaway/takeaway-server/internal/stats"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/webhook"
+	"takeaway/takeaway-server/internal/websocket"
+	"takeaway/takeaway-server/internal/workspace"
+
+	"github.com/gorilla/mux"
+)
+
+func testRouter() *mux.Router {
+	polls := &vote.MockPollModel{}
+	events := &vote.MockEventModel{}
+
+	recommender := &recommend.Recommender{Model: polls}
+	recommend.Init(recommender)
+	stats.Init(&stats.Analyser{Polls: polls, Events: events})
+	vote.Init(&vote.Container{Model: polls, Events: events, Suggester: recommender})
+	schedule.Init(&schedule.Container{Model: &schedule.MockTemplateModel{}})
+	workspace.Init(&workspace.Container{Model: &workspace.MockWorkspaceModel{}})
+	notifications := &notify.MockNotificationModel{}
+	notify.Init(&notify.Container{Model: notifications, Notifier: &notify.Notifier{Polls: polls, Model: notifications, Channels: map[string]notify.Channel{notify.Log: &notify.LogChannel{}}}})
+	webhooks := &webhook.MockWebhookModel{}
+	webhook.Init(&webhook.Container{Model: webhooks, Dispatcher: &webhook.Dispatcher{Model: webhooks}})
+	catalogue.Init(&catalogue.Container{Model: &catalogue.MockRestaurantModel{}})
+	chatops.Init(&chatops.Integration{Token: "token", Channels: &chatops.MockChannelModel{}, Poster: &chatops.StubPoster{}})
+
+	hub := websocket.HubInstance
+	go hub.Run()
+	return newRouter(hub, polls)
+}
+
+// TestRoutesDocumented ensures every route registered by the router is described by the OpenAPI document, and every path within the document is registered by the router.
+func TestRoutesDocumented(t *testing.T) {
+	spec := openapi.Spec()
+	registered := make(map[string]bool)
+
+	newRouter(websocket.HubInstance, &vote.MockPollModel{}).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || route.GetHandler() == nil {
+			return nil
+		}
+
+		registered[path] = true
+		if _, ok := spec.Paths[path]; !ok {
+			t.Logf("The route %s is not described by the OpenAPI document", path)
+			t.Fail()
+		}
+		return nil
+	})
+
+	for path := range spec.Paths {
+		if !registered[path] {
+			t.Logf("The path %s within the OpenAPI document is not registered by the router", path)
+			t.Fail()
+		}
+	}
+}
+
+// TestContract exercises the handlers through the router, checking each response's status code is documented and its body matches the documented schema.
+func TestContract(t *testing.T) {
+	r := testRouter()
+	spec := openapi.Spec()
+
+	restaurant := `{"id": "r1", "name": "Restaurant 1", "address": "Address 1"}`
+
+	for _, c := range []struct {
+		method string
+		path   string
+		url    string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/poll", "/poll?id=test", "", http.StatusOK},
+		{http.MethodGet, "/poll", "/poll?id=unknown", "", http.StatusNotFound},
+		{http.MethodPut, "/poll", "/poll", "[" + restaurant + "]", http.StatusCreated},
+		{http.MethodPost, "/vote", "/vote?id=new+poll", `{"user": "Jack", "restaurant_ID": "r1"}`, http.StatusAccepted},
+		{http.MethodGet, "/polls", "/polls?participant=Jack", "", http.StatusOK},
+		{http.MethodGet, "/polls", "/polls?sort=name", "", http.StatusBadRequest},
+		{http.MethodGet, "/poll/history", "/poll/history?id=new+poll", "", http.StatusOK},
+		{http.MethodGet, "/v2/polls", "/v2/polls", "", http.StatusOK},
+		{http.MethodGet, "/v2/polls/{id}", "/v2/polls/new%20poll", "", http.StatusOK},
+		{http.MethodGet, "/v2/polls/{id}", "/v2/polls/unknown", "", http.StatusNotFound},
+		{http.MethodPut, "/v2/polls/{id}/votes/{user}", "/v2/polls/new%20poll/votes/Tom", `{"optionId": "r1"}`, http.StatusOK},
+		{http.MethodPut, "/v2/polls/{id}/votes/{user}", "/v2/polls/new%20poll/votes/Tom", `{"optionId": 1}`, http.StatusBadRequest},
+		{http.MethodGet, "/v2/polls/{id}/votes/{user}", "/v2/polls/new%20poll/votes/Tom", "", http.StatusOK},
+		{http.MethodPost, "/v2/polls/{id}/options", "/v2/polls/new%20poll/options", `{"id": "r2", "name": "Restaurant 2"}`, http.StatusCreated},
+		{http.MethodGet, "/v2/polls/{id}/options/{optionId}", "/v2/polls/new%20poll/options/r2", "", http.StatusOK},
+		{http.MethodPatch, "/v2/polls/{id}/options/{optionId}", "/v2/polls/new%20poll/options/r2", `{"address": "Address 2"}`, http.StatusOK},
+		{http.MethodDelete, "/v2/polls/{id}/options/{optionId}", "/v2/polls/new%20poll/options/r1", "", http.StatusConflict},
+		{http.MethodDelete, "/v2/polls/{id}/options/{optionId}", "/v2/polls/new%20poll/options/r2", "", http.StatusOK},
+		{http.MethodPost, "/poll/options", "/poll/options?id=new+poll", `{"id": "r3", "name": "Restaurant 3"}`, http.StatusCreated},
+		{http.MethodPost, "/poll/options/approve", "/poll/options/approve?id=new+poll&option=r3", "", http.StatusNotFound},
+		{http.MethodDelete, "/poll/options", "/poll/options?id=new+poll&option=r3", "", http.StatusOK},
+		{http.MethodPut, "/v2/polls/{id}/rights/{user}", "/v2/polls/new%20poll/rights/Tom", `{"weight": 2, "vetoes": 1}`, http.StatusOK},
+		{http.MethodPut, "/v2/polls/{id}/vetoes/{optionId}", "/v2/polls/new%20poll/vetoes/r1", "", http.StatusForbidden},
+		{http.MethodPut, "/v2/polls/{id}/rules", "/v2/polls/new%20poll/rules", `{"quorum": 1, "margin": 1}`, http.StatusOK},
+		{http.MethodPut, "/restaurant", "/restaurant", restaurant, http.StatusCreated},
+		{http.MethodGet, "/restaurants", "/restaurants", "", http.StatusOK},
+		{http.MethodGet, "/restaurants/suggest", "/restaurants/suggest?n=2", "", http.StatusOK},
+		{http.MethodPut, "/template", "/template", `{"name": "Lunch", "schedule": "0 12 * * 1-5", "options": [` + restaurant + `]}`, http.StatusCreated},
+		{http.MethodGet, "/templates", "/templates", "", http.StatusOK},
+		{http.MethodPut, "/workspace", "/workspace", `{"name": "Team"}`, http.StatusCreated},
+		{http.MethodGet, "/workspaces", "/workspaces", "", http.StatusOK},
+		{http.MethodPut, "/notifications/contact", "/notifications/contact", `{"endpoints": [{"channel": "log", "address": "Jack"}]}`, http.StatusOK},
+		{http.MethodPut, "/notifications/contact", "/notifications/contact", `{"endpoints": [{"channel": "email", "address": "jack@example.com"}]}`, http.StatusBadRequest},
+		{http.MethodGet, "/notifications/contact", "/notifications/contact", "", http.StatusOK},
+		{http.MethodGet, "/notifications", "/notifications", "", http.StatusOK},
+		{http.MethodPut, "/webhook", "/webhook", `{"url": "https://bots.example.com/lunch", "events": ["poll_created", "poll_decided"]}`, http.StatusCreated},
+		{http.MethodPut, "/webhook", "/webhook", `{"url": "bots", "events": []}`, http.StatusBadRequest},
+		{http.MethodGet, "/webhook", "/webhook?id=webhook1", "", http.StatusOK},
+		{http.MethodGet, "/webhooks", "/webhooks", "", http.StatusOK},
+		{http.MethodGet, "/webhook/deliveries", "/webhook/deliveries?id=webhook1&state=failed", "", http.StatusOK},
+		{http.MethodPost, "/webhook/deliveries/retry", "/webhook/deliveries/retry?id=unknown", "", http.StatusNotFound},
+		{http.MethodGet, "/stats", "/stats", "", http.StatusOK},
+		{http.MethodPost, "/chat/commands", "/chat/commands", "token=token&channel_id=C1&user_name=Jack&text=status", http.StatusOK},
+		{http.MethodPost, "/chat/commands", "/chat/commands", "token=wrong&channel_id=C1&user_name=Jack&text=status", http.StatusUnauthorized},
+		{http.MethodGet, "/openapi.json", "/openapi.json", "", http.StatusOK},
+		{http.MethodDelete, "/v2/polls/{id}", "/v2/polls/new%20poll", "", http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(c.method, c.url, strings.NewReader(c.body))
+		req.Header.Set(workspace.UserHeader, "Jack")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != c.status {
+			t.Logf("Expected %s %s to return %v, got %v: %s", c.method, c.url, c.status, w.Code, w.Body.String())
+			t.Fail()
+			continue
+		}
+
+		op := spec.Operation(c.method, c.path)
+		if op == nil {
+			t.Logf("%s %s is not described by the OpenAPI document", c.method, c.path)
+			t.Fail()
+			continue
+		}
+
+		res, ok := op.Responses[strconv.Itoa(w.Code)]
+		if !ok {
+			t.Logf("The %v response of %s %s is not described by the OpenAPI document", w.Code, c.method, c.path)
+			t.Fail()
+			continue
+		}
+
+		if res.Content == nil {
+			continue
+		}
+
+		var body interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Logf("The response of %s %s is not valid JSON: %s", c.method, c.url, w.Body.String())
+			t.Fail()
+			continue
+		}
+
+		if err := spec.Schemas().Validate(res.Content["application/json"].Schema, body); err != nil {
+			t.Logf("The response of %s %s does not match the OpenAPI document: %s", c.method, c.url, err.Error())
+			t.Fail()
+		}
+	}
+}
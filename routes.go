@@ -0,0 +1,439 @@
+package main
+
+import (
+	"net/http"
+	"takeaway/takeaway-server/internal/caller"
+	"takeaway/takeaway-server/internal/catalogue"
+	"takeaway/takeaway-server/internal/chatops"
+	"takeaway/takeaway-server/internal/graph"
+	"takeaway/takeaway-server/internal/notify"
+	"takeaway/takeaway-server/internal/openapi"
+	"takeaway/takeaway-server/internal/recommend"
+	"takeaway/takeaway-server/internal/schedule"
+	"takeaway/takeaway-server/internal/stats"
+	"takeaway/takeaway-server/internal/vote"
+	"takeaway/takeaway-server/internal/webhook"
+	"takeaway/takeaway-server/internal/websocket"
+	"takeaway/takeaway-server/internal/workspace"
+
+	"github.com/gorilla/mux"
+)
+
+// newRouter creates the router serving every route of the server, using the given hub for websocket connections and the given PollModel to check polls exist before they are
+// watched. Every route is documented by openapi.Spec, which the tests check against the routes registered here.
+func newRouter(hub *websocket.Hub, polls vote.PollModel) *mux.Router {
+	r := mux.NewRouter().StrictSlash(true)
+	r.Use(workspace.Middleware)
+	r.HandleFunc("/poll", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetPoll(w, r)
+		case http.MethodPut:
+			vote.NewPoll(w, r)
+		case http.MethodPost:
+			vote.UpdatePoll(w, r)
+		case http.MethodDelete:
+			vote.DeletePoll(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/polls", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.ListPolls(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/history", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetHistory(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/export", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.ExportPoll(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/rollback", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.RollbackPoll(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/options", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.AddOption(w, r)
+		case http.MethodDelete:
+			vote.RemoveOption(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/options/approve", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.ApproveOption(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/rights", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.SetRights(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/rules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.SetRules(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetReviews(w, r)
+		case http.MethodPost:
+			vote.AddReview(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/poll/veto", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.AddVeto(w, r)
+		case http.MethodDelete:
+			vote.RemoveVeto(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/template", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			schedule.GetTemplate(w, r)
+		case http.MethodPut:
+			schedule.NewTemplate(w, r)
+		case http.MethodPost:
+			schedule.UpdateTemplate(w, r)
+		case http.MethodDelete:
+			schedule.DeleteTemplate(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/templates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			schedule.GetTemplates(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/notifications", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			notify.GetNotifications(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/notifications/contact", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			notify.GetContact(w, r)
+		case http.MethodPut:
+			notify.SetContact(w, r)
+		case http.MethodDelete:
+			notify.DeleteContact(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			webhook.GetSubscription(w, r)
+		case http.MethodPut:
+			webhook.NewSubscription(w, r)
+		case http.MethodDelete:
+			webhook.DeleteSubscription(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			webhook.GetSubscriptions(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/webhook/deliveries", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			webhook.GetDeliveries(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/webhook/deliveries/retry", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			webhook.RetryDelivery(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/workspace", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			workspace.GetWorkspace(w, r)
+		case http.MethodPut:
+			workspace.NewWorkspace(w, r)
+		case http.MethodDelete:
+			workspace.DeleteWorkspace(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			workspace.GetWorkspaces(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/workspace/members", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			workspace.AddMember(w, r)
+		case http.MethodDelete:
+			workspace.RemoveMember(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/restaurant", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			catalogue.GetRestaurant(w, r)
+		case http.MethodPut:
+			catalogue.NewRestaurant(w, r)
+		case http.MethodPost:
+			catalogue.UpdateRestaurant(w, r)
+		case http.MethodDelete:
+			catalogue.DeleteRestaurant(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/restaurants", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			catalogue.GetRestaurants(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/restaurants/import", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			catalogue.ImportRestaurants(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/restaurants/suggest", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			recommend.GetSuggestions(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			stats.GetReport(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/vote", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.AddVote(w, r)
+		case http.MethodDelete:
+			vote.RemoveUser(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/chat/commands", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			chatops.SlashCommand(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/chat/actions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			chatops.Interact(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		// the GraphQL handler serves queries and mutations along with subscriptions over websockets.
+		graph.ServeGraphQL(w, r)
+	})
+	r.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			openapi.GetSpec(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	r.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		// only allow clients to watch polls within their own workspace.
+		if _, _, err := polls.GetPoll(caller.Workspace(r.Context()), r.URL.Query().Get("id")); err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		websocket.HandleWs(hub, w, r)
+	})
+
+	// the v2 API addresses polls as resources, returning every error as a JSON error envelope.
+	v2 := r.PathPrefix("/v2").Subrouter()
+	v2.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vote.WriteError(w, http.StatusNotFound, vote.NotFound.Code(), r.URL.Path+" could not be found")
+	})
+	v2.HandleFunc("/polls", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.ListPollsV2(w, r)
+		case http.MethodPost:
+			vote.CreatePollV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetPollV2(w, r)
+		case http.MethodPut:
+			vote.UpdatePollV2(w, r)
+		case http.MethodDelete:
+			vote.DeletePollV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/votes/{user}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetVoteV2(w, r)
+		case http.MethodPut:
+			vote.CastVoteV2(w, r)
+		case http.MethodDelete:
+			vote.DeleteVoteV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/options", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.AddOptionV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/options/{optionId}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetOptionV2(w, r)
+		case http.MethodPatch:
+			vote.RenameOptionV2(w, r)
+		case http.MethodDelete:
+			vote.DeleteOptionV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/options/{optionId}/approve", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			vote.ApproveOptionV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/rights/{user}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.SetRightsV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/rules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.SetRulesV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			vote.GetReviewsV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/reviews/{user}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.ReviewPollV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+	v2.HandleFunc("/polls/{id}/vetoes/{optionId}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			vote.VetoOptionV2(w, r)
+		case http.MethodDelete:
+			vote.WithdrawVetoV2(w, r)
+		default:
+			vote.WriteMethodNotAllowed(w, r)
+		}
+	})
+
+	return r
+}